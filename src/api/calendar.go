@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	generator "tltui/src/elm-store/calendar/report-generator"
+)
+
+// calendarFeedPath serves the iCalendar feed. Calendar apps subscribe by URL and
+// cannot send headers, so it also takes the token as ?token=.
+const calendarFeedPath = "/api/calendar.ics"
+
+// getCalendar returns the workhours as an iCalendar feed, from three months back to
+// three months ahead unless ?from= and ?to= are given. ?project_id= and
+// ?details_id= take comma-separated IDs.
+func (s *Server) getCalendar(w http.ResponseWriter, r *http.Request) {
+	today := time.Now()
+	from := time.Date(today.Year(), today.Month()-3, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(today.Year(), today.Month()+4, 0, 0, 0, 0, 0, time.Local)
+	if r.URL.Query().Get("from") != "" {
+		var err error
+		if from, to, err = parseRange(r); err != nil {
+			writeResult(w, 0, nil, err)
+			return
+		}
+	}
+
+	var filter domain.ICSFilter
+	var err error
+	if filter.ProjectIDs, err = parseIDList(r, "project_id"); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	if filter.DetailsIDs, err = parseIDList(r, "details_id"); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := generator.BuildICS(w, from, to, filter); err != nil {
+		writeResult(w, 0, nil, err)
+	}
+}
+
+// parseIDList reads a query parameter of comma-separated IDs
+func parseIDList(r *http.Request, name string) ([]int, error) {
+	var ids []int
+	for _, value := range strings.Split(r.URL.Query().Get(name), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return nil, &common.ValidationError{Field: name, Message: fmt.Sprintf("%s must be comma-separated IDs, got %q", name, value)}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
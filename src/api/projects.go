@@ -0,0 +1,159 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// projectJSON is the API form of a project, see the "project" schema
+type projectJSON struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	OdooID       int     `json:"odoo_id"`
+	ClientID     int     `json:"client_id"`
+	BudgetHours  float64 `json:"budget_hours"`
+	BudgetPeriod int     `json:"budget_period"`
+}
+
+func toProjectJSON(p domain.Project) projectJSON {
+	return projectJSON{
+		ID:           p.ID,
+		Name:         p.Name,
+		OdooID:       p.OdooID,
+		ClientID:     p.ClientID,
+		BudgetHours:  p.BudgetHours,
+		BudgetPeriod: int(p.BudgetPeriod),
+	}
+}
+
+// project validates the input with the rules of the project form
+func (p projectJSON) project(id int) (domain.Project, error) {
+	name := strings.TrimSpace(p.Name)
+	validate := common.ChainValidators(
+		common.MinLengthValidator("name", 2),
+		common.MaxLengthValidator("name", 50),
+	)
+	if err := validate(name); err != nil {
+		return domain.Project{}, err
+	}
+	if err := common.PositiveIntValidator("odoo_id")(strconv.Itoa(p.OdooID)); err != nil {
+		return domain.Project{}, err
+	}
+	if p.BudgetHours < 0 {
+		return domain.Project{}, &common.ValidationError{Field: "budget_hours", Message: "budget_hours must not be negative"}
+	}
+	period := domain.BudgetPeriod(p.BudgetPeriod)
+	if period < domain.BudgetPeriodTotal || period > domain.BudgetPeriodQuarterly {
+		return domain.Project{}, &common.ValidationError{Field: "budget_period", Message: "budget_period must be 0 (total), 1 (monthly) or 2 (quarterly)"}
+	}
+	if p.ClientID != 0 {
+		client, err := repository.GetClientByID(p.ClientID)
+		if err != nil {
+			return domain.Project{}, err
+		}
+		if client == nil {
+			return domain.Project{}, &common.ValidationError{Field: "client_id", Message: fmt.Sprintf("client %d does not exist", p.ClientID)}
+		}
+	}
+
+	return domain.Project{
+		ID:           id,
+		Name:         name,
+		OdooID:       p.OdooID,
+		ClientID:     p.ClientID,
+		BudgetHours:  p.BudgetHours,
+		BudgetPeriod: period,
+	}, nil
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := repository.GetAllProjectsFromDB()
+	result := make([]projectJSON, 0, len(projects))
+	for _, p := range projects {
+		result = append(result, toProjectJSON(p))
+	}
+	writeResult(w, http.StatusOK, result, err)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	project, err := findProject(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProjectJSON(*project))
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var input projectJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	// New projects take the next free ID, like in the projects view
+	s.newID.Lock()
+	defer s.newID.Unlock()
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	nextID := 1
+	for _, p := range projects {
+		nextID = max(nextID, p.ID+1)
+	}
+
+	project, err := input.project(nextID)
+	if err == nil {
+		err = repository.CreateProject(project)
+	}
+	writeResult(w, http.StatusCreated, toProjectJSON(project), err)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	stored, err := findProject(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	var input projectJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	project, err := input.project(stored.ID)
+	if err == nil {
+		err = repository.UpdateProject(project)
+	}
+	writeResult(w, http.StatusOK, toProjectJSON(project), err)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	project, err := findProject(r)
+	if err == nil {
+		err = repository.DeleteProject(project.ID)
+	}
+	writeResult(w, http.StatusNoContent, nil, err)
+}
+
+func findProject(r *http.Request) (*domain.Project, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	project, err := repository.GetProjectByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project %d %w", id, errNotFound)
+	}
+	return project, nil
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	generator "tltui/src/elm-store/calendar/report-generator"
+)
+
+// reportRequestJSON selects the report to generate, see the "report_request" schema.
+// The company fields and selection only apply to mail reports, the client to mail
+// reports and summaries.
+type reportRequestJSON struct {
+	Kind        string                     `json:"kind"`
+	Year        int                        `json:"year"`
+	Month       int                        `json:"month"`
+	ClientID    int                        `json:"client_id,omitempty"`
+	FromCompany string                     `json:"from_company,omitempty"`
+	ToCompany   string                     `json:"to_company,omitempty"`
+	InvoiceName string                     `json:"invoice_name,omitempty"`
+	Selected    map[string]map[string]bool `json:"selected,omitempty"` // project -> activity -> included
+}
+
+// generateReport builds a report like the report generator does and returns the file
+func (s *Server) generateReport(w http.ResponseWriter, r *http.Request) {
+	var input reportRequestJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	if input.Year < 1900 || input.Year > 9999 {
+		writeResult(w, 0, nil, &common.ValidationError{Field: "year", Message: "year must be between 1900 and 9999"})
+		return
+	}
+	if input.Month < 1 || input.Month > 12 {
+		writeResult(w, 0, nil, &common.ValidationError{Field: "month", Message: "month must be between 1 and 12"})
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "tltui-report-")
+	if err != nil {
+		writeResult(w, 0, nil, fmt.Errorf("failed to create temp directory: %w", err))
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	var filePath, contentType string
+	switch domain.ReportKind(input.Kind) {
+	case domain.ReportKindOdooCSV:
+		filePath = filepath.Join(dir, fmt.Sprintf("odoo_timesheet_%s_%d.csv", time.Month(input.Month), input.Year))
+		contentType = "text/csv"
+		err = generator.BuildOdooCSVReport(filePath, input.Month, input.Year)
+	case domain.ReportKindMailReport:
+		contentType = "application/pdf"
+		filePath, err = buildMailReport(dir, input)
+	case domain.ReportKindXLSX:
+		filePath = filepath.Join(dir, generator.XLSXReportFileName(input.Month, input.Year))
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		err = generator.BuildXLSXReport(filePath, input.Month, input.Year)
+	case domain.ReportKindMarkdown:
+		contentType = "text/markdown; charset=utf-8"
+		filePath, err = buildSummary(dir, generator.SummaryFormatMarkdown, input)
+	case domain.ReportKindHTML:
+		contentType = "text/html; charset=utf-8"
+		filePath, err = buildSummary(dir, generator.SummaryFormatHTML, input)
+	default:
+		err = &common.ValidationError{Field: "kind", Message: fmt.Sprintf("kind must be one of %q, %q, %q, %q or %q",
+			domain.ReportKindOdooCSV, domain.ReportKindMailReport, domain.ReportKindXLSX, domain.ReportKindMarkdown, domain.ReportKindHTML)}
+	}
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(filePath)))
+	http.ServeFile(w, r, filePath)
+}
+
+// buildMailReport writes the PDF report into dir, filling in the defaults of the
+// report generator for the fields left out
+func buildMailReport(dir string, input reportRequestJSON) (string, error) {
+	var client *domain.Client
+	if input.ClientID != 0 {
+		var err error
+		if client, err = repository.GetClientByID(input.ClientID); err != nil {
+			return "", err
+		}
+		if client == nil {
+			return "", &common.ValidationError{Field: "client_id", Message: fmt.Sprintf("client %d does not exist", input.ClientID)}
+		}
+	}
+
+	fromCompany := strings.TrimSpace(input.FromCompany)
+	if fromCompany == "" {
+		return "", &common.ValidationError{Field: "from_company", Message: "from_company is required"}
+	}
+	toCompany := strings.TrimSpace(input.ToCompany)
+	if toCompany == "" && client != nil {
+		toCompany = client.DisplayLegalName()
+	}
+	if toCompany == "" {
+		return "", &common.ValidationError{Field: "to_company", Message: "to_company is required"}
+	}
+
+	selected := input.Selected
+	if selected == nil {
+		start := time.Date(input.Year, time.Month(input.Month), 1, 0, 0, 0, 0, time.Local)
+		end := start.AddDate(0, 1, -1)
+		stats, err := generator.LoadStats(start, end, input.ClientID)
+		if err != nil {
+			return "", err
+		}
+		workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+		if err != nil {
+			return "", err
+		}
+		selected = generator.DefaultSelectedItems(stats, workhourDetails)
+	}
+
+	filePath := filepath.Join(dir, generator.MailReportFileName(input.Month, input.Year, client))
+	err := generator.BuildMailReport(filePath, input.Month, input.Year, fromCompany, toCompany, strings.TrimSpace(input.InvoiceName), "", selected, client)
+	return filePath, err
+}
+
+// buildSummary writes the Markdown or HTML summary into dir, limited to a client when one is given
+func buildSummary(dir string, format generator.SummaryFormat, input reportRequestJSON) (string, error) {
+	var client *domain.Client
+	if input.ClientID != 0 {
+		var err error
+		if client, err = repository.GetClientByID(input.ClientID); err != nil {
+			return "", err
+		}
+		if client == nil {
+			return "", &common.ValidationError{Field: "client_id", Message: fmt.Sprintf("client %d does not exist", input.ClientID)}
+		}
+	}
+
+	content, err := generator.BuildSummary(format, input.Month, input.Year, client)
+	if err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(dir, generator.SummaryFileName(format, input.Month, input.Year, client))
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write summary: %w", err)
+	}
+	return filePath, nil
+}
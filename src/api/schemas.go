@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// schemas describes the request and response bodies of the API as JSON Schema
+var schemas = map[string]json.RawMessage{
+	"project": json.RawMessage(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "project",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer", "readOnly": true},
+    "name": {"type": "string", "minLength": 2, "maxLength": 50},
+    "odoo_id": {"type": "integer", "minimum": 1},
+    "client_id": {"type": "integer", "minimum": 0, "description": "0 when the project has no client"},
+    "budget_hours": {"type": "number", "minimum": 0, "description": "0 when the project has no budget"},
+    "budget_period": {"type": "integer", "enum": [0, 1, 2], "description": "0 total, 1 monthly, 2 quarterly"}
+  },
+  "required": ["name", "odoo_id"],
+  "additionalProperties": false
+}`),
+	"workhour_details": json.RawMessage(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "workhour_details",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer", "readOnly": true},
+    "name": {"type": "string", "minLength": 1},
+    "short_name": {"type": "string", "minLength": 1, "maxLength": 20},
+    "is_work": {"type": "boolean"},
+    "overtime_role": {"type": "integer", "enum": [0, 1, 2], "description": "0 none, 1 overtime, 2 time off in lieu"}
+  },
+  "required": ["name", "short_name"],
+  "additionalProperties": false
+}`),
+	"workhour": json.RawMessage(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "workhour",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer", "readOnly": true},
+    "uuid": {"type": "string", "readOnly": true},
+    "date": {"type": "string", "format": "date"},
+    "details_id": {"type": "integer", "minimum": 1},
+    "project_id": {"type": "integer", "minimum": 1},
+    "task_id": {"type": "integer", "minimum": 0, "description": "0 or absent when no task is set"},
+    "hours": {"type": "number", "exclusiveMinimum": 0, "maximum": 24, "description": "Rounded like in the TUI; computed from start and end when they are set"},
+    "start": {"type": "string", "pattern": "^[0-9]{1,2}(:[0-9]{2})?$"},
+    "end": {"type": "string", "pattern": "^[0-9]{1,2}(:[0-9]{2})?$"},
+    "break_minutes": {"type": "integer", "minimum": 0},
+    "issue_key": {"type": "string", "maxLength": 200, "description": "Issue the hours are pushed to, e.g. API-12 for Jira or group/api#12 for GitLab"},
+    "warnings": {"type": "array", "items": {"type": "string"}, "readOnly": true, "description": "Budget thresholds the write made a project cross"}
+  },
+  "required": ["date", "details_id", "project_id"],
+  "dependentRequired": {"start": ["end"], "end": ["start"], "break_minutes": ["start", "end"]},
+  "additionalProperties": false
+}`),
+	"stats": json.RawMessage(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "stats",
+  "type": "object",
+  "properties": {
+    "from": {"type": "string", "format": "date"},
+    "to": {"type": "string", "format": "date"},
+    "client_id": {"type": "integer"},
+    "total_hours": {"type": "number"},
+    "total_days": {"type": "integer"},
+    "average_per_day": {"type": "number"},
+    "project_hours": {"type": "object", "additionalProperties": {"type": "number"}},
+    "activity_hours": {"type": "object", "additionalProperties": {"type": "number"}},
+    "project_activity_hours": {"type": "object", "additionalProperties": {"type": "object", "additionalProperties": {"type": "number"}}},
+    "project_task_hours": {"type": "object", "additionalProperties": {"type": "object", "additionalProperties": {"type": "number"}}},
+    "daily": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "array",
+        "items": {
+          "type": "object",
+          "properties": {
+            "project": {"type": "string"},
+            "task": {"type": "string"},
+            "activity": {"type": "string"},
+            "hours": {"type": "number"},
+            "time_range": {"type": "string"}
+          }
+        }
+      }
+    }
+  }
+}`),
+	"report_request": json.RawMessage(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "report_request",
+  "type": "object",
+  "properties": {
+    "kind": {"type": "string", "enum": ["odoo_csv", "mail_report", "xlsx", "markdown_summary", "html_summary"]},
+    "year": {"type": "integer", "minimum": 1900, "maximum": 9999},
+    "month": {"type": "integer", "minimum": 1, "maximum": 12},
+    "client_id": {"type": "integer", "minimum": 0},
+    "from_company": {"type": "string", "description": "Required for mail reports"},
+    "to_company": {"type": "string", "description": "Defaults to the client's legal name"},
+    "invoice_name": {"type": "string"},
+    "selected": {
+      "type": "object",
+      "description": "project name -> activity name -> included; defaults to the work activities",
+      "additionalProperties": {"type": "object", "additionalProperties": {"type": "boolean"}}
+    }
+  },
+  "required": ["kind", "year", "month"],
+  "additionalProperties": false
+}`),
+	"error": json.RawMessage(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "error",
+  "type": "object",
+  "properties": {
+    "error": {"type": "string"},
+    "field": {"type": "string", "description": "The invalid input field, for validation errors"}
+  },
+  "required": ["error"]
+}`),
+}
+
+func (s *Server) listSchemas(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, schemas)
+}
+
+func (s *Server) getSchema(w http.ResponseWriter, r *http.Request) {
+	schema, ok := schemas[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("schema %q %w", r.PathValue("name"), errNotFound))
+		return
+	}
+	writeJSON(w, http.StatusOK, schema)
+}
@@ -0,0 +1,184 @@
+// Package api serves the tltui data as a local HTTP/JSON API, for dashboards and
+// editor plugins. Every request needs the server's token as a bearer token, and
+// changes go through the same validation as the TUI.
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// errNotFound is returned for requests naming a record that does not exist
+var errNotFound = errors.New("not found")
+
+// Server routes the API requests, rejecting the ones without the token
+type Server struct {
+	token string
+	mux   *http.ServeMux
+	newID sync.Mutex // Serializes picking the next ID of new projects and workhour types
+}
+
+func NewServer(token string) *Server {
+	s := &Server{token: token, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /api/projects", s.listProjects)
+	s.mux.HandleFunc("POST /api/projects", s.createProject)
+	s.mux.HandleFunc("GET /api/projects/{id}", s.getProject)
+	s.mux.HandleFunc("PUT /api/projects/{id}", s.updateProject)
+	s.mux.HandleFunc("DELETE /api/projects/{id}", s.deleteProject)
+
+	s.mux.HandleFunc("GET /api/workhour-details", s.listWorkhourDetails)
+	s.mux.HandleFunc("POST /api/workhour-details", s.createWorkhourDetails)
+	s.mux.HandleFunc("GET /api/workhour-details/{id}", s.getWorkhourDetails)
+	s.mux.HandleFunc("PUT /api/workhour-details/{id}", s.updateWorkhourDetails)
+	s.mux.HandleFunc("DELETE /api/workhour-details/{id}", s.deleteWorkhourDetails)
+
+	s.mux.HandleFunc("GET /api/workhours", s.listWorkhours)
+	s.mux.HandleFunc("POST /api/workhours", s.createWorkhour)
+	s.mux.HandleFunc("GET /api/workhours/{id}", s.getWorkhour)
+	s.mux.HandleFunc("PUT /api/workhours/{id}", s.updateWorkhour)
+	s.mux.HandleFunc("DELETE /api/workhours/{id}", s.deleteWorkhour)
+
+	s.mux.HandleFunc("GET /api/stats", s.getStats)
+	s.mux.HandleFunc("POST /api/reports", s.generateReport)
+
+	s.mux.HandleFunc("GET "+calendarFeedPath, s.getCalendar)
+
+	s.mux.HandleFunc("GET /api/schemas", s.listSchemas)
+	s.mux.HandleFunc("GET /api/schemas/{name}", s.getSchema)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok && r.URL.Path == calendarFeedPath {
+		token, ok = r.URL.Query().Get("token"), true
+	}
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, fmt.Errorf("missing or invalid token"))
+		return
+	}
+	if repository.IsReadOnly() && r.Method != http.MethodGet && r.URL.Path != "/api/reports" {
+		writeError(w, http.StatusForbidden, fmt.Errorf("the database is open read-only"))
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the API on addr until it fails. Changes are recorded in
+// the history as coming from the API.
+func ListenAndServe(addr, token string) error {
+	repository.SetChangeSource(domain.ChangeSourceAPI)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           NewServer(token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server.ListenAndServe()
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+	Field string `json:"field,omitempty"` // Invalid input field, for validation errors
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	response := errorResponse{Error: err.Error()}
+	var validationErr *common.ValidationError
+	if errors.As(err, &validationErr) {
+		response.Field = validationErr.Field
+	}
+	writeJSON(w, status, response)
+}
+
+// writeResult writes value, or err with the status matching it
+func writeResult(w http.ResponseWriter, status int, value any, err error) {
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	if value == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, value)
+}
+
+func statusFor(err error) int {
+	var validationErr *common.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest
+	case errors.Is(err, errNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrMonthLocked), errors.Is(err, domain.ErrRuleBlocked), errors.Is(err, domain.ErrOverlappingTimes):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// decodeBody reads a JSON request body, rejecting unknown fields
+func decodeBody(r *http.Request, value any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return &common.ValidationError{Message: "invalid JSON body: " + err.Error()}
+	}
+	return nil
+}
+
+func pathID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid id %q", errNotFound, r.PathValue("id"))
+	}
+	return id, nil
+}
+
+// parseDateParam reads a YYYY-MM-DD query parameter
+func parseDateParam(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if err := common.DateValidator(name)(value); err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+// parseRange reads the from and to query parameters; to defaults to from
+func parseRange(r *http.Request) (time.Time, time.Time, error) {
+	from, err := parseDateParam(r, "from")
+	if err != nil {
+		return from, from, err
+	}
+	to := from
+	if r.URL.Query().Get("to") != "" {
+		if to, err = parseDateParam(r, "to"); err != nil {
+			return from, to, err
+		}
+	}
+	if to.Before(from) {
+		return from, to, &common.ValidationError{Field: "to", Message: "to must not be before from"}
+	}
+	return from, to, nil
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+const testToken = "secret"
+
+func request(t *testing.T, server http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var value T
+	if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+		t.Fatalf("failed to decode %q: %v", rec.Body.String(), err)
+	}
+	return value
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401 for %q, got %d", header, rec.Code)
+		}
+	}
+
+	if rec := request(t, server, http.MethodGet, "/api/projects", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected 200 with the token, got %d", rec.Code)
+	}
+}
+
+func TestServer_ProjectsCRUD(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	repository.CreateTestProject(t, 3, "Arnia", 100)
+
+	rec := request(t, server, http.MethodPost, "/api/projects", `{"name":"  Website ","odoo_id":42,"budget_hours":80,"budget_period":1}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	created := decode[projectJSON](t, rec)
+	if created.ID != 4 || created.Name != "Website" || created.BudgetPeriod != 1 {
+		t.Errorf("unexpected project %+v", created)
+	}
+
+	rec = request(t, server, http.MethodPut, "/api/projects/4", `{"name":"Website v2","odoo_id":43}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if stored, _ := repository.GetProjectByID(4); stored == nil || stored.Name != "Website v2" || stored.OdooID != 43 {
+		t.Errorf("expected the update to be stored, got %+v", stored)
+	}
+
+	projects := decode[[]projectJSON](t, request(t, server, http.MethodGet, "/api/projects", ""))
+	if len(projects) != 2 {
+		t.Errorf("expected 2 projects, got %+v", projects)
+	}
+
+	if rec := request(t, server, http.MethodDelete, "/api/projects/4", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := request(t, server, http.MethodGet, "/api/projects/4", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after deleting, got %d", rec.Code)
+	}
+}
+
+func TestServer_DeleteProjectWithWorkhours(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	repository.CreateTestWorkhour(t, date, 1, project.ID, 4)
+
+	repository.LockMonth(2024, time.January, "test")
+	if rec := request(t, server, http.MethodDelete, "/api/projects/1", ""); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 with hours in a locked month, got %d: %s", rec.Code, rec.Body)
+	}
+	if stored, _ := repository.GetProjectByID(1); stored == nil {
+		t.Fatal("expected the project to be kept")
+	}
+
+	repository.UnlockMonth(2024, time.January, "test", "cleanup")
+	if rec := request(t, server, http.MethodDelete, "/api/projects/1", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	history, err := repository.GetWorkhourHistory(date, date)
+	if err != nil || len(history) != 2 || history[1].Action != domain.ChangeDelete {
+		t.Errorf("expected the deleted hours in the history, got %+v %v", history, err)
+	}
+}
+
+func TestServer_ValidatesLikeTheForms(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia", 100)
+
+	tests := []struct {
+		path  string
+		body  string
+		field string
+	}{
+		{"/api/projects", `{"name":"A","odoo_id":1}`, "name"},
+		{"/api/projects", `{"name":"Arnia 2","odoo_id":0}`, "odoo_id"},
+		{"/api/projects", `{"name":"Arnia 2","odoo_id":1,"client_id":9}`, "client_id"},
+		{"/api/workhour-details", `{"name":"Support","short_name":"this short name is too long"}`, "short_name"},
+		{"/api/workhours", `{"date":"2024-01-15","details_id":1,"project_id":1,"hours":25}`, "hours"},
+		{"/api/workhours", `{"date":"2024-13-01","details_id":1,"project_id":1,"hours":2}`, "date"},
+		{"/api/workhours", `{"date":"2024-01-15","details_id":7,"project_id":1,"hours":2}`, "details_id"},
+		{"/api/workhours", `{"date":"2024-01-15","details_id":1,"project_id":1,"start":"12:00","end":"09:00"}`, "end"},
+		{"/api/workhours", `{"date":"2024-01-15","details_id":1,"project_id":1,"hours":2,"unknown":1}`, ""},
+	}
+
+	for _, tt := range tests {
+		rec := request(t, server, http.MethodPost, tt.path, tt.body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: expected 400, got %d: %s", tt.path, tt.body, rec.Code, rec.Body)
+			continue
+		}
+		if got := decode[errorResponse](t, rec); got.Field != tt.field {
+			t.Errorf("%s %s: expected field %q, got %+v", tt.path, tt.body, tt.field, got)
+		}
+	}
+}
+
+func TestServer_Workhours(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia", 100)
+	repository.SetSetting(repository.SettingHoursRounding, "0.25")
+
+	rec := request(t, server, http.MethodPost, "/api/workhours", `{"date":"2024-01-15","details_id":1,"project_id":1,"hours":1.1}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	if created := decode[workhourJSON](t, rec); created.Hours != 1 || created.UUID == "" {
+		t.Errorf("expected hours rounded to 1 and a uuid, got %+v", created)
+	}
+
+	rec = request(t, server, http.MethodPost, "/api/workhours", `{"date":"2024-01-16","details_id":1,"project_id":1,"start":"09:00","end":"12:30","break_minutes":30}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	timed := decode[workhourJSON](t, rec)
+	if timed.Hours != 3 || timed.Start != "09:00" || timed.End != "12:30" {
+		t.Errorf("expected 3 hours from the times, got %+v", timed)
+	}
+
+	rec = request(t, server, http.MethodPost, "/api/workhours", `{"date":"2024-01-16","details_id":1,"project_id":1,"start":"12:00","end":"13:00"}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 for overlapping times, got %d: %s", rec.Code, rec.Body)
+	}
+
+	workhours := decode[[]workhourJSON](t, request(t, server, http.MethodGet, "/api/workhours?from=2024-01-01&to=2024-01-31", ""))
+	if len(workhours) != 2 {
+		t.Errorf("expected 2 workhours in January, got %+v", workhours)
+	}
+	if rec := request(t, server, http.MethodGet, "/api/workhours?from=2024-01-31&to=2024-01-01", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a reversed range, got %d", rec.Code)
+	}
+
+	if err := repository.LockMonth(2024, time.January, "test"); err != nil {
+		t.Fatalf("failed to lock month: %v", err)
+	}
+	if rec := request(t, server, http.MethodDelete, "/api/workhours/1", ""); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 in a locked month, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := request(t, server, http.MethodDelete, "/api/workhours/99", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing workhour, got %d", rec.Code)
+	}
+}
+
+func TestServer_WorkhourBudgetWarnings(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	project.BudgetHours = 10
+	if err := repository.UpdateProject(project); err != nil {
+		t.Fatalf("failed to set budget: %v", err)
+	}
+
+	rec := request(t, server, http.MethodPost, "/api/workhours", `{"date":"2024-01-15","details_id":1,"project_id":1,"hours":8}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	created := decode[workhourJSON](t, rec)
+	if len(created.Warnings) != 1 || !strings.Contains(created.Warnings[0], "80%") {
+		t.Errorf("expected the 80%% budget warning, got %q", created.Warnings)
+	}
+
+	// Moving the entry adds no hours to the budget
+	rec = request(t, server, http.MethodPut, "/api/workhours/1", `{"date":"2024-01-16","details_id":1,"project_id":1,"hours":8}`)
+	if updated := decode[workhourJSON](t, rec); rec.Code != http.StatusOK || len(updated.Warnings) != 0 {
+		t.Errorf("expected no warnings for a moved entry, got %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = request(t, server, http.MethodPut, "/api/workhours/1", `{"date":"2024-01-16","details_id":1,"project_id":1,"hours":11}`)
+	if updated := decode[workhourJSON](t, rec); len(updated.Warnings) != 1 || !strings.Contains(updated.Warnings[0], "exceeded") {
+		t.Errorf("expected the budget exceeded warning, got %s", rec.Body)
+	}
+}
+
+func TestServer_StatsAndReports(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	repository.CreateTestWorkhour(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 6)
+	repository.CreateTestWorkhour(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 2)
+
+	rec := request(t, server, http.MethodGet, "/api/stats?from=2024-01-01&to=2024-01-31", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	stats := decode[statsJSON](t, rec)
+	if stats.TotalHours != 8 || stats.TotalDays != 2 || stats.ProjectHours["Arnia"] != 8 || len(stats.Daily["2024-01-15"]) != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	rec = request(t, server, http.MethodPost, "/api/reports", `{"kind":"odoo_csv","year":2024,"month":1}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec.Header().Get("Content-Type") != "text/csv" || !strings.Contains(rec.Body.String(), "2024-01-15,__export__.account_analytic_account_100") {
+		t.Errorf("expected the CSV report, got %s:\n%s", rec.Header().Get("Content-Type"), rec.Body)
+	}
+
+	rec = request(t, server, http.MethodPost, "/api/reports", `{"kind":"markdown_summary","year":2024,"month":1}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/markdown") || !strings.Contains(rec.Body.String(), "| Arnia | 8 |") {
+		t.Errorf("expected the Markdown summary, got %s:\n%s", rec.Header().Get("Content-Type"), rec.Body)
+	}
+
+	if rec := request(t, server, http.MethodPost, "/api/reports", `{"kind":"html_summary","year":2024,"month":1,"client_id":9}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown client, got %d", rec.Code)
+	}
+	if rec := request(t, server, http.MethodPost, "/api/reports", `{"kind":"docx","year":2024,"month":1}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown kind, got %d", rec.Code)
+	}
+}
+
+func TestServer_Schemas(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+
+	all := decode[map[string]map[string]any](t, request(t, server, http.MethodGet, "/api/schemas", ""))
+	for _, name := range []string{"project", "workhour_details", "workhour", "stats", "report_request", "error"} {
+		if all[name]["title"] != name {
+			t.Errorf("expected the %q schema, got %v", name, all[name])
+		}
+	}
+	if rec := request(t, server, http.MethodGet, "/api/schemas/nope", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown schema, got %d", rec.Code)
+	}
+}
+
+func TestServer_CalendarFeed(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	server := NewServer(testToken)
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	vacation := repository.CreateTestWorkhourDetails(t, 2, "Vacation", "VAC", false)
+	repository.CreateTestLeaveEntitlement(t, 2024, vacation.ID, 21, 0, nil)
+	arnia := repository.CreateTestProject(t, 1, "Arnia", 100)
+	website := repository.CreateTestProject(t, 2, "Website", 101)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	repository.CreateTestWorkhour(t, day, dev.ID, arnia.ID, 6)
+	repository.CreateTestWorkhour(t, day, dev.ID, website.ID, 2)
+	repository.CreateTestWorkhour(t, day.AddDate(0, 0, 1), vacation.ID, arnia.ID, 8)
+
+	// Calendar apps subscribe with the token in the URL
+	req := httptest.NewRequest(http.MethodGet, "/api/calendar.ics?token="+testToken+"&from=2024-01-01&to=2024-01-31&project_id=1", nil)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") {
+		t.Errorf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	if got := strings.Count(body, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("expected the 2 events of Arnia, got %d:\n%s", got, body)
+	}
+	if !strings.Contains(body, "SUMMARY:Out of office: Vacation\r\n") || !strings.Contains(body, "X-MICROSOFT-CDO-BUSYSTATUS:OOF") {
+		t.Errorf("expected the vacation as out of office:\n%s", body)
+	}
+
+	// The token is only accepted in the URL of the feed
+	for _, path := range []string{"/api/calendar.ics?token=wrong", "/api/projects?token=" + testToken} {
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", path, rec.Code)
+		}
+	}
+}
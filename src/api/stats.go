@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain/repository"
+	generator "tltui/src/elm-store/calendar/report-generator"
+)
+
+// statsJSON is the API form of the report statistics, see the "stats" schema
+type statsJSON struct {
+	From                 string                        `json:"from"`
+	To                   string                        `json:"to"`
+	ClientID             int                           `json:"client_id,omitempty"`
+	TotalHours           float64                       `json:"total_hours"`
+	TotalDays            int                           `json:"total_days"`
+	AveragePerDay        float64                       `json:"average_per_day"`
+	ProjectHours         map[string]float64            `json:"project_hours"`
+	ActivityHours        map[string]float64            `json:"activity_hours"`
+	ProjectActivityHours map[string]map[string]float64 `json:"project_activity_hours"`
+	ProjectTaskHours     map[string]map[string]float64 `json:"project_task_hours"`
+	Daily                map[string][]statsEntryJSON   `json:"daily"`
+}
+
+type statsEntryJSON struct {
+	Project   string  `json:"project"`
+	Task      string  `json:"task,omitempty"`
+	Activity  string  `json:"activity"`
+	Hours     float64 `json:"hours"`
+	TimeRange string  `json:"time_range,omitempty"`
+}
+
+// getStats returns the statistics of the report generator for ?from=&to=, limited
+// to the projects of a client with ?client_id=
+func (s *Server) getStats(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	clientID := 0
+	if value := r.URL.Query().Get("client_id"); value != "" {
+		if err := common.PositiveIntValidator("client_id")(value); err != nil {
+			writeResult(w, 0, nil, err)
+			return
+		}
+		clientID, _ = strconv.Atoi(value)
+		client, err := repository.GetClientByID(clientID)
+		if err != nil {
+			writeResult(w, 0, nil, err)
+			return
+		}
+		if client == nil {
+			writeResult(w, 0, nil, &common.ValidationError{Field: "client_id", Message: "client " + value + " does not exist"})
+			return
+		}
+	}
+
+	stats, err := generator.LoadStats(from, to, clientID)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	result := statsJSON{
+		From:                 from.Format("2006-01-02"),
+		To:                   to.Format("2006-01-02"),
+		ClientID:             clientID,
+		TotalHours:           stats.TotalHours,
+		TotalDays:            stats.TotalDays,
+		AveragePerDay:        stats.AveragePerDay,
+		ProjectHours:         stats.ProjectHours,
+		ActivityHours:        stats.ActivityHours,
+		ProjectActivityHours: stats.ProjectActivityHours,
+		ProjectTaskHours:     stats.ProjectTaskHours,
+		Daily:                make(map[string][]statsEntryJSON, len(stats.DailyBreakdown)),
+	}
+	for day, entries := range stats.DailyBreakdown {
+		// The breakdown is keyed like "15-Jan-2024" for display, the API uses ISO dates
+		date := day
+		if parsed, err := time.Parse("02-Jan-2006", day); err == nil {
+			date = parsed.Format("2006-01-02")
+		}
+		for _, e := range entries {
+			result.Daily[date] = append(result.Daily[date], statsEntryJSON{
+				Project:   e.ProjectName,
+				Task:      e.TaskName,
+				Activity:  e.ActivityName,
+				Hours:     e.Hours,
+				TimeRange: e.TimeRange,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// workhourDetailsJSON is the API form of a workhour type, see the "workhour_details" schema
+type workhourDetailsJSON struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	ShortName    string `json:"short_name"`
+	IsWork       bool   `json:"is_work"`
+	OvertimeRole int    `json:"overtime_role"`
+}
+
+func toWorkhourDetailsJSON(d domain.WorkhourDetails) workhourDetailsJSON {
+	return workhourDetailsJSON{
+		ID:           d.ID,
+		Name:         d.Name,
+		ShortName:    d.ShortName,
+		IsWork:       d.IsWork,
+		OvertimeRole: int(d.OvertimeRole),
+	}
+}
+
+// workhourDetails validates the input with the rules of the workhour type form
+func (d workhourDetailsJSON) workhourDetails(id int) (domain.WorkhourDetails, error) {
+	name := strings.TrimSpace(d.Name)
+	shortName := strings.TrimSpace(d.ShortName)
+	if err := common.RequiredStringValidator("name")(name); err != nil {
+		return domain.WorkhourDetails{}, err
+	}
+	validateShortName := common.ChainValidators(
+		common.RequiredStringValidator("short_name"),
+		common.MaxLengthValidator("short_name", 20),
+	)
+	if err := validateShortName(shortName); err != nil {
+		return domain.WorkhourDetails{}, err
+	}
+	role := domain.OvertimeRole(d.OvertimeRole)
+	if role < domain.OvertimeRoleNone || role > domain.OvertimeRoleTimeOffInLieu {
+		return domain.WorkhourDetails{}, &common.ValidationError{Field: "overtime_role", Message: "overtime_role must be 0 (none), 1 (overtime) or 2 (time off in lieu)"}
+	}
+
+	return domain.WorkhourDetails{
+		ID:           id,
+		Name:         name,
+		ShortName:    shortName,
+		IsWork:       d.IsWork,
+		OvertimeRole: role,
+	}, nil
+}
+
+func (s *Server) listWorkhourDetails(w http.ResponseWriter, r *http.Request) {
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	result := make([]workhourDetailsJSON, 0, len(workhourDetails))
+	for _, d := range workhourDetails {
+		result = append(result, toWorkhourDetailsJSON(d))
+	}
+	writeResult(w, http.StatusOK, result, err)
+}
+
+func (s *Server) getWorkhourDetails(w http.ResponseWriter, r *http.Request) {
+	details, err := findWorkhourDetails(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toWorkhourDetailsJSON(*details))
+}
+
+func (s *Server) createWorkhourDetails(w http.ResponseWriter, r *http.Request) {
+	var input workhourDetailsJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	// New types take the next free ID, like in the workhour types view
+	s.newID.Lock()
+	defer s.newID.Unlock()
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	nextID := 1
+	for _, d := range workhourDetails {
+		nextID = max(nextID, d.ID+1)
+	}
+
+	details, err := input.workhourDetails(nextID)
+	if err == nil {
+		err = repository.CreateWorkhourDetails(details)
+	}
+	writeResult(w, http.StatusCreated, toWorkhourDetailsJSON(details), err)
+}
+
+func (s *Server) updateWorkhourDetails(w http.ResponseWriter, r *http.Request) {
+	stored, err := findWorkhourDetails(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	var input workhourDetailsJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	details, err := input.workhourDetails(stored.ID)
+	if err == nil {
+		err = repository.UpdateWorkhourDetails(details)
+	}
+	writeResult(w, http.StatusOK, toWorkhourDetailsJSON(details), err)
+}
+
+func (s *Server) deleteWorkhourDetails(w http.ResponseWriter, r *http.Request) {
+	details, err := findWorkhourDetails(r)
+	if err == nil {
+		err = repository.DeleteWorkhourDetails(details.ID)
+	}
+	writeResult(w, http.StatusNoContent, nil, err)
+}
+
+func findWorkhourDetails(r *http.Request) (*domain.WorkhourDetails, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	details, err := repository.GetWorkhourDetailsByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, fmt.Errorf("workhour details %d %w", id, errNotFound)
+	}
+	return details, nil
+}
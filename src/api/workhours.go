@@ -0,0 +1,236 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// workhourJSON is the API form of a workhour, see the "workhour" schema. Hours are
+// computed from start and end when both are set.
+type workhourJSON struct {
+	ID           int     `json:"id"`
+	UUID         string  `json:"uuid"`
+	Date         string  `json:"date"`
+	DetailsID    int     `json:"details_id"`
+	ProjectID    int     `json:"project_id"`
+	TaskID       int     `json:"task_id,omitempty"`
+	Hours        float64 `json:"hours"`
+	Start        string  `json:"start,omitempty"`
+	End          string  `json:"end,omitempty"`
+	BreakMinutes int     `json:"break_minutes,omitempty"`
+	IssueKey     string  `json:"issue_key,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"` // Only set in the responses of writes
+}
+
+func toWorkhourJSON(wh domain.Workhour) workhourJSON {
+	result := workhourJSON{
+		ID:        wh.ID,
+		UUID:      wh.UUID,
+		Date:      wh.Date.Format("2006-01-02"),
+		DetailsID: wh.DetailsID,
+		ProjectID: wh.ProjectID,
+		TaskID:    wh.TaskID,
+		Hours:     wh.Hours,
+		IssueKey:  wh.IssueKey,
+	}
+	if wh.HasTimes() {
+		result.Start = wh.Start.String()
+		result.End = wh.End.String()
+		result.BreakMinutes = wh.BreakMinutes
+	}
+	return result
+}
+
+// workhour validates the input with the rules of the workhour form. Times, overlaps,
+// rules and month locks are checked by the repository when storing it.
+func (in workhourJSON) workhour() (domain.Workhour, error) {
+	if err := common.DateValidator("date")(in.Date); err != nil {
+		return domain.Workhour{}, err
+	}
+	date, err := time.ParseInLocation("2006-01-02", in.Date, time.Local)
+	if err != nil {
+		return domain.Workhour{}, &common.ValidationError{Field: "date", Message: err.Error()}
+	}
+
+	details, err := repository.GetWorkhourDetailsByID(in.DetailsID)
+	if err != nil {
+		return domain.Workhour{}, err
+	}
+	if details == nil {
+		return domain.Workhour{}, &common.ValidationError{Field: "details_id", Message: fmt.Sprintf("workhour type %d does not exist", in.DetailsID)}
+	}
+	project, err := repository.GetProjectByID(in.ProjectID)
+	if err != nil {
+		return domain.Workhour{}, err
+	}
+	if project == nil {
+		return domain.Workhour{}, &common.ValidationError{Field: "project_id", Message: fmt.Sprintf("project %d does not exist", in.ProjectID)}
+	}
+	if in.TaskID != 0 {
+		task, err := repository.GetTaskByID(in.TaskID)
+		if err != nil {
+			return domain.Workhour{}, err
+		}
+		if task == nil || task.ProjectID != in.ProjectID {
+			return domain.Workhour{}, &common.ValidationError{Field: "task_id", Message: fmt.Sprintf("task %d does not exist in project %d", in.TaskID, in.ProjectID)}
+		}
+	}
+
+	wh := domain.Workhour{
+		Date:         date,
+		DetailsID:    in.DetailsID,
+		ProjectID:    in.ProjectID,
+		TaskID:       in.TaskID,
+		BreakMinutes: in.BreakMinutes,
+		IssueKey:     strings.TrimSpace(in.IssueKey),
+	}
+	if len(wh.IssueKey) > 200 {
+		return domain.Workhour{}, &common.ValidationError{Field: "issue_key", Message: "issue_key must be at most 200 characters"}
+	}
+
+	if in.Start != "" || in.End != "" {
+		if wh.Start, err = domain.ParseClockTime(in.Start); err != nil {
+			return domain.Workhour{}, &common.ValidationError{Field: "start", Message: "start: " + err.Error()}
+		}
+		if wh.End, err = domain.ParseClockTime(in.End); err != nil {
+			return domain.Workhour{}, &common.ValidationError{Field: "end", Message: "end: " + err.Error()}
+		}
+		if err := wh.ValidateTimes(); err != nil {
+			return domain.Workhour{}, &common.ValidationError{Field: "end", Message: err.Error()}
+		}
+		wh.Hours = wh.TimedHours()
+		return wh, nil
+	}
+	if in.BreakMinutes != 0 {
+		return domain.Workhour{}, &common.ValidationError{Field: "break_minutes", Message: "a break needs start and end times"}
+	}
+
+	rounding := repository.GetHoursRounding()
+	hours := strconv.FormatFloat(in.Hours, 'f', -1, 64)
+	if err := common.DurationValidator("hours", rounding)(hours); err != nil {
+		return domain.Workhour{}, err
+	}
+	wh.Hours = common.RoundHours(in.Hours, rounding)
+	return wh, nil
+}
+
+func (s *Server) listWorkhours(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	workhours, err := repository.GetWorkhoursByDateRange(from, to)
+	result := make([]workhourJSON, 0, len(workhours))
+	for _, wh := range workhours {
+		result = append(result, toWorkhourJSON(wh))
+	}
+	writeResult(w, http.StatusOK, result, err)
+}
+
+func (s *Server) getWorkhour(w http.ResponseWriter, r *http.Request) {
+	wh, err := findWorkhour(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toWorkhourJSON(*wh))
+}
+
+func (s *Server) createWorkhour(w http.ResponseWriter, r *http.Request) {
+	var input workhourJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	wh, err := input.workhour()
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	id, warnings, err := repository.CreateWorkhour(wh)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	created, err := repository.GetWorkhourByID(id)
+	if err != nil || created == nil {
+		writeResult(w, 0, nil, fmt.Errorf("failed to read created workhour: %w", err))
+		return
+	}
+	result := toWorkhourJSON(*created)
+	result.Warnings = budgetWarningMessages(warnings)
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (s *Server) updateWorkhour(w http.ResponseWriter, r *http.Request) {
+	stored, err := findWorkhour(r)
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	var input workhourJSON
+	if err := decodeBody(r, &input); err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+
+	var warnings []domain.BudgetWarning
+	wh, err := input.workhour()
+	if err == nil {
+		warnings, err = repository.UpdateWorkhour(stored.ID, wh)
+	}
+	if err != nil {
+		writeResult(w, 0, nil, err)
+		return
+	}
+	updated, err := repository.GetWorkhourByID(stored.ID)
+	if err != nil || updated == nil {
+		writeResult(w, 0, nil, fmt.Errorf("failed to read updated workhour: %w", err))
+		return
+	}
+	result := toWorkhourJSON(*updated)
+	result.Warnings = budgetWarningMessages(warnings)
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) deleteWorkhour(w http.ResponseWriter, r *http.Request) {
+	wh, err := findWorkhour(r)
+	if err == nil {
+		err = repository.DeleteWorkhour(wh.ID)
+	}
+	writeResult(w, http.StatusNoContent, nil, err)
+}
+
+func findWorkhour(r *http.Request) (*domain.Workhour, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	wh, err := repository.GetWorkhourByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if wh == nil {
+		return nil, fmt.Errorf("workhour %d %w", id, errNotFound)
+	}
+	return wh, nil
+}
+
+// budgetWarningMessages returns the messages of the budget warnings of a write
+func budgetWarningMessages(warnings []domain.BudgetWarning) []string {
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.Message())
+	}
+	return messages
+}
@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// runAdd logs a workhour entry, e.g. "add -p Arnia -t DEV 9:00-17:30 -30m"
+func runAdd(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("add", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	dateFlag := flags.String("date", "today", "day to log, YYYY-MM-DD, today or yesterday")
+	projectFlag := flags.String("p", "", "project name or ID")
+	typeFlag := flags.String("t", "", "workhour type short name, name or ID")
+	taskFlag := flags.String("task", "", "task name or ID (optional)")
+	issueFlag := flags.String("issue", "", "issue the hours are logged on, e.g. API-12 or group/api#12 (optional)")
+	flags.Usage = func() {
+		fmt.Fprintln(stdout, "Usage: tltui add -p PROJECT -t TYPE [-date DATE] [-task TASK] [-issue KEY] DURATION")
+		fmt.Fprintln(stdout, "DURATION accepts 7.5, 1:30, 1h30m, 90m or a range like 9:00-17:30 -30m")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *projectFlag == "" || *typeFlag == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("project, type and duration are required")
+	}
+
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	entry, err := parseHours(strings.Join(flags.Args(), " "))
+	if err != nil {
+		return err
+	}
+
+	project, err := findProject(*projectFlag)
+	if err != nil {
+		return err
+	}
+
+	details, err := findWorkhourDetails(*typeFlag)
+	if err != nil {
+		return err
+	}
+
+	taskID := 0
+	if *taskFlag != "" {
+		task, err := findTask(project.ID, *taskFlag)
+		if err != nil {
+			return err
+		}
+		taskID = task.ID
+	}
+
+	entry.Date = date
+	entry.DetailsID = details.ID
+	entry.ProjectID = project.ID
+	entry.TaskID = taskID
+	entry.IssueKey = strings.TrimSpace(*issueFlag)
+	_, budgetWarnings, err := repository.CreateWorkhour(entry)
+	if err != nil {
+		return err
+	}
+
+	logged := fmt.Sprintf("%gh", entry.Hours)
+	if entry.HasTimes() {
+		logged += fmt.Sprintf(" (%s)", entry.TimeRange())
+	}
+	fmt.Fprintf(stdout, "Logged %s of %s on %s for %s\n", logged, details.ShortName, project.Name, date.Format("2006-01-02"))
+
+	for _, warning := range budgetWarnings {
+		fmt.Fprintln(stdout, "⚠ "+warning.Message())
+	}
+
+	violations, err := repository.GetViolationsForDate(date)
+	if err == nil {
+		for _, v := range violations {
+			fmt.Fprintln(stdout, "⚠ "+v.Message)
+		}
+	}
+
+	return nil
+}
+
+// parseHours reads a duration with the configured rounding, using the same rules as the TUI.
+// Time ranges also set the start/end times and break of the returned workhour.
+func parseHours(value string) (domain.Workhour, error) {
+	var entry domain.Workhour
+	rounding := repository.GetHoursRounding()
+	if err := common.DurationValidator("Duration", rounding)(value); err != nil {
+		return entry, err
+	}
+
+	hours, err := common.ParseDuration(value, rounding)
+	if err != nil {
+		return entry, err
+	}
+	entry.Hours = hours
+
+	if span, isRange, err := common.ParseTimeSpan(value); isRange && err == nil {
+		entry.Start = domain.ClockTime(span.StartMinutes)
+		entry.End = domain.ClockTime(span.EndMinutes)
+		entry.BreakMinutes = span.BreakMinutes
+	}
+	return entry, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+
+	switch value {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// findProject resolves a project by ID, exact name or unique partial name
+func findProject(query string) (*domain.Project, error) {
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(projects))
+	ids := make([]int, len(projects))
+	for i, p := range projects {
+		names[i], ids[i] = p.Name, p.ID
+	}
+
+	index, err := resolve("project", query, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	return &projects[index], nil
+}
+
+// findWorkhourDetails resolves a workhour type by ID, short name, name or unique partial name
+func findWorkhourDetails(query string) (*domain.WorkhourDetails, error) {
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, d := range workhourDetails {
+		if strings.EqualFold(d.ShortName, query) {
+			return &workhourDetails[i], nil
+		}
+	}
+
+	names := make([]string, len(workhourDetails))
+	ids := make([]int, len(workhourDetails))
+	for i, d := range workhourDetails {
+		names[i], ids[i] = d.Name, d.ID
+	}
+
+	index, err := resolve("type", query, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	return &workhourDetails[index], nil
+}
+
+// findTask resolves a task of a project by ID, exact name or unique partial name
+func findTask(projectID int, query string) (*domain.Task, error) {
+	tasks, err := repository.GetTasksByProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(tasks))
+	ids := make([]int, len(tasks))
+	for i, t := range tasks {
+		names[i], ids[i] = t.Name, t.ID
+	}
+
+	index, err := resolve("task", query, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks[index], nil
+}
+
+// resolve returns the index of the item matching query by ID, then by exact name,
+// then by a case-insensitive substring that matches a single item
+func resolve(kind, query string, ids []int, names []string) (int, error) {
+	if id, err := strconv.Atoi(query); err == nil {
+		for i := range ids {
+			if ids[i] == id {
+				return i, nil
+			}
+		}
+	}
+
+	for i, name := range names {
+		if strings.EqualFold(name, query) {
+			return i, nil
+		}
+	}
+
+	var candidates []int
+	lowerQuery := strings.ToLower(query)
+	for i, name := range names {
+		if strings.Contains(strings.ToLower(name), lowerQuery) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		return 0, fmt.Errorf("no %s matches %q", kind, query)
+	default:
+		matches := make([]string, len(candidates))
+		for i, c := range candidates {
+			matches[i] = names[c]
+		}
+		return 0, fmt.Errorf("%q matches several %ss: %s", query, kind, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"tltui/src/domain/repository"
+)
+
+// runBackup handles "backup [PATH]", writing a copy of the database to PATH or the backup directory
+func runBackup(args []string, stdout io.Writer) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: tltui backup [PATH]")
+	}
+
+	dest := ""
+	if len(args) == 1 {
+		dest = args[0]
+	}
+	path, err := repository.Backup(dest)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Backed up database to %s\n", path)
+	return nil
+}
+
+// runRestore handles "restore [-yes] FILE", replacing the database with a backup
+func runRestore(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	confirmed := flags.Bool("yes", false, "confirm replacing the current data")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: tltui restore [-yes] FILE")
+	}
+
+	file := flags.Arg(0)
+	if err := repository.CheckBackup(file); err != nil {
+		return err
+	}
+	if !*confirmed {
+		return fmt.Errorf("restoring %s replaces the current data, run again with -yes to confirm", file)
+	}
+
+	safety, err := repository.Restore(file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Restored %s, the previous data was backed up to %s\n", file, safety)
+	return nil
+}
+
+// runDump handles "dump [-o FILE]", writing all data as JSON to FILE or stdout
+func runDump(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("dump", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	output := flags.String("o", "", "file to write the dump to instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: tltui dump [-o FILE]")
+	}
+
+	if *output == "" {
+		return repository.DumpJSON(stdout)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+	if err := repository.DumpJSON(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+
+	fmt.Fprintf(stdout, "Dumped database to %s\n", *output)
+	return nil
+}
+
+// runLoad handles "load [-replace [-allow-locked]] FILE", replacing the data with a JSON dump
+func runLoad(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("load", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	replace := flags.Bool("replace", false, "overwrite a database that already has logged hours")
+	allowLocked := flags.Bool("allow-locked", false, "with -replace, also overwrite the hours of locked months")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: tltui load [-replace [-allow-locked]] FILE")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to open dump: %w", err)
+	}
+	defer file.Close()
+
+	warnings, err := repository.LoadJSON(file, *replace, *allowLocked)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Loaded %s\n", flags.Arg(0))
+	for _, warning := range warnings {
+		fmt.Fprintln(stdout, "⚠ "+warning)
+	}
+	return nil
+}
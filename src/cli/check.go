@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+	"tltui/src/domain/repository"
+)
+
+// runCheck lists the rule violations of a month, the current one by default
+func runCheck(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	monthFlag := flags.String("month", "", "month to check as YYYY-MM (default: current month)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	month := time.Now()
+	if *monthFlag != "" {
+		parsed, err := time.ParseInLocation("2006-01", *monthFlag, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid month %q, use YYYY-MM", *monthFlag)
+		}
+		month = parsed
+	}
+
+	violations, err := repository.GetMonthViolations(month.Year(), month.Month())
+	if err != nil {
+		return err
+	}
+
+	if len(violations) == 0 {
+		fmt.Fprintf(stdout, "No rule violations in %s\n", month.Format("January 2006"))
+		return nil
+	}
+	for _, v := range violations {
+		fmt.Fprintf(stdout, "%-5s %s\n", v.Rule.Severity, v.Message)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+const usage = `Usage: tltui [--profile NAME | --db PATH] [--read-only] [command] [arguments]
+
+Without a command, tltui starts the interactive calendar.
+The profile can also be chosen with the TLTUI_PROFILE environment variable,
+and a database file with TLTUI_DB. A database file takes precedence over profiles.
+--read-only opens the database without allowing changes.
+
+Commands:
+  add      Log hours for a day
+  backup   Copy the database to a file or the backup directory
+  check    List rule violations of a month
+  config   Show or change settings
+  dump     Write all data as JSON
+  git      Suggest hours from the commits in local git repositories
+  help     Show this help
+  history  List changes made to the logged hours
+  ics      Export logged hours and leave as an iCalendar file
+  load     Replace all data with a JSON dump
+  mail     Show or set how reports are emailed, or list the emails sent
+  profile  List, create, rename or delete profiles
+  push     Send logged hours to Odoo, Jira worklogs or GitLab time tracking
+  restore  Replace the database with a backup
+  serve    Serve the data as an HTTP/JSON API on 127.0.0.1
+  summary  Write a Markdown or HTML summary of a month, or copy it
+  sync     Share changes with other devices through a folder
+`
+
+// Run executes the command named by args[0], writing its output to stdout.
+// Changes made by commands are recorded in the history as coming from the CLI.
+func Run(args []string, stdout io.Writer) error {
+	repository.SetChangeSource(domain.ChangeSourceCLI)
+
+	if len(args) == 0 {
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	switch args[0] {
+	case "add":
+		return runAdd(args[1:], stdout)
+	case "backup":
+		return runBackup(args[1:], stdout)
+	case "check":
+		return runCheck(args[1:], stdout)
+	case "config":
+		return runConfig(args[1:], stdout)
+	case "dump":
+		return runDump(args[1:], stdout)
+	case "git":
+		return runGit(args[1:], stdout)
+	case "history":
+		return runHistory(args[1:], stdout)
+	case "ics":
+		return runICS(args[1:], stdout)
+	case "load":
+		return runLoad(args[1:], stdout)
+	case "mail":
+		return runMail(args[1:], stdout)
+	case "profile":
+		return runProfile(args[1:], stdout)
+	case "push":
+		return runPush(args[1:], stdout)
+	case "restore":
+		return runRestore(args[1:], stdout)
+	case "serve":
+		return runServe(args[1:], stdout)
+	case "summary":
+		return runSummary(args[1:], stdout)
+	case "sync":
+		return runSync(args[1:], stdout)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, run 'tltui help' for usage", args[0])
+	}
+}
@@ -0,0 +1,1194 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+func TestRunAdd(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+	repository.CreateTestProject(t, 2, "Arnia Mobile", 200)
+	task := repository.CreateTestTask(t, 1, "Code review", 0)
+
+	var out bytes.Buffer
+	err := Run([]string{"add", "-date", "2025-03-04", "-p", "api", "-t", "dev", "-task", "review", "9:00-12:15", "-30m"}, &out)
+	if err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Logged 2.75h (09:00-12:15) of DEV on Arnia API for 2025-03-04") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	workhours, _ := repository.GetWorkhoursByDate(time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local))
+	if len(workhours) != 1 {
+		t.Fatalf("expected 1 workhour, got %d", len(workhours))
+	}
+	if workhours[0].Hours != 2.75 || workhours[0].ProjectID != 1 || workhours[0].TaskID != task.ID || workhours[0].BreakMinutes != 30 {
+		t.Errorf("unexpected workhour %+v", workhours[0])
+	}
+}
+
+func TestRunAdd_Errors(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+	repository.CreateTestProject(t, 2, "Arnia Mobile", 200)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"ambiguous project", []string{"add", "-p", "arnia", "-t", "DEV", "1h"}, "matches several projects"},
+		{"unknown type", []string{"add", "-p", "API", "-t", "XYZ", "1h"}, "no type matches"},
+		{"invalid duration", []string{"add", "-p", "API", "-t", "DEV", "17-9"}, "end time must be after start time"},
+		{"missing duration", []string{"add", "-p", "API", "-t", "DEV"}, "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Run(tt.args, &out)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunAdd_UsesConfiguredRounding(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+
+	var out bytes.Buffer
+	if err := Run([]string{"config", "set", repository.SettingHoursRounding, "0.25"}, &out); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if err := Run([]string{"add", "-date", "2025-03-04", "-p", "API", "-t", "DEV", "1h20m"}, &out); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	workhours, _ := repository.GetWorkhoursByDate(time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local))
+	if len(workhours) != 1 || workhours[0].Hours != 1.25 {
+		t.Errorf("expected 1.25h after rounding, got %+v", workhours)
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+
+	var out bytes.Buffer
+	if err := Run([]string{"config", "set", "rule_max_daily_hours", "warn 10"}, &out); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if err := Run([]string{"config", "set", "rule_max_daily_hours", "sometimes"}, &out); err == nil {
+		t.Error("expected an invalid rule to be rejected")
+	}
+
+	out.Reset()
+	if err := Run([]string{"add", "-date", "2025-03-04", "-p", "API", "-t", "DEV", "11"}, &out); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "more than the 10h daily maximum") {
+		t.Errorf("expected a rule warning after adding, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := Run([]string{"check", "-month", "2025-03"}, &out); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "warn  2025-03-04: 11h logged") {
+		t.Errorf("unexpected check output %q", out.String())
+	}
+
+	out.Reset()
+	if err := Run([]string{"check", "-month", "2025-04"}, &out); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "No rule violations in April 2025") {
+		t.Errorf("unexpected check output %q", out.String())
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+
+	var out bytes.Buffer
+	if err := Run([]string{"add", "-date", "2025-03-04", "-p", "API", "-t", "DEV", "9:00-11:00"}, &out); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if err := Run([]string{"add", "-date", "2025-03-10", "-p", "API", "-t", "DEV", "1h"}, &out); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	out.Reset()
+	if err := Run([]string{"history", "-from", "2025-03-01", "-to", "2025-03-07"}, &out); err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one change in the range, got %q", out.String())
+	}
+	if !strings.Contains(lines[0], "cli    create") || !strings.Contains(lines[0], "2025-03-04 DEV 2h Arnia API 09:00-11:00") {
+		t.Errorf("unexpected history line %q", lines[0])
+	}
+
+	out.Reset()
+	if err := Run([]string{"history", "-from", "2025-04-01"}, &out); err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "No changes recorded") {
+		t.Errorf("unexpected history output %q", out.String())
+	}
+
+	if err := Run([]string{"history", "-from", "2025-03-07", "-to", "2025-03-01"}, &out); err == nil {
+		t.Error("expected a reversed range to be rejected")
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	env := map[string]string{"TLTUI_PROFILE": "from-env", "TLTUI_DB": ""}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     Options
+		wantArgs []string
+	}{
+		{"environment default", []string{"add", "1h"}, Options{Profile: "from-env"}, []string{"add", "1h"}},
+		{"flag with value", []string{"--profile", "acme", "add"}, Options{Profile: "acme"}, []string{"add"}},
+		{"flag with equals", []string{"-profile=acme"}, Options{Profile: "acme"}, []string{}},
+		{"database and read-only", []string{"--db", "/tmp/copy.db", "--read-only", "check"}, Options{Profile: "from-env", DB: "/tmp/copy.db", ReadOnly: true}, []string{"check"}},
+		{"read-only turned off", []string{"--read-only=false"}, Options{Profile: "from-env"}, []string{}},
+		{"command flags untouched", []string{"--help"}, Options{Profile: "from-env"}, []string{"--help"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, args, err := ParseOptions(tt.args, getenv)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if opts != tt.want || strings.Join(args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("got %+v args %q, want %+v %q", opts, args, tt.want, tt.wantArgs)
+			}
+		})
+	}
+
+	env["TLTUI_DB"] = "/srv/shared/tltui.db"
+	if opts, _, _ := ParseOptions(nil, getenv); opts.DB != "/srv/shared/tltui.db" {
+		t.Errorf("expected the database from TLTUI_DB, got %q", opts.DB)
+	}
+
+	for _, args := range [][]string{{"--profile"}, {"--db"}, {"--read-only=maybe"}} {
+		if _, _, err := ParseOptions(args, getenv); err == nil {
+			t.Errorf("expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestReadOnlyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "copy.db")
+	if err := repository.InitDBAt(path); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), 1, 1, 8)
+	repository.CloseDB()
+
+	repository.SetReadOnly(true)
+	defer repository.SetReadOnly(false)
+	if err := repository.InitDBAt(path); err != nil {
+		t.Fatalf("failed to open database read-only: %v", err)
+	}
+	defer repository.CloseDB()
+
+	var out bytes.Buffer
+	if err := Run([]string{"history", "-from", "2025-03-04"}, &out); err != nil || !strings.Contains(out.String(), "DEV 8h Arnia API") {
+		t.Errorf("expected to browse a read-only database, got %v %q", err, out.String())
+	}
+	if err := Run([]string{"add", "-date", "2025-03-05", "-p", "API", "-t", "DEV", "1h"}, &out); err == nil {
+		t.Error("expected adding to a read-only database to fail")
+	}
+	if workhours, _ := repository.GetAllWorkhours(); len(workhours) != 1 {
+		t.Errorf("expected the database to be unchanged, got %d workhours", len(workhours))
+	}
+
+	if err := repository.InitDBAt(filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("expected a missing database to be rejected in read-only mode")
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	if err := repository.InitDB(""); err != nil {
+		t.Fatalf("failed to open the default profile: %v", err)
+	}
+	defer repository.CloseDB()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), 1, 1, 8)
+
+	var out bytes.Buffer
+	for _, args := range [][]string{
+		{"profile", "create", "acme"},
+		{"profile", "create", "globex"},
+		{"profile", "rename", "globex", "initech"},
+	} {
+		if err := Run(args, &out); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+	}
+
+	out.Reset()
+	if err := Run([]string{"profile", "list"}, &out); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 3 ||
+		!strings.HasPrefix(lines[0], "* default") || !strings.Contains(lines[1], "acme") || !strings.Contains(lines[2], "initech") {
+		t.Errorf("unexpected profile list %q", out.String())
+	}
+
+	if err := Run([]string{"profile", "create", "../escape"}, &out); err == nil {
+		t.Error("expected an invalid profile name to be rejected")
+	}
+	if err := Run([]string{"profile", "delete", "initech"}, &out); err == nil {
+		t.Error("expected deleting without -yes to be rejected")
+	}
+	if err := Run([]string{"profile", "delete", "-yes", "initech"}, &out); err != nil {
+		t.Errorf("delete failed: %v", err)
+	}
+	if err := Run([]string{"profile", "delete", "-yes", "default"}, &out); err == nil {
+		t.Error("expected deleting the default profile to be rejected")
+	}
+
+	// Each profile has its own database and settings
+	if err := repository.SwitchProfile("acme"); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+	if repository.CurrentProfile() != "acme" {
+		t.Errorf("expected acme to be open, got %s", repository.CurrentProfile())
+	}
+	if workhours, _ := repository.GetAllWorkhours(); len(workhours) != 0 {
+		t.Errorf("expected an empty database for a new profile, got %d workhours", len(workhours))
+	}
+	if err := Run([]string{"profile", "delete", "-yes", "acme"}, &out); err == nil {
+		t.Error("expected deleting the open profile to be rejected")
+	}
+	if err := repository.SwitchProfile("missing"); err == nil || repository.CurrentProfile() != "acme" {
+		t.Errorf("expected switching to a missing profile to fail and keep acme open, got %v", err)
+	}
+}
+
+func TestRunBackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	if err := repository.InitDBAt(filepath.Join(dir, "data.db")); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer repository.CloseDB()
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia API", 100)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), 1, 1, 8)
+
+	backup := filepath.Join(dir, "copy.db")
+	var out bytes.Buffer
+	if err := Run([]string{"backup", backup}, &out); err != nil {
+		t.Fatalf("backup failed: %v", err)
+	}
+	if err := Run([]string{"backup", backup}, &out); err == nil {
+		t.Error("expected backing up over an existing file to fail")
+	}
+
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 5, 0, 0, 0, 0, time.Local), 1, 1, 4)
+	if err := Run([]string{"restore", backup}, &out); err == nil {
+		t.Error("expected restore without -yes to be refused")
+	}
+	out.Reset()
+	if err := Run([]string{"restore", "-yes", backup}, &out); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if workhours, _ := repository.GetAllWorkhours(); len(workhours) != 1 {
+		t.Errorf("expected the backed up workhour only, got %d", len(workhours))
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "backups", "pre-restore-*.db")); len(matches) != 1 {
+		t.Errorf("expected a safety backup before restoring, got %v (%q)", matches, out.String())
+	}
+
+	if err := Run([]string{"restore", "-yes", filepath.Join(dir, "missing.db")}, &out); err == nil {
+		t.Error("expected restoring a missing file to fail")
+	}
+	notDB := filepath.Join(dir, "notes.txt")
+	os.WriteFile(notDB, []byte("not a database"), 0644)
+	if err := Run([]string{"restore", "-yes", notDB}, &out); err == nil {
+		t.Error("expected restoring a file that is not a database to fail")
+	}
+}
+
+func TestRestore_RejectsNewerSchema(t *testing.T) {
+	dir := t.TempDir()
+	newer := filepath.Join(dir, "newer.db")
+	if err := repository.InitDBAt(newer); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	repository.GetDB().Exec(fmt.Sprintf("PRAGMA user_version = %d", repository.SchemaVersion+1))
+	repository.CloseDB()
+
+	if err := repository.InitDBAt(filepath.Join(dir, "data.db")); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer repository.CloseDB()
+
+	var out bytes.Buffer
+	err := Run([]string{"restore", "-yes", newer}, &out)
+	if err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("expected a newer schema to be rejected, got %v", err)
+	}
+
+	dump := fmt.Sprintf(`{"format":"tltui-dump","version":1,"schema_version":%d,"tables":[]}`, repository.SchemaVersion+1)
+	dumpFile := filepath.Join(dir, "newer.json")
+	os.WriteFile(dumpFile, []byte(dump), 0644)
+	if err := Run([]string{"load", dumpFile}, &out); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("expected a dump of a newer schema to be rejected, got %v", err)
+	}
+}
+
+func TestAutoBackup(t *testing.T) {
+	dir := t.TempDir()
+	if err := repository.InitDBAt(filepath.Join(dir, "data.db")); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer repository.CloseDB()
+	repository.SetSetting(repository.SettingBackupKeep, "2")
+
+	backups := filepath.Join(dir, "backups")
+	os.MkdirAll(backups, 0755)
+	for _, name := range []string{"auto-20250101-080000.db", "auto-20250102-080000.db", "data-20250103-080000.db"} {
+		os.WriteFile(filepath.Join(backups, name), nil, 0644)
+	}
+
+	if err := repository.AutoBackup(); err != nil {
+		t.Fatalf("automatic backup failed: %v", err)
+	}
+	if err := repository.AutoBackup(); err != nil {
+		t.Fatalf("second automatic backup failed: %v", err)
+	}
+
+	today, _ := filepath.Glob(filepath.Join(backups, "auto-"+time.Now().Format("20060102")+"-*.db"))
+	if len(today) != 1 {
+		t.Errorf("expected one automatic backup today, got %v", today)
+	}
+	all, _ := filepath.Glob(filepath.Join(backups, "*.db"))
+	want := []string{"auto-20250102-080000.db", filepath.Base(today[0]), "data-20250103-080000.db"}
+	if len(all) != len(want) {
+		t.Fatalf("expected backups %v, got %v", want, all)
+	}
+	for i, path := range all {
+		if filepath.Base(path) != want[i] {
+			t.Errorf("expected backups %v, got %v", want, all)
+			break
+		}
+	}
+}
+
+func TestRunDumpAndLoad(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestWorkhourDetails(t, 2, "Vacation", "CO", false)
+	client := repository.CreateTestClient(t, "Arnia", "Arnia SRL")
+	project := repository.CreateTestProject(t, 7, "Arnia API", 100)
+	project.ClientID = client.ID
+	repository.UpdateProject(project)
+	repository.CreateTestTask(t, 7, "Sprint \"12\"", 44)
+	repository.CreateTestLeaveEntitlement(t, 2025, 2, 21, 2.5, nil)
+	repository.SetSetting(repository.SettingHoursRounding, "0.25")
+	repository.LockMonth(2025, time.February, "tester")
+
+	var out bytes.Buffer
+	if err := Run([]string{"add", "-date", "2025-03-04", "-p", "API", "-t", "DEV", "9:00-17:30", "-30m"}, &out); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if err := Run([]string{"add", "-date", "2025-03-05", "-p", "API", "-t", "CO", "1:20"}, &out); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	var dump bytes.Buffer
+	if err := Run([]string{"dump"}, &dump); err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	before, _ := repository.GetAllWorkhours()
+	cleanup()
+
+	defer repository.SetupTest(t)()
+	file := filepath.Join(t.TempDir(), "dump.json")
+	os.WriteFile(file, dump.Bytes(), 0644)
+	var loaded bytes.Buffer
+	if err := Run([]string{"load", file}, &loaded); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !strings.Contains(loaded.String(), "2025-03-05: non-work entries total") {
+		t.Errorf("expected the load to report the broken rules, got %q", loaded.String())
+	}
+	if err := Run([]string{"load", file}, &out); err == nil {
+		t.Error("expected loading over logged hours without -replace to fail")
+	}
+	if err := Run([]string{"load", "-replace", file}, &out); err != nil {
+		t.Fatalf("load with -replace failed: %v", err)
+	}
+
+	var again bytes.Buffer
+	if err := Run([]string{"dump"}, &again); err != nil {
+		t.Fatalf("second dump failed: %v", err)
+	}
+	if again.String() != dump.String() {
+		t.Errorf("expected the dump to round-trip exactly\nbefore: %s\nafter:  %s", dump.String(), again.String())
+	}
+
+	after, _ := repository.GetAllWorkhours()
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("expected the same workhours after loading, got %+v, want %+v", after, before)
+	}
+}
+
+func TestRunLoad_LockedMonths(t *testing.T) {
+	defer repository.SetupTest(t)()
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia API", 100)
+	logged := repository.CreateTestWorkhour(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local), 1, project.ID, 8)
+
+	var dump, out bytes.Buffer
+	if err := Run([]string{"dump"}, &dump); err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "dump.json")
+	os.WriteFile(file, dump.Bytes(), 0644)
+
+	logged.Hours = 6
+	if _, err := repository.UpdateWorkhour(logged.ID, logged); err != nil {
+		t.Fatalf("failed to update workhour: %v", err)
+	}
+	repository.LockMonth(2025, time.January, "tester")
+
+	err := Run([]string{"load", "-replace", file}, &out)
+	if !errors.Is(err, domain.ErrMonthLocked) {
+		t.Fatalf("expected the load to be refused for the locked month, got %v", err)
+	}
+	if workhours, _ := repository.GetAllWorkhours(); len(workhours) != 1 || workhours[0].Hours != 6 {
+		t.Errorf("expected the locked hours to be kept, got %+v", workhours)
+	}
+
+	if err := Run([]string{"load", "-replace", "-allow-locked", file}, &out); err != nil {
+		t.Fatalf("load with -allow-locked failed: %v", err)
+	}
+	if workhours, _ := repository.GetAllWorkhours(); len(workhours) != 1 || workhours[0].Hours != 8 {
+		t.Errorf("expected the dumped hours after confirming, got %+v", workhours)
+	}
+}
+
+func TestRunSync_LockedMonth(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared")
+	os.MkdirAll(shared, 0755)
+	laptop, desktop := filepath.Join(dir, "laptop.db"), filepath.Join(dir, "desktop.db")
+	defer repository.CloseDB()
+
+	var out bytes.Buffer
+	open := func(path string) {
+		t.Helper()
+		repository.CloseDB()
+		if err := repository.InitDBAt(path); err != nil {
+			t.Fatalf("failed to open %s: %v", path, err)
+		}
+	}
+	run := func(args ...string) error {
+		t.Helper()
+		out.Reset()
+		return Run(args, &out)
+	}
+	hours := func() float64 {
+		t.Helper()
+		workhours, err := repository.GetAllWorkhours()
+		if err != nil || len(workhours) != 1 {
+			t.Fatalf("expected one workhour, got %+v %v", workhours, err)
+		}
+		return workhours[0].Hours
+	}
+
+	for _, path := range []string{laptop, desktop} {
+		open(path)
+		repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+		repository.CreateTestProject(t, 1, "Arnia API", 100)
+		run("sync", "init", shared)
+	}
+
+	open(laptop)
+	run("add", "-date", "2025-03-04", "-p", "API", "-t", "DEV", "8")
+	run("sync")
+	open(desktop)
+	run("sync")
+	repository.LockMonth(2025, time.March, "tester")
+
+	open(laptop)
+	workhours, _ := repository.GetAllWorkhours()
+	workhours[0].Hours = 6
+	repository.UpdateWorkhour(workhours[0].ID, workhours[0])
+	run("sync")
+
+	open(desktop)
+	if err := run("sync"); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "1 changes touch locked months") {
+		t.Errorf("expected the locked change to be reported, got %q", out.String())
+	}
+	if got := hours(); got != 8 {
+		t.Fatalf("expected the locked month to keep 8h, got %v", got)
+	}
+
+	conflicts, err := repository.GetSyncConflicts()
+	if err != nil || len(conflicts) != 1 || conflicts[0].Kept != domain.SyncSideLocal || conflicts[0].Remote.Hours != 6 {
+		t.Fatalf("expected the locked change as a conflict, got %+v %v", conflicts, err)
+	}
+	id := fmt.Sprint(conflicts[0].ID)
+	if err := run("sync", "resolve", "-use-other", id); !errors.Is(err, domain.ErrMonthLocked) {
+		t.Fatalf("expected resolving into a locked month to fail, got %v", err)
+	}
+	repository.UnlockMonth(2025, time.March, "tester", "late correction")
+	if err := run("sync", "resolve", "-use-other", id); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if got := hours(); got != 6 {
+		t.Errorf("expected the change after unlocking, got %v", got)
+	}
+}
+
+func TestRunSync_TwoReplicas(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared")
+	os.MkdirAll(shared, 0755)
+	laptop, desktop := filepath.Join(dir, "laptop.db"), filepath.Join(dir, "desktop.db")
+	defer repository.CloseDB()
+
+	var out bytes.Buffer
+	open := func(path string) {
+		t.Helper()
+		repository.CloseDB()
+		if err := repository.InitDBAt(path); err != nil {
+			t.Fatalf("failed to open %s: %v", path, err)
+		}
+	}
+	run := func(args ...string) {
+		t.Helper()
+		out.Reset()
+		if err := Run(args, &out); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+	}
+	hours := func() []float64 {
+		t.Helper()
+		workhours, err := repository.GetAllWorkhours()
+		if err != nil {
+			t.Fatalf("failed to get workhours: %v", err)
+		}
+		var result []float64
+		for _, wh := range workhours {
+			result = append(result, wh.Hours)
+		}
+		return result
+	}
+	update := func(value float64) {
+		t.Helper()
+		workhours, _ := repository.GetAllWorkhours()
+		workhours[0].Hours = value
+		if _, err := repository.UpdateWorkhour(workhours[0].ID, workhours[0]); err != nil {
+			t.Fatalf("failed to update workhour: %v", err)
+		}
+	}
+
+	for _, path := range []string{laptop, desktop} {
+		open(path)
+		repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+		repository.CreateTestProject(t, 1, "Arnia API", 100)
+		run("sync", "init", shared)
+	}
+
+	open(laptop)
+	run("add", "-date", "2025-03-04", "-p", "API", "-t", "DEV", "8")
+	run("sync")
+
+	open(desktop)
+	run("sync")
+	if got := hours(); !reflect.DeepEqual(got, []float64{8}) {
+		t.Fatalf("expected the laptop's entry on the desktop, got %v", got)
+	}
+	update(6)
+	run("sync")
+
+	open(laptop)
+	run("sync")
+	if got := hours(); !reflect.DeepEqual(got, []float64{6}) {
+		t.Fatalf("expected the desktop's change on the laptop, got %v", got)
+	}
+
+	// Both change the entry before syncing, the desktop last
+	update(5)
+	run("sync")
+	open(desktop)
+	update(7)
+	run("sync")
+	if !strings.Contains(out.String(), "1 conflicts") {
+		t.Errorf("expected a conflict on the desktop, got %q", out.String())
+	}
+	open(laptop)
+	run("sync")
+	if got := hours(); !reflect.DeepEqual(got, []float64{7}) {
+		t.Fatalf("expected the newest change to win, got %v", got)
+	}
+
+	conflicts, err := repository.GetSyncConflicts()
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("expected one conflict on the laptop, got %v %v", conflicts, err)
+	}
+	if c := conflicts[0]; c.Kept != "remote" || c.Local.Hours != 5 || c.Remote.Hours != 7 {
+		t.Errorf("expected the remote value to be kept over the local one, got %+v", c)
+	}
+	run("sync", "resolve", "-use-other", fmt.Sprint(conflicts[0].ID))
+	if got := hours(); !reflect.DeepEqual(got, []float64{5}) {
+		t.Fatalf("expected the discarded value after resolving, got %v", got)
+	}
+	run("sync")
+
+	open(desktop)
+	run("sync")
+	if got := hours(); !reflect.DeepEqual(got, []float64{5}) {
+		t.Fatalf("expected the resolution to reach the desktop, got %v", got)
+	}
+	workhours, _ := repository.GetAllWorkhours()
+	repository.DeleteWorkhour(workhours[0].ID)
+	run("sync")
+
+	// A change still being written by the folder sync is left for the next run
+	logs, _ := filepath.Glob(filepath.Join(shared, "*.jsonl"))
+	for _, log := range logs {
+		file, _ := os.OpenFile(log, os.O_APPEND|os.O_WRONLY, 0644)
+		file.WriteString(`{"replica":`)
+		file.Close()
+	}
+
+	open(laptop)
+	run("sync")
+	if got := hours(); len(got) != 0 {
+		t.Errorf("expected the deletion to reach the laptop, got %v", got)
+	}
+}
+
+func TestRunServe_RejectsNonLoopbackAddress(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	for _, addr := range []string{"0.0.0.0:8765", "192.168.1.10:8765", "8765"} {
+		if err := Run([]string{"serve", "-addr", addr, "-token", "secret"}, &out); err == nil {
+			t.Errorf("expected %q to be rejected", addr)
+		}
+	}
+}
+
+func TestRunICS_FiltersByType(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	vacation := repository.CreateTestWorkhourDetails(t, 2, "Vacation", "VAC", false)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	repository.CreateTestWorkhour(t, day, dev.ID, project.ID, 8)
+	repository.CreateTestWorkhour(t, day.AddDate(0, 0, 1), vacation.ID, project.ID, 8)
+
+	var out bytes.Buffer
+	if err := Run([]string{"ics", "-from", "2025-03-01", "-t", "VAC"}, &out); err != nil {
+		t.Fatalf("ics failed: %v", err)
+	}
+	ics := out.String()
+	if strings.Count(ics, "BEGIN:VEVENT") != 1 || !strings.Contains(ics, "DTSTART;VALUE=DATE:20250305") {
+		t.Errorf("expected only the vacation day, got:\n%s", ics)
+	}
+
+	path := filepath.Join(t.TempDir(), "march.ics")
+	out.Reset()
+	if err := Run([]string{"ics", "-from", "2025-03-01", "-o", path}, &out); err != nil {
+		t.Fatalf("ics -o failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected both days in the file, got:\n%s", data)
+	}
+	// Types that are not work are leave, also without a leave entitlement
+	if !strings.Contains(string(data), "SUMMARY:Out of office: Vacation\r\n") {
+		t.Errorf("expected the vacation as out of office, got:\n%s", data)
+	}
+	if !strings.Contains(out.String(), "2025-03-01 - 2025-03-31") {
+		t.Errorf("expected the exported month to be reported, got %q", out.String())
+	}
+}
+
+func TestRunSummary(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	client := repository.CreateTestClient(t, "Arnia", "Arnia SRL")
+	api := repository.CreateTestProject(t, 1, "Arnia API", 100)
+	api.ClientID = client.ID
+	repository.UpdateProject(api)
+	internal := repository.CreateTestProject(t, 2, "Internal", 101)
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	repository.CreateTestWorkhour(t, day, dev.ID, api.ID, 6)
+	repository.CreateTestWorkhour(t, day, dev.ID, internal.ID, 2)
+
+	var out bytes.Buffer
+	if err := Run([]string{"summary", "-month", "2025-03"}, &out); err != nil {
+		t.Fatalf("summary failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "| Arnia API | 6 |\n| Internal | 2 |\n") {
+		t.Errorf("expected both projects in the summary, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := Run([]string{"summary", "-month", "2025-03", "-format", "html", "-c", "Arnia", "-copy"}, &out); err != nil {
+		t.Fatalf("summary -copy failed: %v", err)
+	}
+	sequence, note, _ := strings.Cut(out.String(), "\a")
+	encoded, ok := strings.CutPrefix(sequence, "\x1b]52;c;")
+	if !ok {
+		t.Fatalf("expected an OSC52 sequence, got %q", out.String())
+	}
+	copied, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("invalid clipboard payload: %v", err)
+	}
+	if !strings.Contains(string(copied), "Timesheet summary for Arnia SRL") || !strings.Contains(string(copied), "Arnia API") || strings.Contains(string(copied), "Internal") {
+		t.Errorf("expected the HTML summary of the client only, got:\n%s", copied)
+	}
+	if note != "Copied the summary of March 2025 to the clipboard\n" {
+		t.Errorf("unexpected output after the sequence: %q", note)
+	}
+
+	if err := Run([]string{"summary", "-format", "pdf"}, &out); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
+
+func TestRunGit(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	api := repository.CreateTestProject(t, 1, "Arnia API", 100)
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+
+	dir := repository.CreateTestGitRepo(t, "me@example.com")
+	repository.CreateTestGitCommit(t, dir, "me@example.com", day.Add(9*time.Hour+30*time.Minute), "Start the API")
+	repository.CreateTestGitCommit(t, dir, "me@example.com", day.Add(11*time.Hour+20*time.Minute), "Add tests")
+	repository.CreateTestGitCommit(t, dir, "colleague@example.com", day.Add(15*time.Hour), "Not mine")
+	repository.CreateTestGitCommit(t, dir, "me@example.com", day.AddDate(0, 0, 1).Add(10*time.Hour), "Next day")
+
+	var out bytes.Buffer
+	if err := Run([]string{"git", "add", "-p", "Arnia API", "-t", "DEV", dir}, &out); err != nil {
+		t.Fatalf("git add failed: %v", err)
+	}
+
+	out.Reset()
+	if err := Run([]string{"git"}, &out); err != nil {
+		t.Fatalf("git list failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "-> Arnia API DEV") {
+		t.Errorf("expected the repository in the list, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := Run([]string{"git", "suggest", "-date", "2025-03-04"}, &out); err != nil {
+		t.Fatalf("git suggest failed: %v", err)
+	}
+	want := filepath.Base(dir) + " DEV 2.5h Arnia API 09:00-11:30, 2 commit(s): Start the API; Add tests\n"
+	if !strings.HasPrefix(out.String(), want) {
+		t.Errorf("expected the suggestion %q, got:\n%s", want, out.String())
+	}
+
+	// Once logged, the suggestion is marked as such
+	repository.CreateTestWorkhour(t, day, dev.ID, api.ID, 3)
+	out.Reset()
+	if err := Run([]string{"git", "suggest", "-date", "2025-03-04"}, &out); err != nil {
+		t.Fatalf("git suggest failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "(already logged)") {
+		t.Errorf("expected the suggestion marked as logged, got:\n%s", out.String())
+	}
+
+	// A configured email takes precedence over the repository's user.email
+	if err := Run([]string{"git", "email", "colleague@example.com"}, &out); err != nil {
+		t.Fatalf("git email failed: %v", err)
+	}
+	out.Reset()
+	if err := Run([]string{"git", "suggest", "-date", "2025-03-04"}, &out); err != nil {
+		t.Fatalf("git suggest failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "14:30-15:00, 1 commit(s): Not mine") {
+		t.Errorf("expected the commits of the configured email, got:\n%s", out.String())
+	}
+
+	if err := Run([]string{"git", "add", "-p", "Arnia API", t.TempDir()}, &out); err == nil {
+		t.Error("expected a directory outside git to be rejected")
+	}
+}
+
+func TestRunPush_Jira(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	type worklog struct {
+		ID               string `json:"id"`
+		Started          string `json:"started"`
+		TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	}
+	worklogs := map[string]worklog{} // By "ISSUE/ID"
+	var requests []string
+	nextID := 100
+	jira := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "me@example.com" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		issue, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/"), "/worklog")
+		id = strings.TrimPrefix(id, "/")
+		if issue == "API-9" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			var body worklog
+			json.NewDecoder(r.Body).Decode(&body)
+			nextID++
+			body.ID = strconv.Itoa(nextID)
+			worklogs[issue+"/"+body.ID] = body
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(body)
+		case http.MethodPut:
+			if _, ok := worklogs[issue+"/"+id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var body worklog
+			json.NewDecoder(r.Body).Decode(&body)
+			body.ID = id
+			worklogs[issue+"/"+id] = body
+			json.NewEncoder(w).Encode(body)
+		case http.MethodDelete:
+			delete(worklogs, issue+"/"+id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer jira.Close()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	api := repository.CreateTestProject(t, 1, "Arnia API", 100)
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	logged := domain.Workhour{Date: day, DetailsID: dev.ID, ProjectID: api.ID, Start: domain.NewClockTime(9, 0), End: domain.NewClockTime(11, 0), IssueKey: "API-1"}
+	loggedID, _, err := repository.CreateWorkhour(logged)
+	if err != nil {
+		t.Fatalf("failed to create workhour: %v", err)
+	}
+	repository.CreateTestWorkhour(t, day, dev.ID, api.ID, 1) // No issue key
+
+	var out bytes.Buffer
+	if err := Run([]string{"push", "jira"}, &out); err == nil {
+		t.Error("expected pushing to an unconfigured Jira to fail")
+	}
+	if err := Run([]string{"push", "config", "jira", jira.URL, "me@example.com"}, &out); err != nil {
+		t.Fatalf("push config failed: %v", err)
+	}
+	t.Setenv("TLTUI_JIRA_TOKEN", "secret")
+
+	out.Reset()
+	if err := Run([]string{"push", "jira", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if out.String() != "Pushed 1 entries of 2025-03-01 - 2025-03-31 to jira, 0 unchanged, 1 without a jira issue key\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+	created, ok := worklogs["API-1/101"]
+	if !ok || created.TimeSpentSeconds != 7200 || !strings.HasPrefix(created.Started, "2025-03-04T09:00:00.000") {
+		t.Fatalf("expected a 2h worklog from 09:00 on API-1, got %+v", worklogs)
+	}
+
+	// Pushing again sends nothing
+	if err := Run([]string{"push", "jira", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected no requests for unchanged entries, got %v", requests)
+	}
+
+	// Changes update the worklog, and a new issue moves it there
+	logged.End = domain.NewClockTime(12, 0)
+	if _, err := repository.UpdateWorkhour(loggedID, logged); err != nil {
+		t.Fatalf("failed to update workhour: %v", err)
+	}
+	if err := Run([]string{"push", "jira", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if worklogs["API-1/101"].TimeSpentSeconds != 10800 || len(worklogs) != 1 {
+		t.Errorf("expected the worklog to be updated to 3h, got %+v", worklogs)
+	}
+	logged.IssueKey = "API-2"
+	repository.UpdateWorkhour(loggedID, logged)
+	if err := Run([]string{"push", "jira", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if _, ok := worklogs["API-2/102"]; !ok || len(worklogs) != 1 {
+		t.Errorf("expected the worklog to move to API-2, got %+v", worklogs)
+	}
+
+	// A failing entry stops the push; entries sent before it are not sent again
+	repository.CreateWorkhour(domain.Workhour{Date: day.AddDate(0, 0, 1), DetailsID: dev.ID, ProjectID: api.ID, Hours: 1, IssueKey: "API-3"})
+	repository.CreateWorkhour(domain.Workhour{Date: day.AddDate(0, 0, 2), DetailsID: dev.ID, ProjectID: api.ID, Hours: 1, IssueKey: "API-9"})
+	out.Reset()
+	err = Run([]string{"push", "jira", "-from", "2025-03-01", "-dry-run"}, &out)
+	if err != nil || !strings.Contains(out.String(), "2025-03-06 DEV 1h Arnia API API-9 (new)\n2 to push") {
+		t.Errorf("unexpected dry run output %q (%v)", out.String(), err)
+	}
+	requests = nil
+	if err := Run([]string{"push", "jira", "-from", "2025-03-01"}, &out); err == nil || !strings.Contains(err.Error(), "API-9: unexpected status 500") {
+		t.Errorf("expected the failing entry to be reported, got %v", err)
+	}
+	requests = nil
+	Run([]string{"push", "jira", "-from", "2025-03-01"}, &out)
+	if !reflect.DeepEqual(requests, []string{"POST /rest/api/3/issue/API-9/worklog"}) {
+		t.Errorf("expected only the failed entry to be retried, got %v", requests)
+	}
+
+	// Worklogs of deleted entries and of entries without a Jira key are deleted
+	logged.IssueKey = ""
+	repository.UpdateWorkhour(loggedID, logged)
+	repository.DeleteWorkhoursByDate(day.AddDate(0, 0, 1))
+	repository.DeleteWorkhoursByDate(day.AddDate(0, 0, 2))
+	requests = nil
+	if err := Run([]string{"push", "jira", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	want := []string{"DELETE /rest/api/3/issue/API-3/worklog/103", "DELETE /rest/api/3/issue/API-2/worklog/102"}
+	if !reflect.DeepEqual(requests, want) || len(worklogs) != 0 {
+		t.Errorf("expected the worklogs to be deleted with %v, got %v and %+v", want, requests, worklogs)
+	}
+}
+
+func TestRunPush_GitLab(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	t.Setenv("TLTUI_GITLAB_TOKEN", "secret")
+
+	var notes []string
+	failing := "" // Issue whose notes fail once
+	gitlab := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issue, ok := strings.CutPrefix(r.URL.EscapedPath(), "/api/v4/projects/group%2Fapi/issues/")
+		issue, ok = strings.CutSuffix(issue, "/notes")
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if issue == failing {
+			failing = ""
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var body struct {
+			Body string `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		notes = append(notes, "#"+issue+" "+body.Body)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": %d}`, len(notes))
+	}))
+	defer gitlab.Close()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	api := repository.CreateTestProject(t, 1, "Arnia API", 100)
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	logged := domain.Workhour{Date: day, DetailsID: dev.ID, ProjectID: api.ID, Hours: 1.5, IssueKey: "group/api#3"}
+	loggedID, _, _ := repository.CreateWorkhour(logged)
+	repository.CreateWorkhour(domain.Workhour{Date: day, DetailsID: dev.ID, ProjectID: api.ID, Hours: 1, IssueKey: "API-1"})
+
+	var out bytes.Buffer
+	if err := Run([]string{"push", "config", "gitlab", gitlab.URL}, &out); err != nil {
+		t.Fatalf("push config failed: %v", err)
+	}
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+
+	// Spent time cannot be edited, changes spend the difference
+	logged.Hours = 1
+	repository.UpdateWorkhour(loggedID, logged)
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	want := []string{"#3 /spend 1h30m 2025-03-04", "#3 /spend -30m 2025-03-04"}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("got notes %q, want %q", notes, want)
+	}
+
+	// A move takes the time back once, also when spending it on the new issue fails
+	logged.IssueKey = "group/api#4"
+	repository.UpdateWorkhour(loggedID, logged)
+	failing = "4"
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err == nil {
+		t.Error("expected the failing spend to be reported")
+	}
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+
+	// Clearing the issue key or deleting the entry takes its time back
+	other := domain.Workhour{Date: day.AddDate(0, 0, 1), DetailsID: dev.ID, ProjectID: api.ID, Hours: 2, IssueKey: "group/api#5"}
+	otherID, _, _ := repository.CreateWorkhour(other)
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	logged.IssueKey = ""
+	repository.UpdateWorkhour(loggedID, logged)
+	if err := repository.DeleteWorkhour(otherID); err != nil {
+		t.Fatalf("failed to delete workhour: %v", err)
+	}
+	out.Reset()
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01", "-dry-run"}, &out); err != nil || !strings.Contains(out.String(), "2025-03-05 2h group/api#5 (retract)\n") {
+		t.Errorf("unexpected dry run output %q (%v)", out.String(), err)
+	}
+	out.Reset()
+	if err := Run([]string{"push", "gitlab", "-from", "2025-03-01"}, &out); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "2 retracted") {
+		t.Errorf("expected the retractions to be counted, got %q", out.String())
+	}
+
+	want = append(want,
+		"#3 /spend -1h 2025-03-04", "#4 /spend 1h 2025-03-04",
+		"#5 /spend 2h 2025-03-05",
+		"#5 /spend -2h 2025-03-05", "#4 /spend -1h 2025-03-04",
+	)
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("got notes %q, want %q", notes, want)
+	}
+}
+
+func TestRunPush_Odoo(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	api := repository.CreateTestProject(t, 1, "Arnia API", 100)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), dev.ID, api.ID, 6)
+
+	path := filepath.Join(t.TempDir(), "odoo.csv")
+	var out bytes.Buffer
+	for range 2 {
+		if err := Run([]string{"push", "odoo", "-from", "2025-03-01", "-o", path}, &out); err != nil {
+			t.Fatalf("push failed: %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the import: %v", err)
+	}
+	want := "date,account_id/id,journal_id/id,name,unit_amount\n2025-03-04,__export__.account_analytic_account_100,hr_timesheet.analytic_journal,Development,6\n"
+	if string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+	if !strings.HasSuffix(out.String(), "Pushed 1 entries of 2025-03-01 - 2025-03-31 to odoo, 0 unchanged\n") {
+		t.Errorf("expected the file to be written again on every push, got %q", out.String())
+	}
+}
+
+func TestRunMail(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	if err := Run([]string{"mail", "smtp", "smtp.example.com", "me@example.com"}, &out); err == nil {
+		t.Error("expected a server without port to be refused")
+	}
+	if err := Run([]string{"mail", "smtp", "smtp.example.com:587", "Me <me@example.com>", "me"}, &out); err != nil {
+		t.Fatalf("mail smtp failed: %v", err)
+	}
+	if err := Run([]string{"mail", "body", `Hi,\n\nReport {period}, invoice {invoice}.`}, &out); err != nil {
+		t.Fatalf("mail body failed: %v", err)
+	}
+
+	if err := Run([]string{"mail"}, &out); err != nil {
+		t.Fatalf("mail failed: %v", err)
+	}
+	for _, want := range []string{"smtp_server   smtp.example.com:587", "smtp_username me", "Subject: Activity report {period}", "Hi,\n\nReport {period}, invoice {invoice}.", "{invoice}"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+
+	sentAt := time.Date(2025, 4, 1, 10, 30, 0, 0, time.Local)
+	repository.CreateSentEmail(domain.SentEmail{
+		Recipients:  []string{"ana@arnia.example", "billing@arnia.example"},
+		Subject:     "Activity report March 2025",
+		Attachments: []string{"report.pdf"},
+		SentAt:      sentAt,
+	})
+	out.Reset()
+	if err := Run([]string{"mail", "sent"}, &out); err != nil {
+		t.Fatalf("mail sent failed: %v", err)
+	}
+	want := "2025-04-01 10:30  Activity report March 2025  to ana@arnia.example, billing@arnia.example (report.pdf)\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
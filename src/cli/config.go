@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// configKeys lists the settings that can be changed from the command line, with their description
+var configKeys = map[string]string{
+	repository.SettingDailyTargetHours:  "working hours per day",
+	repository.SettingWeeklyTargetHours: "working hours per week",
+	repository.SettingHoursRounding:     "step entered hours are rounded to, e.g. 0.25 (0 = no rounding)",
+	repository.SettingBackupKeep:        "automatic backups kept, one per day (0 = no automatic backups)",
+}
+
+// ruleDescriptions describes the rules that can be configured as "off", "warn" or "block"
+var ruleDescriptions = map[domain.RuleKind]string{
+	domain.RuleMaxDailyHours:   "maximum hours per day, e.g. \"block 16\"",
+	domain.RuleMaxWeeklyHours:  "maximum hours per week, e.g. \"warn 60\"",
+	domain.RuleNoWorkOnLeave:   "no work entries on full leave days",
+	domain.RuleNonWorkFullDay:  "non-work entries must total the daily target",
+	domain.RuleNoFutureEntries: "no entries after today",
+}
+
+// runConfig handles "config get KEY" and "config set KEY VALUE"
+func runConfig(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		for _, key := range []string{repository.SettingDailyTargetHours, repository.SettingWeeklyTargetHours, repository.SettingHoursRounding, repository.SettingBackupKeep} {
+			value, _, err := repository.GetSetting(key)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "%-20s %-6s %s\n", key, value, configKeys[key])
+		}
+		for _, rule := range repository.GetRules() {
+			fmt.Fprintf(stdout, "%-26s %-9s %s\n", repository.RuleSettingKey(rule.Kind), rule.SettingValue(), ruleDescriptions[rule.Kind])
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: tltui config get KEY")
+		}
+		value, ok, err := repository.GetSetting(args[1])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("setting %q is not set", args[1])
+		}
+		fmt.Fprintln(stdout, value)
+		return nil
+
+	case "set":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: tltui config set KEY VALUE")
+		}
+		key, value := args[1], args[2]
+		if kind, ok := ruleKindForKey(key); ok {
+			rule, err := domain.ParseRule(kind, value)
+			if err != nil {
+				return err
+			}
+			return repository.SetRule(rule)
+		}
+		if _, known := configKeys[key]; !known {
+			return fmt.Errorf("unknown setting %q", key)
+		}
+		if number, err := strconv.ParseFloat(value, 64); err != nil || number < 0 {
+			return fmt.Errorf("%s must be a non-negative number", key)
+		}
+		if _, err := strconv.Atoi(value); key == repository.SettingBackupKeep && err != nil {
+			return fmt.Errorf("%s must be a whole number", key)
+		}
+		return repository.SetSetting(key, value)
+
+	default:
+		return fmt.Errorf("unknown config command %q, use get or set", args[0])
+	}
+}
+
+// ruleKindForKey maps a rule setting key such as "rule_max_daily_hours" to its rule
+func ruleKindForKey(key string) (domain.RuleKind, bool) {
+	for _, kind := range domain.RuleKinds {
+		if strings.EqualFold(key, repository.RuleSettingKey(kind)) {
+			return kind, true
+		}
+	}
+	return "", false
+}
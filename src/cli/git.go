@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// runGit handles "git", "git add -p PROJECT [-t TYPE] PATH", "git remove ID",
+// "git email [ADDRESS]" and "git suggest [-date DATE]"
+func runGit(args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] == "list" {
+		return listGitRepos(stdout)
+	}
+
+	switch args[0] {
+	case "add":
+		flags := flag.NewFlagSet("git add", flag.ContinueOnError)
+		flags.SetOutput(stdout)
+		projectFlag := flags.String("p", "", "project name or ID the commits are logged on (required)")
+		typeFlag := flags.String("t", "", "workhour type of the suggestions (default: chosen when accepting)")
+		if err := flags.Parse(args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil
+			}
+			return err
+		}
+		if flags.NArg() != 1 || *projectFlag == "" {
+			return fmt.Errorf("usage: tltui git add -p PROJECT [-t TYPE] PATH")
+		}
+
+		path, err := repository.ResolveGitRepoPath(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		project, err := findProject(*projectFlag)
+		if err != nil {
+			return err
+		}
+		repo := domain.GitRepo{Path: path, ProjectID: project.ID}
+		if *typeFlag != "" {
+			details, err := findWorkhourDetails(*typeFlag)
+			if err != nil {
+				return err
+			}
+			repo.DetailsID = details.ID
+		}
+		if _, err := repository.SaveGitRepo(repo); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Suggesting workhours on %s from the commits in %s\n", project.Name, path)
+		return nil
+
+	case "remove":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: tltui git remove ID")
+		}
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid repository ID %q", args[1])
+		}
+		if err := repository.DeleteGitRepo(id); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Removed repository #%d\n", id)
+		return nil
+
+	case "email":
+		switch len(args) {
+		case 1:
+			if email := repository.GetGitAuthorEmail(); email != "" {
+				fmt.Fprintln(stdout, email)
+			} else {
+				fmt.Fprintln(stdout, "Not set, the user.email of each repository is used")
+			}
+			return nil
+		case 2:
+			return repository.SetSetting(repository.SettingGitAuthorEmail, args[1])
+		}
+		return fmt.Errorf("usage: tltui git email [ADDRESS]")
+
+	case "suggest":
+		flags := flag.NewFlagSet("git suggest", flag.ContinueOnError)
+		flags.SetOutput(stdout)
+		dateFlag := flags.String("date", "today", "day to suggest workhours for, YYYY-MM-DD, today or yesterday")
+		if err := flags.Parse(args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil
+			}
+			return err
+		}
+		date, err := parseDate(*dateFlag)
+		if err != nil {
+			return err
+		}
+		return listGitSuggestions(stdout, date)
+
+	default:
+		return fmt.Errorf("unknown git command %q, use list, add, remove, email or suggest", args[0])
+	}
+}
+
+func listGitRepos(stdout io.Writer) error {
+	repos, err := repository.GetGitRepos()
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		fmt.Fprintln(stdout, "No repositories, add one with 'tltui git add -p PROJECT PATH'")
+		return nil
+	}
+
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return err
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		line := fmt.Sprintf("#%d %s -> %s", repo.ID, repo.Path, projectName(repo.ProjectID, projects))
+		for _, d := range workhourDetails {
+			if d.ID == repo.DetailsID {
+				line += " " + d.ShortName
+			}
+		}
+		fmt.Fprintln(stdout, line)
+	}
+	return nil
+}
+
+func listGitSuggestions(stdout io.Writer, date time.Time) error {
+	suggestions, suggestErr := repository.SuggestWorkhours(date)
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return err
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return err
+	}
+
+	if len(suggestions) == 0 && suggestErr == nil {
+		fmt.Fprintf(stdout, "No commits on %s\n", date.Format("2006-01-02"))
+	}
+	for _, s := range suggestions {
+		wh := s.Workhour
+		typeName := "?"
+		for _, d := range workhourDetails {
+			if d.ID == wh.DetailsID {
+				typeName = d.ShortName
+			}
+		}
+		line := fmt.Sprintf("%s %s %gh %s %s", filepath.Base(s.Repo.Path), typeName, math.Round(wh.Hours*100)/100, projectName(wh.ProjectID, projects), wh.TimeRange())
+		if wh.BreakMinutes > 0 {
+			line += fmt.Sprintf(" -%dm", wh.BreakMinutes)
+		}
+		line += fmt.Sprintf(", %d commit(s): %s", len(s.Commits), s.Description)
+		if s.Logged {
+			line += " (already logged)"
+		}
+		fmt.Fprintln(stdout, line)
+	}
+	if len(suggestions) > 0 {
+		fmt.Fprintln(stdout, "Accept or edit them in the day view of the calendar (g)")
+	}
+	return suggestErr
+}
+
+func projectName(id int, projects []domain.Project) string {
+	for _, p := range projects {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return fmt.Sprintf("project %d", id)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// runHistory lists the recorded workhour changes touching a date range
+func runHistory(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	fromFlag := flags.String("from", "today", "first day, YYYY-MM-DD, today or yesterday")
+	toFlag := flags.String("to", "", "last day, YYYY-MM-DD, today or yesterday (default: same as -from)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	from, err := parseDate(*fromFlag)
+	if err != nil {
+		return err
+	}
+	to := from
+	if *toFlag != "" {
+		if to, err = parseDate(*toFlag); err != nil {
+			return err
+		}
+	}
+	if to.Before(from) {
+		return fmt.Errorf("-to must not be before -from")
+	}
+
+	changes, err := repository.GetWorkhourHistory(from, to)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(stdout, "No changes recorded")
+		return nil
+	}
+
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return err
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return err
+	}
+	describe := func(wh *domain.Workhour) string {
+		return describeWorkhour(wh, projects, workhourDetails)
+	}
+
+	for _, c := range changes {
+		var change string
+		switch c.Action {
+		case domain.ChangeCreate:
+			change = describe(c.New)
+		case domain.ChangeDelete:
+			change = describe(c.Old)
+		default:
+			change = describe(c.Old) + " -> " + describe(c.New)
+		}
+		fmt.Fprintf(stdout, "%s  %-6s %-6s #%d %s\n", c.ChangedAt.Format("2006-01-02 15:04:05"), c.Source, c.Action, c.WorkhourID, change)
+	}
+	return nil
+}
+
+// describeWorkhour formats a workhour of the history, e.g. "2025-03-04 DEV 2h Arnia API 09:00-11:00"
+func describeWorkhour(wh *domain.Workhour, projects []domain.Project, workhourDetails []domain.WorkhourDetails) string {
+	typeName := fmt.Sprintf("type %d", wh.DetailsID)
+	for _, d := range workhourDetails {
+		if d.ID == wh.DetailsID {
+			typeName = d.ShortName
+			break
+		}
+	}
+	projectName := fmt.Sprintf("project %d", wh.ProjectID)
+	for _, p := range projects {
+		if p.ID == wh.ProjectID {
+			projectName = p.Name
+			break
+		}
+	}
+
+	desc := fmt.Sprintf("%s %s %gh %s", wh.Date.Format("2006-01-02"), typeName, math.Round(wh.Hours*100)/100, projectName)
+	if wh.HasTimes() {
+		desc += " " + wh.TimeRange()
+	}
+	return desc
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	generator "tltui/src/elm-store/calendar/report-generator"
+)
+
+// runICS handles "ics", writing the workhours of a date range as an iCalendar file
+func runICS(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("ics", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	fromFlag := flags.String("from", "", "first day, YYYY-MM-DD, today or yesterday (default: first day of the month)")
+	toFlag := flags.String("to", "", "last day, YYYY-MM-DD, today or yesterday (default: last day of the -from month)")
+	projectFlag := flags.String("p", "", "comma-separated project names or IDs to export (default: all)")
+	typeFlag := flags.String("t", "", "comma-separated workhour types to export (default: all)")
+	outFlag := flags.String("o", "", "file to write (default: standard output)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: tltui ics [-from DATE] [-to DATE] [-p PROJECTS] [-t TYPES] [-o FILE]")
+	}
+
+	from, to, err := parseRange(*fromFlag, *toFlag)
+	if err != nil {
+		return err
+	}
+
+	var filter domain.ICSFilter
+	for _, query := range splitList(*projectFlag) {
+		project, err := findProject(query)
+		if err != nil {
+			return err
+		}
+		filter.ProjectIDs = append(filter.ProjectIDs, project.ID)
+	}
+	for _, query := range splitList(*typeFlag) {
+		details, err := findWorkhourDetails(query)
+		if err != nil {
+			return err
+		}
+		filter.DetailsIDs = append(filter.DetailsIDs, details.ID)
+	}
+
+	if *outFlag == "" {
+		return generator.BuildICS(stdout, from, to, filter)
+	}
+
+	file, err := os.Create(*outFlag)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *outFlag, err)
+	}
+	if err := generator.BuildICS(file, from, to, filter); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *outFlag, err)
+	}
+	fmt.Fprintf(stdout, "Exported %s to %s\n", formatRange(from, to), *outFlag)
+	return nil
+}
+
+// parseRange reads the -from and -to flags, defaulting to the current month and
+// to the end of the -from month
+func parseRange(fromValue, toValue string) (time.Time, time.Time, error) {
+	from, err := parseDate("today")
+	if err != nil {
+		return from, from, err
+	}
+	from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local)
+	if fromValue != "" {
+		if from, err = parseDate(fromValue); err != nil {
+			return from, from, err
+		}
+	}
+	to := time.Date(from.Year(), from.Month()+1, 0, 0, 0, 0, 0, time.Local)
+	if toValue != "" {
+		if to, err = parseDate(toValue); err != nil {
+			return from, to, err
+		}
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("-to must not be before -from")
+	}
+	return from, to, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatRange(from, to time.Time) string {
+	if from.Equal(to) {
+		return from.Format("2006-01-02")
+	}
+	return from.Format("2006-01-02") + " - " + to.Format("2006-01-02")
+}
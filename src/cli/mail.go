@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"strings"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// runMail shows or sets how generated reports are emailed, and lists the emails sent
+func runMail(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return printMailSettings(stdout)
+	}
+
+	switch {
+	case args[0] == "smtp" && (len(args) == 3 || len(args) == 4):
+		if _, _, err := net.SplitHostPort(args[1]); err != nil {
+			return fmt.Errorf("mail server must be HOST:PORT, e.g. smtp.example.com:587")
+		}
+		if _, err := mail.ParseAddress(args[2]); err != nil {
+			return fmt.Errorf("%q is not a valid sender address", args[2])
+		}
+		username := ""
+		if len(args) == 4 {
+			username = args[3]
+		}
+		for key, value := range map[string]string{
+			repository.SettingSMTPServer:   args[1],
+			repository.SettingSMTPFrom:     args[2],
+			repository.SettingSMTPUsername: username,
+		} {
+			if err := repository.SetSetting(key, value); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case args[0] == "subject" && len(args) == 2:
+		return repository.SetSetting(repository.SettingEmailSubjectTemplate, args[1])
+
+	case args[0] == "body" && len(args) == 2:
+		// Line breaks can be written as \n on the command line
+		return repository.SetSetting(repository.SettingEmailBodyTemplate, strings.ReplaceAll(args[1], `\n`, "\n"))
+
+	case args[0] == "sent" && len(args) == 1:
+		return printSentEmails(stdout)
+	}
+	return fmt.Errorf("usage: tltui mail [smtp HOST:PORT FROM [USERNAME] | subject TEMPLATE | body TEMPLATE | sent]")
+}
+
+func printMailSettings(stdout io.Writer) error {
+	for _, key := range []string{repository.SettingSMTPServer, repository.SettingSMTPFrom, repository.SettingSMTPUsername} {
+		value, _, err := repository.GetSetting(key)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(stdout, "%-13s %s\n", key, value)
+	}
+	fmt.Fprintln(stdout, "The SMTP password is read from TLTUI_SMTP_PASSWORD")
+
+	subject, body, err := repository.GetEmailTemplates()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\nSubject: %s\n\n%s\n", subject, strings.TrimRight(body, "\n"))
+
+	fmt.Fprintln(stdout, "\nPlaceholders:")
+	for _, placeholder := range domain.EmailPlaceholders {
+		fmt.Fprintf(stdout, "  %-10s %s\n", placeholder[0], placeholder[1])
+	}
+	return nil
+}
+
+func printSentEmails(stdout io.Writer) error {
+	emails, err := repository.GetSentEmails()
+	if err != nil {
+		return err
+	}
+	if len(emails) == 0 {
+		fmt.Fprintln(stdout, "No reports emailed yet")
+		return nil
+	}
+
+	for _, email := range emails {
+		fmt.Fprintf(stdout, "%s  %s  to %s (%s)\n",
+			email.SentAt.Format("2006-01-02 15:04"), email.Subject,
+			strings.Join(email.Recipients, ", "), strings.Join(email.Attachments, ", "))
+	}
+	return nil
+}
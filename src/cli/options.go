@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Options are the flags given in front of the command, e.g. "tltui --profile acme add ..."
+type Options struct {
+	Profile  string // Profile to open, the default one when empty
+	DB       string // Database file to open instead of a profile's
+	ReadOnly bool   // Open the database in query-only mode
+}
+
+// ParseOptions reads the flags in front of the command and returns the remaining
+// arguments. Environment variables, read through getenv, give the defaults.
+func ParseOptions(args []string, getenv func(string) string) (Options, []string, error) {
+	opts := Options{
+		Profile: getenv("TLTUI_PROFILE"),
+		DB:      getenv("TLTUI_DB"),
+	}
+
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+
+		switch name {
+		case "profile", "db":
+			if !hasValue {
+				if len(args) < 2 {
+					return opts, nil, fmt.Errorf("--%s needs a value", name)
+				}
+				value = args[1]
+				args = args[1:]
+			}
+			if name == "profile" {
+				opts.Profile = value
+			} else {
+				opts.DB = value
+			}
+
+		case "read-only":
+			opts.ReadOnly = true
+			if hasValue {
+				enabled, err := strconv.ParseBool(value)
+				if err != nil {
+					return opts, nil, fmt.Errorf("invalid --read-only value %q", value)
+				}
+				opts.ReadOnly = enabled
+			}
+
+		default:
+			// Not a global flag, e.g. "--help", left for the command
+			return opts, args, nil
+		}
+		args = args[1:]
+	}
+
+	return opts, args, nil
+}
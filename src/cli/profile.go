@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"tltui/src/domain/repository"
+)
+
+// runProfile handles "profile list", "profile create NAME", "profile rename OLD NEW" and "profile delete NAME"
+func runProfile(args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] == "list" {
+		profiles, err := repository.ListProfiles()
+		if err != nil {
+			return err
+		}
+		for _, name := range profiles {
+			marker := " "
+			if name == repository.CurrentProfile() {
+				marker = "*"
+			}
+			dir, _ := repository.ProfileDir(name)
+			fmt.Fprintf(stdout, "%s %-20s %s\n", marker, name, dir)
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "create":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: tltui profile create NAME")
+		}
+		if err := repository.CreateProfile(args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Created profile %s, open it with --profile %s\n", args[1], args[1])
+		return nil
+
+	case "rename":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: tltui profile rename OLD NEW")
+		}
+		if err := repository.RenameProfile(args[1], args[2]); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Renamed profile %s to %s\n", args[1], args[2])
+		return nil
+
+	case "delete":
+		flags := flag.NewFlagSet("profile delete", flag.ContinueOnError)
+		flags.SetOutput(stdout)
+		confirmed := flags.Bool("yes", false, "confirm deleting the profile and all its data")
+		if err := flags.Parse(args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil
+			}
+			return err
+		}
+		if flags.NArg() != 1 {
+			return fmt.Errorf("usage: tltui profile delete [-yes] NAME")
+		}
+		name := flags.Arg(0)
+		if !*confirmed {
+			return fmt.Errorf("deleting profile %s removes all its data, run again with -yes to confirm", name)
+		}
+		if err := repository.DeleteProfile(name); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Deleted profile %s\n", name)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown profile command %q, use list, create, rename or delete", args[0])
+	}
+}
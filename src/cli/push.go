@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"tltui/src/domain/repository"
+	generator "tltui/src/elm-store/calendar/report-generator"
+)
+
+// runPush handles "push SINK" and "push config", sending logged hours to Odoo,
+// Jira or GitLab. Entries pushed before are only sent again when they changed.
+func runPush(args []string, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "config" {
+		return runPushConfig(args[1:], stdout)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("usage: tltui push odoo|jira|gitlab [-from DATE] [-to DATE] [-dry-run] [-o FILE]")
+	}
+
+	sinkName := args[0]
+	flags := flag.NewFlagSet("push "+sinkName, flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	fromFlag := flags.String("from", "", "first day, YYYY-MM-DD, today or yesterday (default: first day of the month)")
+	toFlag := flags.String("to", "", "last day, YYYY-MM-DD, today or yesterday (default: last day of the -from month)")
+	dryRunFlag := flags.Bool("dry-run", false, "list the entries that would be pushed without sending them")
+	outFlag := flags.String("o", "", "file the odoo import is written to (required for odoo)")
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: tltui push odoo|jira|gitlab [-from DATE] [-to DATE] [-dry-run] [-o FILE]")
+	}
+
+	from, to, err := parseRange(*fromFlag, *toFlag)
+	if err != nil {
+		return err
+	}
+
+	sink, err := newTimesheetSink(sinkName, *outFlag)
+	if err != nil {
+		return err
+	}
+
+	if *dryRunFlag {
+		push, err := generator.PlanTimesheetPush(sink, from, to)
+		if err != nil {
+			return err
+		}
+		for _, export := range push.Retract {
+			fmt.Fprintf(stdout, "%s %gh %s (retract)\n", export.Date.Format("2006-01-02"), math.Round(export.Hours*100)/100, export.IssueKey)
+		}
+		for _, entry := range push.Pending {
+			wh := entry.Workhour
+			action := "new"
+			if entry.Export != nil {
+				action = "update"
+			}
+			line := fmt.Sprintf("%s %s %gh %s", wh.Date.Format("2006-01-02"), entry.Details.ShortName, math.Round(wh.Hours*100)/100, entry.Project.Name)
+			if wh.IssueKey != "" {
+				line += " " + wh.IssueKey
+			}
+			fmt.Fprintf(stdout, "%s (%s)\n", line, action)
+		}
+		summary := fmt.Sprintf("%d to push to %s", len(push.Pending), sinkName)
+		if len(push.Retract) > 0 {
+			summary += fmt.Sprintf(", %d to retract", len(push.Retract))
+		}
+		fmt.Fprintf(stdout, "%s, %s\n", summary, describePushRest(push, sinkName))
+		return nil
+	}
+
+	push, err := generator.PushTimesheet(sink, from, to)
+	fmt.Fprintf(stdout, "Pushed %d entries of %s to %s, %s\n", push.Sent, formatRange(from, to), sinkName, describePushRest(push, sinkName))
+	return err
+}
+
+// newTimesheetSink sets up a sink from the settings and the token in the environment
+func newTimesheetSink(name, outPath string) (generator.TimesheetSink, error) {
+	switch name {
+	case "odoo":
+		if outPath == "" {
+			return nil, fmt.Errorf("odoo needs the file to write, use -o FILE")
+		}
+		return generator.OdooCSVSink{Path: outPath}, nil
+
+	case generator.JiraSinkName:
+		url, _, err := repository.GetSetting(repository.SettingJiraURL)
+		if err != nil {
+			return nil, err
+		}
+		email, _, err := repository.GetSetting(repository.SettingJiraEmail)
+		if err != nil {
+			return nil, err
+		}
+		token := os.Getenv("TLTUI_JIRA_TOKEN")
+		if url == "" || email == "" || token == "" {
+			return nil, fmt.Errorf("jira is not set up, run 'tltui push config jira URL EMAIL' and set TLTUI_JIRA_TOKEN")
+		}
+		return generator.JiraSink{URL: url, Email: email, Token: token}, nil
+
+	case generator.GitLabSinkName:
+		url, _, err := repository.GetSetting(repository.SettingGitLabURL)
+		if err != nil {
+			return nil, err
+		}
+		token := os.Getenv("TLTUI_GITLAB_TOKEN")
+		if url == "" || token == "" {
+			return nil, fmt.Errorf("gitlab is not set up, run 'tltui push config gitlab URL' and set TLTUI_GITLAB_TOKEN")
+		}
+		return generator.GitLabSink{URL: url, Token: token}, nil
+	}
+	return nil, fmt.Errorf("unknown sink %q, use odoo, jira or gitlab", name)
+}
+
+// describePushRest counts the entries a push leaves out
+func describePushRest(push generator.TimesheetPush, sinkName string) string {
+	rest := fmt.Sprintf("%d unchanged", push.Unchanged)
+	if push.Retracted > 0 {
+		rest += fmt.Sprintf(", %d retracted", push.Retracted)
+	}
+	if push.Skipped > 0 {
+		rest += fmt.Sprintf(", %d without a %s issue key", push.Skipped, sinkName)
+	}
+	return rest
+}
+
+// runPushConfig shows or sets the issue tracker connections
+func runPushConfig(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		for _, key := range []string{repository.SettingJiraURL, repository.SettingJiraEmail, repository.SettingGitLabURL} {
+			value, _, err := repository.GetSetting(key)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				value = "-"
+			}
+			fmt.Fprintf(stdout, "%-12s %s\n", key, value)
+		}
+		fmt.Fprintln(stdout, "API tokens are read from TLTUI_JIRA_TOKEN and TLTUI_GITLAB_TOKEN")
+		return nil
+	}
+
+	switch {
+	case args[0] == generator.JiraSinkName && len(args) == 3:
+		if err := repository.SetSetting(repository.SettingJiraURL, args[1]); err != nil {
+			return err
+		}
+		return repository.SetSetting(repository.SettingJiraEmail, args[2])
+	case args[0] == generator.GitLabSinkName && len(args) == 2:
+		return repository.SetSetting(repository.SettingGitLabURL, args[1])
+	}
+	return fmt.Errorf("usage: tltui push config [jira URL EMAIL | gitlab URL]")
+}
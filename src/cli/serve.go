@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"tltui/src/api"
+)
+
+// DefaultServeAddr is where "serve" listens unless -addr is given
+const DefaultServeAddr = "127.0.0.1:8765"
+
+// runServe handles "serve [-addr HOST:PORT] [-token TOKEN]", serving the HTTP/JSON API
+// until interrupted. Without a token, TLTUI_API_TOKEN is used or a new one is printed.
+func runServe(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	addr := flags.String("addr", DefaultServeAddr, "loopback address to listen on")
+	token := flags.String("token", "", "bearer token clients must send (default $TLTUI_API_TOKEN, or a generated one)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: tltui serve [-addr HOST:PORT] [-token TOKEN]")
+	}
+	if err := checkLoopback(*addr); err != nil {
+		return err
+	}
+
+	if *token == "" {
+		*token = os.Getenv("TLTUI_API_TOKEN")
+	}
+	if *token == "" {
+		generated, err := newToken()
+		if err != nil {
+			return err
+		}
+		*token = generated
+		fmt.Fprintf(stdout, "Token: %s\n", *token)
+	}
+
+	fmt.Fprintf(stdout, "Serving the API on http://%s/api\n", *addr)
+	return api.ListenAndServe(*addr, *token)
+}
+
+// checkLoopback rejects addresses reachable from other machines, the API is meant for local tools
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("address %q is not a loopback address such as 127.0.0.1", addr)
+	}
+	return nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	generator "tltui/src/elm-store/calendar/report-generator"
+)
+
+// runSummary handles "summary", writing the Markdown or HTML summary of a month
+func runSummary(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("summary", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	monthFlag := flags.String("month", "", "month to summarize as YYYY-MM (default: current month)")
+	formatFlag := flags.String("format", "md", "md or html")
+	clientFlag := flags.String("c", "", "client name or ID to limit the summary to")
+	copyFlag := flags.Bool("copy", false, "copy the summary to the clipboard through the terminal (OSC52), also over SSH")
+	outFlag := flags.String("o", "", "file to write (default: standard output, unless -copy is given)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: tltui summary [-month YYYY-MM] [-format md|html] [-c CLIENT] [-copy] [-o FILE]")
+	}
+
+	month := time.Now()
+	if *monthFlag != "" {
+		parsed, err := time.ParseInLocation("2006-01", *monthFlag, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid month %q, use YYYY-MM", *monthFlag)
+		}
+		month = parsed
+	}
+
+	format := generator.SummaryFormat(*formatFlag)
+	if format != generator.SummaryFormatMarkdown && format != generator.SummaryFormatHTML {
+		return fmt.Errorf("invalid format %q, use md or html", *formatFlag)
+	}
+
+	var client *domain.Client
+	if *clientFlag != "" {
+		var err error
+		if client, err = findClient(*clientFlag); err != nil {
+			return err
+		}
+	}
+
+	content, err := generator.BuildSummary(format, int(month.Month()), month.Year(), client)
+	if err != nil {
+		return err
+	}
+
+	if *outFlag == "" && !*copyFlag {
+		_, err := io.WriteString(stdout, content)
+		return err
+	}
+
+	if *outFlag != "" {
+		if err := os.WriteFile(*outFlag, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", *outFlag, err)
+		}
+		fmt.Fprintf(stdout, "Wrote the summary of %s to %s\n", month.Format("January 2006"), *outFlag)
+	}
+	if *copyFlag {
+		if err := common.CopyToClipboard(stdout, content); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Copied the summary of %s to the clipboard\n", month.Format("January 2006"))
+	}
+	return nil
+}
+
+// findClient resolves a client by ID, exact name or unique partial name
+func findClient(query string) (*domain.Client, error) {
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(clients))
+	ids := make([]int, len(clients))
+	for i, c := range clients {
+		names[i], ids[i] = c.Name, c.ID
+	}
+
+	index, err := resolve("client", query, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	return &clients[index], nil
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// runSync handles "sync", "sync init FOLDER", "sync status", "sync conflicts" and "sync resolve [-use-other] ID"
+func runSync(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		result, err := repository.Sync()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Applied %d changes from other devices, shared %d local changes\n", result.Applied, result.Exported)
+		if result.Conflicts > 0 {
+			fmt.Fprintf(stdout, "%d conflicts, the newest change was kept; review them with 'tltui sync conflicts'\n", result.Conflicts)
+		}
+		if result.Locked > 0 {
+			fmt.Fprintf(stdout, "%d changes touch locked months and were not applied; unlock the months to use them with 'tltui sync resolve -use-other ID'\n", result.Locked)
+		}
+		for _, warning := range result.BudgetWarnings {
+			fmt.Fprintln(stdout, "⚠ "+warning.Message())
+		}
+		for _, violation := range result.RuleViolations {
+			fmt.Fprintln(stdout, "⚠ "+violation.Message)
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "init":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: tltui sync init FOLDER")
+		}
+		replica, err := repository.InitSync(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Syncing through %s as replica %s, run 'tltui sync' to share changes\n", args[1], replica)
+		return nil
+
+	case "status":
+		dir, replica, err := repository.GetSyncConfig()
+		if err != nil {
+			return err
+		}
+		if dir == "" {
+			fmt.Fprintln(stdout, "Sync is not set up, run 'tltui sync init FOLDER'")
+			return nil
+		}
+		conflicts, err := repository.GetSyncConflicts()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Folder:    %s\nReplica:   %s\nConflicts: %d\n", dir, replica, len(conflicts))
+		return nil
+
+	case "conflicts":
+		return listSyncConflicts(stdout)
+
+	case "resolve":
+		flags := flag.NewFlagSet("sync resolve", flag.ContinueOnError)
+		flags.SetOutput(stdout)
+		useOther := flags.Bool("use-other", false, "replace the kept value with the one the conflict discarded")
+		if err := flags.Parse(args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil
+			}
+			return err
+		}
+		if flags.NArg() != 1 {
+			return fmt.Errorf("usage: tltui sync resolve [-use-other] ID")
+		}
+		id, err := strconv.Atoi(flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("invalid conflict ID %q", flags.Arg(0))
+		}
+		if err := repository.ResolveSyncConflict(id, *useOther); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Resolved conflict #%d\n", id)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown sync command %q, use init, status, conflicts or resolve", args[0])
+	}
+}
+
+func listSyncConflicts(stdout io.Writer) error {
+	conflicts, err := repository.GetSyncConflicts()
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		fmt.Fprintln(stdout, "No conflicts")
+		return nil
+	}
+
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return err
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return err
+	}
+	describe := func(wh *domain.Workhour) string {
+		if wh == nil {
+			return "deleted"
+		}
+		return describeWorkhour(wh, projects, workhourDetails)
+	}
+
+	for _, c := range conflicts {
+		fmt.Fprintf(stdout, "#%d %s kept %s: %s, discarded: %s\n",
+			c.ID, c.DetectedAt.Format("2006-01-02 15:04"), c.Kept, describe(c.KeptValue()), describe(c.OtherValue()))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// CopyToClipboard asks the terminal to put text on the system clipboard with an
+// OSC52 escape sequence. The terminal does the copying, so it also works over SSH;
+// terminals without OSC52 support ignore the sequence. Inside tmux and screen the
+// sequence is wrapped to reach the outer terminal.
+func CopyToClipboard(out io.Writer, text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+
+	if _, err := seq.WriteTo(out); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	text := "| Project | Hours |\n| Arnia | 8 |"
+
+	tests := []struct {
+		name   string
+		tmux   string
+		term   string
+		prefix string
+	}{
+		{"plain terminal", "", "xterm-256color", "\x1b]52;c;"},
+		{"tmux", "/tmp/tmux-1000/default,1,0", "tmux-256color", "\x1bPtmux;\x1b\x1b]52;c;"},
+		{"screen", "", "screen", "\x1bP\x1b]52;c;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("TERM", tt.term)
+
+			var buf bytes.Buffer
+			if err := CopyToClipboard(&buf, text); err != nil {
+				t.Fatalf("CopyToClipboard failed: %v", err)
+			}
+			got := buf.String()
+			if !strings.HasPrefix(got, tt.prefix) {
+				t.Errorf("sequence %q does not start with %q", got, tt.prefix)
+			}
+			if !strings.Contains(got, base64.StdEncoding.EncodeToString([]byte(text))) {
+				t.Errorf("sequence %q does not carry the base64 encoded text", got)
+			}
+		})
+	}
+}
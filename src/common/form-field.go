@@ -12,6 +12,7 @@ type FormField struct {
 	Required   bool
 	Validator  func(string) error
 	HelpText   string
+	Preview    func(string) string // Describes how the current value is interpreted, shown live under the input
 	ErrorStyle lipgloss.Style
 	LabelStyle lipgloss.Style
 	Focused    bool
@@ -54,6 +55,11 @@ func (f FormField) WithHelpText(text string) FormField {
 	return f
 }
 
+func (f FormField) WithPreview(preview func(string) string) FormField {
+	f.Preview = preview
+	return f
+}
+
 func (f FormField) WithInitialValue(value string) FormField {
 	f.Input.SetValue(value)
 	return f
@@ -70,17 +76,16 @@ func (f *FormField) Blur() {
 }
 
 func (f *FormField) Update(msg tea.Msg) tea.Cmd {
-  switch msg := msg.(type) {
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
-	  switch msg.String() {
-		    case "up":
-		      return DispatchFocusPrev()
-		    case "down", "enter":
-		      return DispatchFocusNext()
+		switch msg.String() {
+		case "up":
+			return DispatchFocusPrev()
+		case "down", "enter":
+			return DispatchFocusNext()
 		}
 	}
 
-
 	var cmd tea.Cmd
 	f.Input, cmd = f.Input.Update(msg)
 	return cmd
@@ -117,6 +122,16 @@ func (f FormField) View() string {
 	output += f.Input.View()
 	output += "\n"
 
+	// Live interpretation of the value
+	if f.Preview != nil {
+		if preview := f.Preview(f.Input.Value()); preview != "" {
+			previewStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color("86"))
+			output += previewStyle.Render(preview)
+			output += "\n"
+		}
+	}
+
 	// Help text
 	if f.HelpText != "" {
 		helpStyle := lipgloss.NewStyle().
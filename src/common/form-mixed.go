@@ -152,6 +152,16 @@ func (f *MixedForm) GetSelect(index int) *FormSelect {
 	return nil
 }
 
+func (f *MixedForm) GetSearchSelect(index int) *FormSearchSelect {
+	if index < 0 || index >= len(f.Elements) {
+		return nil
+	}
+	if select_, ok := f.Elements[index].(*FormSearchSelect); ok {
+		return select_
+	}
+	return nil
+}
+
 func (f *MixedForm) Validate() error {
 	f.ErrorMessage = ""
 
@@ -171,6 +181,14 @@ func (f *MixedForm) Validate() error {
 				return err
 			}
 		}
+
+		if select_, ok := element.(*FormSearchSelect); ok {
+			if err := select_.Validate(); err != nil {
+				f.ErrorMessage = err.Error()
+				f.FocusField(i)
+				return err
+			}
+		}
 	}
 
 	return nil
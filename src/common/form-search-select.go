@@ -0,0 +1,364 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultSearchSelectVisible = 6
+
+// FormSearchSelect is a select that filters its options as the user types.
+// Options are fuzzy matched against the query, ranked by most recent use
+// and shown through a scrolling window.
+type FormSearchSelect struct {
+	Label      string
+	Options    []SelectOption
+	Query      string
+	Focused    bool
+	Required   bool
+	MaxVisible int
+
+	RecentIDs []int // Option IDs, most recently used first
+
+	Matches []SearchMatch // Options matching Query, best first
+	Cursor  int           // Index of the selected match
+	Offset  int           // Index of the first visible match
+
+	LabelStyle     lipgloss.Style
+	ValueStyle     lipgloss.Style
+	FocusedStyle   lipgloss.Style
+	HighlightStyle lipgloss.Style
+	MutedStyle     lipgloss.Style
+}
+
+// SearchMatch is an option matching the query, with the matched rune positions
+type SearchMatch struct {
+	Option         SelectOption
+	Score          int
+	NamePositions  []int
+	ExtraPositions []int
+}
+
+func NewFormSearchSelect(label string, options []SelectOption) *FormSearchSelect {
+	s := &FormSearchSelect{
+		Label:          label,
+		Options:        options,
+		MaxVisible:     defaultSearchSelectVisible,
+		LabelStyle:     lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("241")),
+		ValueStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("255")),
+		FocusedStyle:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")),
+		HighlightStyle: lipgloss.NewStyle().Bold(true).Underline(true).Foreground(lipgloss.Color("214")),
+		MutedStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+	}
+	s.refreshMatches()
+	return s
+}
+
+func NewRequiredFormSearchSelect(label string, options []SelectOption) *FormSearchSelect {
+	s := NewFormSearchSelect(label, options)
+	s.Required = true
+	return s
+}
+
+// WithRecent ranks the given option IDs first, most recently used first, and selects the top one
+func (s *FormSearchSelect) WithRecent(ids []int) *FormSearchSelect {
+	s.RecentIDs = ids
+	s.refreshMatches()
+	s.Cursor = 0
+	s.Offset = 0
+	return s
+}
+
+func (s *FormSearchSelect) SetOptions(options []SelectOption) {
+	selectedID := s.GetSelectedID()
+	s.Options = options
+	s.refreshMatches()
+	if !s.SelectByID(selectedID) {
+		s.Cursor = 0
+		s.Offset = 0
+	}
+}
+
+// SelectByID clears the query and selects the option with the given ID
+func (s *FormSearchSelect) SelectByID(id int) bool {
+	if s.Query != "" {
+		s.Query = ""
+		s.refreshMatches()
+	}
+	for i, match := range s.Matches {
+		if match.Option.ID == id {
+			s.Cursor = i
+			s.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FormSearchSelect) Focus() tea.Cmd {
+	s.Focused = true
+	return nil
+}
+
+// Blur clears the query but keeps the current selection
+func (s *FormSearchSelect) Blur() {
+	s.Focused = false
+	if s.Query == "" {
+		return
+	}
+	if selected := s.GetSelectedOption(); selected != nil {
+		s.SelectByID(selected.ID)
+		return
+	}
+	s.Query = ""
+	s.refreshMatches()
+}
+
+func (s *FormSearchSelect) Update(msg tea.Msg) tea.Cmd {
+	if !s.Focused {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyUp, tea.KeyCtrlP:
+			s.move(-1)
+		case tea.KeyDown, tea.KeyCtrlN:
+			s.move(1)
+		case tea.KeyPgUp:
+			s.move(-s.visibleCount())
+		case tea.KeyPgDown:
+			s.move(s.visibleCount())
+		case tea.KeyRight:
+			return DispatchFocusNext()
+		case tea.KeyLeft:
+			return DispatchFocusPrev()
+		case tea.KeyBackspace:
+			if runes := []rune(s.Query); len(runes) > 0 {
+				s.setQuery(string(runes[:len(runes)-1]))
+			}
+		case tea.KeyCtrlU:
+			s.setQuery("")
+		case tea.KeySpace:
+			s.setQuery(s.Query + " ")
+		case tea.KeyRunes:
+			s.setQuery(s.Query + string(msg.Runes))
+		}
+	}
+
+	return nil
+}
+
+func (s *FormSearchSelect) GetSelectedID() int {
+	if selected := s.GetSelectedOption(); selected != nil {
+		return selected.ID
+	}
+	return -1
+}
+
+func (s *FormSearchSelect) GetSelectedOption() *SelectOption {
+	if s.Cursor < 0 || s.Cursor >= len(s.Matches) {
+		return nil
+	}
+	return &s.Matches[s.Cursor].Option
+}
+
+func (s *FormSearchSelect) Validate() error {
+	if s.Required && s.GetSelectedOption() == nil {
+		return &ValidationError{Field: s.Label, Message: s.Label + " is required"}
+	}
+	return nil
+}
+
+func (s *FormSearchSelect) View() string {
+	var output string
+
+	output += s.LabelStyle.Render(s.Label + ":")
+	output += "\n"
+
+	if len(s.Options) == 0 {
+		emptyStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			Italic(true)
+		output += "  " + emptyStyle.Render("(no options available)")
+		output += "\n"
+		return output
+	}
+
+	// Collapsed to the selected option while another field has focus
+	if !s.Focused {
+		if selected := s.GetSelectedOption(); selected != nil {
+			output += "• " + s.ValueStyle.Render(optionText(*selected))
+		} else {
+			output += "  " + s.MutedStyle.Render("(none)")
+		}
+		output += "\n"
+		return output
+	}
+
+	output += "  " + s.FocusedStyle.Render("/ ") + s.ValueStyle.Render(s.Query) + s.FocusedStyle.Render("▏")
+	output += s.MutedStyle.Render(fmt.Sprintf("  %d/%d", len(s.Matches), len(s.Options)))
+	output += "\n"
+
+	if len(s.Matches) == 0 {
+		output += "  " + s.MutedStyle.Render("(no matches)")
+		output += "\n"
+		return output
+	}
+
+	end := min(s.Offset+s.visibleCount(), len(s.Matches))
+	if s.Offset > 0 {
+		output += "  " + s.MutedStyle.Render(fmt.Sprintf("↑ %d more", s.Offset))
+		output += "\n"
+	}
+	for i := s.Offset; i < end; i++ {
+		style := s.ValueStyle
+		prefix := "  "
+		if i == s.Cursor {
+			style = s.FocusedStyle
+			prefix = "▶ "
+		}
+		output += prefix + s.renderMatch(s.Matches[i], style)
+		output += "\n"
+	}
+	if remaining := len(s.Matches) - end; remaining > 0 {
+		output += "  " + s.MutedStyle.Render(fmt.Sprintf("↓ %d more", remaining))
+		output += "\n"
+	}
+
+	return output
+}
+
+func (s *FormSearchSelect) renderMatch(match SearchMatch, style lipgloss.Style) string {
+	text := highlightRunes(match.Option.DisplayName, match.NamePositions, style, s.HighlightStyle)
+	if match.Option.ExtraInfo != "" {
+		text += style.Render(" (")
+		text += highlightRunes(match.Option.ExtraInfo, match.ExtraPositions, style, s.HighlightStyle)
+		text += style.Render(")")
+	}
+	return text
+}
+
+func (s *FormSearchSelect) setQuery(query string) {
+	s.Query = query
+	s.refreshMatches()
+	s.Cursor = 0
+	s.Offset = 0
+}
+
+func (s *FormSearchSelect) move(delta int) {
+	if len(s.Matches) == 0 {
+		return
+	}
+	s.Cursor = max(0, min(s.Cursor+delta, len(s.Matches)-1))
+	s.scrollToCursor()
+}
+
+func (s *FormSearchSelect) visibleCount() int {
+	if s.MaxVisible <= 0 {
+		return defaultSearchSelectVisible
+	}
+	return s.MaxVisible
+}
+
+// scrollToCursor moves the window so that the cursor is visible
+func (s *FormSearchSelect) scrollToCursor() {
+	visible := s.visibleCount()
+	if s.Cursor < s.Offset {
+		s.Offset = s.Cursor
+	}
+	if s.Cursor >= s.Offset+visible {
+		s.Offset = s.Cursor - visible + 1
+	}
+}
+
+// refreshMatches filters the options by the query and ranks them by score, then by recent use
+func (s *FormSearchSelect) refreshMatches() {
+	recentRank := make(map[int]int, len(s.RecentIDs))
+	for i, id := range s.RecentIDs {
+		if _, seen := recentRank[id]; !seen {
+			recentRank[id] = i
+		}
+	}
+	rank := func(id int) int {
+		if r, ok := recentRank[id]; ok {
+			return r
+		}
+		return len(s.RecentIDs)
+	}
+
+	query := strings.TrimSpace(s.Query)
+	matches := make([]SearchMatch, 0, len(s.Options))
+	for _, option := range s.Options {
+		if query == "" {
+			matches = append(matches, SearchMatch{Option: option})
+			continue
+		}
+		if score, positions, ok := FuzzyMatch(query, option.DisplayName); ok {
+			matches = append(matches, SearchMatch{Option: option, Score: score, NamePositions: positions})
+			continue
+		}
+		if score, positions, ok := FuzzyMatch(query, option.ExtraInfo); ok {
+			// Matches on the extra info rank below matches on the name
+			matches = append(matches, SearchMatch{Option: option, Score: score - len(option.DisplayName), ExtraPositions: positions})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return rank(matches[i].Option.ID) < rank(matches[j].Option.ID)
+	})
+
+	s.Matches = matches
+}
+
+func optionText(option SelectOption) string {
+	if option.ExtraInfo != "" {
+		return fmt.Sprintf("%s (%s)", option.DisplayName, option.ExtraInfo)
+	}
+	return option.DisplayName
+}
+
+func highlightRunes(text string, positions []int, style, highlight lipgloss.Style) string {
+	if len(positions) == 0 {
+		return style.Render(text)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var sb strings.Builder
+	var run []rune
+	runHighlighted := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runHighlighted {
+			sb.WriteString(highlight.Render(string(run)))
+		} else {
+			sb.WriteString(style.Render(string(run)))
+		}
+		run = run[:0]
+	}
+
+	for i, r := range []rune(text) {
+		if matched[i] != runHighlighted {
+			flush()
+			runHighlighted = matched[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+
+	return sb.String()
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		text          string
+		wantOK        bool
+		wantPositions []int
+	}{
+		{"empty pattern", "", "Arnia", true, nil},
+		{"prefix", "arn", "Arnia", true, []int{0, 1, 2}},
+		{"subsequence", "ais", "Arnia Internal Support", true, []int{0, 6, 15}},
+		{"case insensitive", "API", "api gateway", true, []int{0, 1, 2}},
+		{"out of order", "ina", "Arnia", false, nil},
+		{"prefers word starts", "is", "Arnia Internal Support", true, []int{6, 15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, tt.wantOK)
+			}
+			if len(positions) != len(tt.wantPositions) {
+				t.Fatalf("got positions %v, want %v", positions, tt.wantPositions)
+			}
+			for i := range positions {
+				if positions[i] != tt.wantPositions[i] {
+					t.Errorf("got positions %v, want %v", positions, tt.wantPositions)
+					break
+				}
+			}
+		})
+	}
+
+	consecutive, _, _ := FuzzyMatch("sup", "Support")
+	scattered, _, _ := FuzzyMatch("sup", "Sales Upgrade Plan")
+	if consecutive <= scattered {
+		t.Errorf("expected consecutive match to score higher, got %d <= %d", consecutive, scattered)
+	}
+}
+
+func TestFormSearchSelect_FilterAndRecent(t *testing.T) {
+	options := []SelectOption{
+		{ID: 1, DisplayName: "Arnia"},
+		{ID: 2, DisplayName: "Internal"},
+		{ID: 3, DisplayName: "Support", ExtraInfo: "Odoo: 42"},
+	}
+
+	s := NewRequiredFormSearchSelect("Project", options).WithRecent([]int{3, 2})
+	s.Focus()
+
+	if got := s.GetSelectedID(); got != 3 {
+		t.Errorf("expected most recently used option selected, got %d", got)
+	}
+	if s.Matches[2].Option.ID != 1 {
+		t.Errorf("expected unused option last, got %+v", s.Matches)
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ar")})
+	if len(s.Matches) != 1 || s.GetSelectedID() != 1 {
+		t.Fatalf("expected only Arnia to match, got %+v", s.Matches)
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("42")})
+	if len(s.Matches) != 1 || s.GetSelectedID() != 3 {
+		t.Fatalf("expected extra info to match Support, got %+v", s.Matches)
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if err := s.Validate(); err == nil {
+		t.Error("expected validation error without a match")
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	s.Blur()
+	if s.Query != "" || s.GetSelectedID() != 3 {
+		t.Errorf("expected blur to clear the query and keep Support, got %q / %d", s.Query, s.GetSelectedID())
+	}
+}
+
+func TestFormSearchSelect_WindowFollowsCursor(t *testing.T) {
+	var options []SelectOption
+	for i := 1; i <= 10; i++ {
+		options = append(options, SelectOption{ID: i, DisplayName: "Project"})
+	}
+
+	s := NewFormSearchSelect("Project", options)
+	s.MaxVisible = 3
+	s.Focus()
+
+	for range 4 {
+		s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if s.Cursor != 4 || s.Offset != 2 {
+		t.Errorf("got cursor %d offset %d, want 4 and 2", s.Cursor, s.Offset)
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	s.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if s.Cursor != 9 || s.Offset != 7 {
+		t.Errorf("got cursor %d offset %d, want 9 and 7", s.Cursor, s.Offset)
+	}
+
+	s.SelectByID(1)
+	if s.Cursor != 0 || s.Offset != 0 {
+		t.Errorf("got cursor %d offset %d, want 0 and 0", s.Cursor, s.Offset)
+	}
+}
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"unicode"
+)
+
+const (
+	fuzzyMatchScore       = 1
+	fuzzyConsecutiveBonus = 5
+	fuzzyWordStartBonus   = 8
+	fuzzyLeadingBonus     = 3
+	fuzzyGapPenalty       = 1
+)
+
+// FuzzyMatch reports whether every rune of pattern appears in text in order, ignoring case.
+// Higher scores mean better matches: consecutive runes and runes at the start of a word
+// score more, gaps score less. Positions are the rune indexes of the matched runes in text.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	patternRunes := []rune(pattern)
+	if len(patternRunes) == 0 {
+		return 0, nil, true
+	}
+
+	textRunes := []rune(text)
+	n, m := len(textRunes), len(patternRunes)
+	if m > n {
+		return 0, nil, false
+	}
+
+	// best[p][i] is the best score matching pattern[:p+1] with pattern[p] at text[i],
+	// prev[p][i] the text index chosen for pattern[p-1] on that path
+	best := make([][]int, m)
+	prev := make([][]int, m)
+	matched := make([][]bool, m)
+	for p := range m {
+		best[p] = make([]int, n)
+		prev[p] = make([]int, n)
+		matched[p] = make([]bool, n)
+
+		want := unicode.ToLower(patternRunes[p])
+		for i := p; i < n; i++ {
+			if unicode.ToLower(textRunes[i]) != want {
+				continue
+			}
+
+			charScore := fuzzyMatchScore
+			if isWordStart(textRunes, i) {
+				charScore += fuzzyWordStartBonus
+			}
+
+			if p == 0 {
+				best[p][i] = charScore
+				if i == 0 {
+					best[p][i] += fuzzyLeadingBonus
+				}
+				matched[p][i] = true
+				continue
+			}
+
+			for j := p - 1; j < i; j++ {
+				if !matched[p-1][j] {
+					continue
+				}
+				score := best[p-1][j] + charScore
+				if i == j+1 {
+					score += fuzzyConsecutiveBonus
+				} else {
+					score -= (i - j - 1) * fuzzyGapPenalty
+				}
+				if !matched[p][i] || score > best[p][i] {
+					best[p][i] = score
+					prev[p][i] = j
+					matched[p][i] = true
+				}
+			}
+		}
+	}
+
+	end := -1
+	for i := range n {
+		if matched[m-1][i] && (end < 0 || best[m-1][i] > best[m-1][end]) {
+			end = i
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, m)
+	for p, i := m-1, end; p >= 0; p-- {
+		positions[p] = i
+		i = prev[p][i]
+	}
+
+	return best[m-1][end], positions, true
+}
+
+func isWordStart(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
@@ -2,9 +2,12 @@ package common
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"tltui/src/domain"
 )
 
 // PositiveIntValidator validates that the value is a positive integer
@@ -166,3 +169,255 @@ func OptionalValidator(validator func(string) error) func(string) error {
 		return validator(value)
 	}
 }
+
+// NonNegativeFloatValidator validates that the value is a float greater than or equal to zero
+func NonNegativeFloatValidator(fieldName string) func(string) error {
+	return func(value string) error {
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			return &ValidationError{Field: fieldName, Message: fieldName + " is required"}
+		}
+
+		num, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return &ValidationError{Field: fieldName, Message: fieldName + " must be a number"}
+		}
+
+		if num < 0 {
+			return &ValidationError{Field: fieldName, Message: fieldName + " must not be negative"}
+		}
+
+		return nil
+	}
+}
+
+// DateValidator validates that the value is a date in YYYY-MM-DD format
+func DateValidator(fieldName string) func(string) error {
+	return func(value string) error {
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(value)); err != nil {
+			return &ValidationError{Field: fieldName, Message: fieldName + " must be a date (YYYY-MM-DD)"}
+		}
+		return nil
+	}
+}
+
+// YearValidator validates that the value is a plausible four-digit year
+func YearValidator(fieldName string) func(string) error {
+	return func(value string) error {
+		year, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || year < 1900 || year > 9999 {
+			return &ValidationError{Field: fieldName, Message: fieldName + " must be a valid year"}
+		}
+		return nil
+	}
+}
+
+// ParseDuration converts a duration input to decimal hours, rounded to the nearest
+// multiple of roundTo (no rounding when roundTo <= 0). It accepts decimal hours
+// ("7.5" or "7,5"), clock durations ("1:30"), units ("1h30m", "90m", "1.5h") and
+// time ranges with an optional break ("9-17:30", "9:00-12:15 -30m"). Time ranges
+// are exact clock times and are never rounded.
+func ParseDuration(value string, roundTo float64) (float64, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, fmt.Errorf("duration is empty")
+	}
+
+	span, isRange, err := ParseTimeSpan(trimmed)
+	if err != nil {
+		return 0, err
+	}
+	if isRange {
+		return RoundHours(span.Hours(), 0), nil
+	}
+
+	hours, err := parseDurationAmount(trimmed)
+	if err != nil {
+		return 0, err
+	}
+
+	return RoundHours(hours, roundTo), nil
+}
+
+// TimeSpan is a time range entered as a duration, in minutes after midnight
+type TimeSpan struct {
+	StartMinutes int
+	EndMinutes   int
+	BreakMinutes int
+}
+
+// Hours returns the length of the span minus its break
+func (s TimeSpan) Hours() float64 {
+	return float64(s.EndMinutes-s.StartMinutes-s.BreakMinutes) / 60
+}
+
+// String formats the span as "09:00-12:15" with the break, if any, appended
+func (s TimeSpan) String() string {
+	text := domain.ClockTime(s.StartMinutes).String() + "-" + domain.ClockTime(s.EndMinutes).String()
+	if s.BreakMinutes > 0 {
+		text += fmt.Sprintf(" -%s", FormatClockDuration(float64(s.BreakMinutes)/60))
+	}
+	return text
+}
+
+// ParseTimeSpan parses a time range such as "9-17:30" or "9:00-12:15 -30m". The
+// boolean reports whether the value is a time range at all; plain durations
+// return false without an error.
+func ParseTimeSpan(value string) (TimeSpan, bool, error) {
+	start, end, breakPart, ok := splitTimeRange(strings.TrimSpace(value))
+	if !ok {
+		return TimeSpan{}, false, nil
+	}
+	span, err := parseTimeRange(start, end, breakPart)
+	if err != nil {
+		return TimeSpan{}, true, err
+	}
+	return span, true, nil
+}
+
+// RoundHours rounds hours to the nearest multiple of step; steps <= 0 only trim float noise
+func RoundHours(hours, step float64) float64 {
+	if step > 0 {
+		hours = math.Round(hours/step) * step
+	}
+	return math.Round(hours*10000) / 10000
+}
+
+// DurationValidator validates that the value is a positive duration of at most a day
+func DurationValidator(fieldName string, roundTo float64) func(string) error {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return &ValidationError{Field: fieldName, Message: fieldName + " is required"}
+		}
+
+		hours, err := ParseDuration(value, roundTo)
+		if err != nil {
+			return &ValidationError{Field: fieldName, Message: fieldName + ": " + err.Error()}
+		}
+		if hours <= 0 {
+			return &ValidationError{Field: fieldName, Message: fieldName + " must be a positive duration"}
+		}
+		if hours > 24 {
+			return &ValidationError{Field: fieldName, Message: fieldName + " must not exceed 24 hours"}
+		}
+
+		return nil
+	}
+}
+
+// DurationPreview describes how a duration input is interpreted, for display under the field
+func DurationPreview(roundTo float64) func(string) string {
+	return func(value string) string {
+		if strings.TrimSpace(value) == "" {
+			return ""
+		}
+
+		hours, err := ParseDuration(value, roundTo)
+		if err != nil {
+			return "? " + err.Error()
+		}
+
+		preview := fmt.Sprintf("= %gh (%s)", hours, FormatClockDuration(hours))
+		if span, isRange, _ := ParseTimeSpan(value); isRange {
+			return preview + ", " + span.String()
+		}
+		if raw, _ := ParseDuration(value, 0); raw != hours {
+			preview += fmt.Sprintf(", rounded from %gh", math.Round(raw*100)/100)
+		}
+		return preview
+	}
+}
+
+// FormatClockDuration formats decimal hours as "1h30m"
+func FormatClockDuration(hours float64) string {
+	totalMinutes := int(math.Round(hours * 60))
+	h, m := totalMinutes/60, totalMinutes%60
+	switch {
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	default:
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+}
+
+var (
+	timeRangePattern  = regexp.MustCompile(`^(\d{1,2}(?::\d{2})?)\s*[-–]\s*(\d{1,2}(?::\d{2})?)(?:\s+(?:-|–|break)\s*(.+))?$`)
+	durationUnitRegex = regexp.MustCompile(`^(?:(\d+(?:[.,]\d+)?)h)?\s*(?:(\d+(?:[.,]\d+)?)m)?$`)
+)
+
+func splitTimeRange(value string) (string, string, string, bool) {
+	match := timeRangePattern.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return "", "", "", false
+	}
+	return match[1], match[2], match[3], true
+}
+
+func parseTimeRange(start, end, breakPart string) (TimeSpan, error) {
+	startTime, err := domain.ParseClockTime(start)
+	if err != nil {
+		return TimeSpan{}, err
+	}
+	endTime, err := domain.ParseClockTime(end)
+	if err != nil {
+		return TimeSpan{}, err
+	}
+	startMinutes, endMinutes := int(startTime), int(endTime)
+	if endMinutes <= startMinutes {
+		return TimeSpan{}, fmt.Errorf("end time must be after start time")
+	}
+
+	span := TimeSpan{StartMinutes: startMinutes, EndMinutes: endMinutes}
+	if breakPart != "" {
+		breakHours, err := parseDurationAmount(breakPart)
+		if err != nil {
+			return TimeSpan{}, fmt.Errorf("invalid break: %w", err)
+		}
+		breakMinutes := int(math.Round(breakHours * 60))
+		if breakMinutes < 0 || breakMinutes >= endMinutes-startMinutes {
+			return TimeSpan{}, fmt.Errorf("break is longer than the time range")
+		}
+		span.BreakMinutes = breakMinutes
+	}
+
+	return span, nil
+}
+
+// parseDurationAmount parses a single duration: "7.5", "7,5", "1:30", "1h30m", "90m" or "1.5h"
+func parseDurationAmount(value string) (float64, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+
+	if hours, err := strconv.ParseFloat(strings.Replace(trimmed, ",", ".", 1), 64); err == nil {
+		if math.IsNaN(hours) || math.IsInf(hours, 0) {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return hours, nil
+	}
+
+	if hourStr, minuteStr, ok := strings.Cut(trimmed, ":"); ok {
+		hour, err1 := strconv.Atoi(hourStr)
+		minute, err2 := strconv.Atoi(minuteStr)
+		if err1 != nil || err2 != nil || hour < 0 || minute < 0 || minute > 59 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return float64(hour) + float64(minute)/60, nil
+	}
+
+	match := durationUnitRegex.FindStringSubmatch(trimmed)
+	if match == nil || (match[1] == "" && match[2] == "") {
+		return 0, fmt.Errorf("invalid duration %q (try 7.5, 1:30, 1h30m or 9-17:30)", value)
+	}
+
+	var hours float64
+	if match[1] != "" {
+		h, _ := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		hours += h
+	}
+	if match[2] != "" {
+		m, _ := strconv.ParseFloat(strings.Replace(match[2], ",", ".", 1), 64)
+		hours += m / 60
+	}
+	return hours, nil
+}
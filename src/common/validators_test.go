@@ -276,3 +276,169 @@ func TestRequiredStringValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestNonNegativeFloatValidator(t *testing.T) {
+	validator := NonNegativeFloatValidator("Field")
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"0", false},
+		{"2.5", false},
+		{"21", false},
+		{"-0.5", true},
+		{"abc", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := validator(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NonNegativeFloatValidator(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDateValidator(t *testing.T) {
+	validator := DateValidator("Date")
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"2025-03-31", false},
+		{" 2024-02-29 ", false},
+		{"2025-02-30", true},
+		{"31.03.2025", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := validator(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DateValidator(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestYearValidator(t *testing.T) {
+	validator := YearValidator("Year")
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"2025", false},
+		{"1899", true},
+		{"25", true},
+		{"abcd", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := validator(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("YearValidator(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		roundTo float64
+		want    float64
+		wantErr bool
+	}{
+		{"decimal", "7.5", 0, 7.5, false},
+		{"decimal comma", "7,5", 0, 7.5, false},
+		{"clock", "1:30", 0, 1.5, false},
+		{"hours and minutes", "1h30m", 0, 1.5, false},
+		{"minutes", "90m", 0, 1.5, false},
+		{"decimal hours unit", "1.5h", 0, 1.5, false},
+		{"range", "9-17:30", 0, 8.5, false},
+		{"range with break", "9:00-12:15 -30m", 0, 2.75, false},
+		{"range with clock break", "9:00-17:30 - 0:30", 0, 8, false},
+		{"range with named break", "8-16 break 1h", 0, 7, false},
+		{"rounded to quarter", "1h20m", 0.25, 1.25, false},
+		{"rounded up", "1h55m", 0.25, 2, false},
+		{"range not rounded", "9:00-10:10", 0.25, 1.1667, false},
+		{"end before start", "17-9", 0, 0, true},
+		{"break too long", "9-10 -2h", 0, 0, true},
+		{"invalid minutes", "1:75", 0, 0, true},
+		{"garbage", "abc", 0, 0, true},
+		{"empty", "", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.input, tt.roundTo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	validator := DurationValidator("Hours", 0)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", "1h30m", false},
+		{"zero", "0", true},
+		{"negative", "-2", true},
+		{"over a day", "25", true},
+		{"not a number", "NaN", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DurationValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := DurationPreview(0.25)("1h20m"); got != "= 1.25h (1h15m), rounded from 1.33h" {
+		t.Errorf("got preview %q", got)
+	}
+	if got := DurationPreview(0.25)("9-12:30 -30m"); got != "= 3h (3h), 09:00-12:30 -30m" {
+		t.Errorf("got range preview %q", got)
+	}
+}
+
+func TestParseTimeSpan(t *testing.T) {
+	span, isRange, err := ParseTimeSpan("8:30-17 break 45m")
+	if err != nil || !isRange {
+		t.Fatalf("ParseTimeSpan() = %v, %v, %v", span, isRange, err)
+	}
+	if span != (TimeSpan{StartMinutes: 510, EndMinutes: 1020, BreakMinutes: 45}) {
+		t.Errorf("unexpected span %+v", span)
+	}
+	if span.Hours() != 7.75 {
+		t.Errorf("expected 7.75 hours, got %v", span.Hours())
+	}
+
+	if _, isRange, err := ParseTimeSpan("1h30m"); isRange || err != nil {
+		t.Errorf("plain duration should not be a range, got %v, %v", isRange, err)
+	}
+	if _, isRange, err := ParseTimeSpan("17-9"); !isRange || err == nil {
+		t.Errorf("expected an invalid range error, got %v, %v", isRange, err)
+	}
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BudgetPeriod is the span an hour budget applies to
+type BudgetPeriod int
+
+const (
+	BudgetPeriodTotal     BudgetPeriod = iota // The budget covers the whole project
+	BudgetPeriodMonthly                       // The budget renews every calendar month
+	BudgetPeriodQuarterly                     // The budget renews every calendar quarter
+)
+
+func (p BudgetPeriod) String() string {
+	switch p {
+	case BudgetPeriodMonthly:
+		return "Monthly"
+	case BudgetPeriodQuarterly:
+		return "Quarterly"
+	default:
+		return "Total"
+	}
+}
+
+// BudgetThresholds are the consumption ratios that trigger a warning when crossed
+var BudgetThresholds = []float64{0.8, 1.0}
+
+// BudgetStatus is the consumption of a project budget over one period
+type BudgetStatus struct {
+	Project       Project
+	PeriodStart   time.Time // Zero for total budgets
+	PeriodEnd     time.Time // Zero for total budgets
+	ConsumedHours float64
+}
+
+// RemainingHours returns the hours left, negative when the budget is overrun
+func (s BudgetStatus) RemainingHours() float64 {
+	return s.Project.BudgetHours - s.ConsumedHours
+}
+
+// Ratio returns the consumed share of the budget
+func (s BudgetStatus) Ratio() float64 {
+	if s.Project.BudgetHours <= 0 {
+		return 0
+	}
+	return s.ConsumedHours / s.Project.BudgetHours
+}
+
+// PeriodLabel describes the budget period, e.g. "March 2025" or "Q1 2025"
+func (s BudgetStatus) PeriodLabel() string {
+	switch s.Project.BudgetPeriod {
+	case BudgetPeriodMonthly:
+		return s.PeriodStart.Format("January 2006")
+	case BudgetPeriodQuarterly:
+		return fmt.Sprintf("Q%d %d", (int(s.PeriodStart.Month())-1)/3+1, s.PeriodStart.Year())
+	default:
+		return "total"
+	}
+}
+
+// BudgetWarning is raised when an entry makes a budget cross a threshold
+type BudgetWarning struct {
+	Status    BudgetStatus
+	Threshold float64
+}
+
+func (w BudgetWarning) Message() string {
+	if w.Threshold >= 1 {
+		return fmt.Sprintf("%s budget exceeded (%s): %gh of %gh",
+			w.Status.Project.Name, w.Status.PeriodLabel(), roundHours(w.Status.ConsumedHours), w.Status.Project.BudgetHours)
+	}
+	return fmt.Sprintf("%s reached %d%% of its budget (%s): %gh of %gh",
+		w.Status.Project.Name, int(w.Threshold*100), w.Status.PeriodLabel(), roundHours(w.Status.ConsumedHours), w.Status.Project.BudgetHours)
+}
+
+// FormatBudgetWarnings joins the messages of budget warnings into one line
+func FormatBudgetWarnings(warnings []BudgetWarning) string {
+	messages := make([]string, len(warnings))
+	for i, w := range warnings {
+		messages[i] = w.Message()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// BudgetPeriodRange returns the first and last day of the budget period containing date.
+// Total budgets have no range and return zero times.
+func BudgetPeriodRange(period BudgetPeriod, date time.Time) (time.Time, time.Time) {
+	switch period {
+	case BudgetPeriodMonthly:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		return start, start.AddDate(0, 1, -1)
+	case BudgetPeriodQuarterly:
+		firstMonth := time.Month((int(date.Month())-1)/3*3 + 1)
+		start := time.Date(date.Year(), firstMonth, 1, 0, 0, 0, 0, date.Location())
+		return start, start.AddDate(0, 3, -1)
+	default:
+		return time.Time{}, time.Time{}
+	}
+}
+
+// CalculateBudgetStatus sums the project's hours in the budget period containing date
+func CalculateBudgetStatus(project Project, workhours []Workhour, date time.Time) BudgetStatus {
+	start, end := BudgetPeriodRange(project.BudgetPeriod, date)
+	status := BudgetStatus{Project: project, PeriodStart: start, PeriodEnd: end}
+
+	for _, wh := range workhours {
+		if wh.ProjectID != project.ID || !inPeriod(wh.Date, start, end) {
+			continue
+		}
+		status.ConsumedHours += wh.Hours
+	}
+
+	return status
+}
+
+// CrossedBudgetThreshold returns the highest threshold passed when consumption
+// goes from before to after hours, or 0 when none was crossed
+func CrossedBudgetThreshold(budgetHours, before, after float64) float64 {
+	if budgetHours <= 0 || after <= before {
+		return 0
+	}
+
+	crossed := 0.0
+	for _, threshold := range BudgetThresholds {
+		limit := budgetHours * threshold
+		if before < limit && after >= limit {
+			crossed = threshold
+		}
+	}
+	return crossed
+}
+
+// BurnDownPoint is the state of a budget at the end of one day
+type BurnDownPoint struct {
+	Date           time.Time
+	ConsumedHours  float64
+	RemainingHours float64
+	IdealRemaining float64 // Remaining hours of an even burn across the period
+}
+
+// CalculateBurnDown returns one point per day between start and end, inclusive
+func CalculateBurnDown(project Project, workhours []Workhour, start, end time.Time) []BurnDownPoint {
+	hoursByDate := make(map[string]float64)
+	for _, wh := range workhours {
+		if wh.ProjectID == project.ID {
+			hoursByDate[wh.Date.Format("2006-01-02")] += wh.Hours
+		}
+	}
+
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	totalDays := int(endDay.Sub(startDay).Hours()/24) + 1
+	if totalDays <= 0 {
+		return nil
+	}
+
+	// Hours logged before the range still count against total budgets
+	consumed := 0.0
+	if project.BudgetPeriod == BudgetPeriodTotal {
+		for dateStr, hours := range hoursByDate {
+			if dateStr < startDay.Format("2006-01-02") {
+				consumed += hours
+			}
+		}
+	}
+	initialRemaining := project.BudgetHours - consumed
+
+	points := make([]BurnDownPoint, 0, totalDays)
+	for i := range totalDays {
+		day := startDay.AddDate(0, 0, i)
+		consumed += hoursByDate[day.Format("2006-01-02")]
+		points = append(points, BurnDownPoint{
+			Date:           day,
+			ConsumedHours:  consumed,
+			RemainingHours: project.BudgetHours - consumed,
+			IdealRemaining: initialRemaining * float64(totalDays-i-1) / float64(totalDays),
+		})
+	}
+
+	return points
+}
+
+func inPeriod(date, start, end time.Time) bool {
+	if start.IsZero() && end.IsZero() {
+		return true
+	}
+	day := date.Format("2006-01-02")
+	return day >= start.Format("2006-01-02") && day <= end.Format("2006-01-02")
+}
+
+func roundHours(hours float64) float64 {
+	return float64(int(hours*100+0.5)) / 100
+}
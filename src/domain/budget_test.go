@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetPeriodRange(t *testing.T) {
+	date := time.Date(2025, time.May, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		period    BudgetPeriod
+		wantStart string
+		wantEnd   string
+	}{
+		{name: "monthly", period: BudgetPeriodMonthly, wantStart: "2025-05-01", wantEnd: "2025-05-31"},
+		{name: "quarterly", period: BudgetPeriodQuarterly, wantStart: "2025-04-01", wantEnd: "2025-06-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := BudgetPeriodRange(tt.period, date)
+			if got := start.Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("got start %s, want %s", got, tt.wantStart)
+			}
+			if got := end.Format("2006-01-02"); got != tt.wantEnd {
+				t.Errorf("got end %s, want %s", got, tt.wantEnd)
+			}
+		})
+	}
+
+	start, end := BudgetPeriodRange(BudgetPeriodTotal, date)
+	if !start.IsZero() || !end.IsZero() {
+		t.Errorf("expected no range for total budgets, got %v - %v", start, end)
+	}
+}
+
+func TestCalculateBudgetStatus(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	workhours := []Workhour{
+		{Date: day(time.March, 3), ProjectID: 1, Hours: 8},
+		{Date: day(time.April, 1), ProjectID: 1, Hours: 6},
+		{Date: day(time.April, 2), ProjectID: 1, Hours: 4},
+		{Date: day(time.April, 2), ProjectID: 2, Hours: 4},
+	}
+
+	monthly := CalculateBudgetStatus(Project{ID: 1, BudgetHours: 20, BudgetPeriod: BudgetPeriodMonthly}, workhours, day(time.April, 15))
+	if monthly.ConsumedHours != 10 {
+		t.Errorf("got monthly consumed %v, want 10", monthly.ConsumedHours)
+	}
+	if monthly.RemainingHours() != 10 {
+		t.Errorf("got monthly remaining %v, want 10", monthly.RemainingHours())
+	}
+	if monthly.PeriodLabel() != "April 2025" {
+		t.Errorf("got label %q, want April 2025", monthly.PeriodLabel())
+	}
+
+	total := CalculateBudgetStatus(Project{ID: 1, BudgetHours: 16}, workhours, day(time.April, 15))
+	if total.ConsumedHours != 18 {
+		t.Errorf("got total consumed %v, want 18", total.ConsumedHours)
+	}
+	if total.RemainingHours() != -2 {
+		t.Errorf("got total remaining %v, want -2", total.RemainingHours())
+	}
+}
+
+func TestCrossedBudgetThreshold(t *testing.T) {
+	tests := []struct {
+		name   string
+		before float64
+		after  float64
+		want   float64
+	}{
+		{name: "below warning", before: 0, after: 70, want: 0},
+		{name: "crosses 80%", before: 70, after: 85, want: 0.8},
+		{name: "crosses 100%", before: 85, after: 100, want: 1.0},
+		{name: "crosses both", before: 50, after: 120, want: 1.0},
+		{name: "already over", before: 110, after: 120, want: 0},
+		{name: "hours removed", before: 100, after: 70, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CrossedBudgetThreshold(100, tt.before, tt.after); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateBurnDown(t *testing.T) {
+	project := Project{ID: 1, BudgetHours: 10, BudgetPeriod: BudgetPeriodMonthly}
+	start := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.June, 5, 0, 0, 0, 0, time.UTC)
+	workhours := []Workhour{
+		{Date: time.Date(2025, time.May, 30, 0, 0, 0, 0, time.UTC), ProjectID: 1, Hours: 8},
+		{Date: time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC), ProjectID: 1, Hours: 4},
+		{Date: time.Date(2025, time.June, 4, 0, 0, 0, 0, time.UTC), ProjectID: 1, Hours: 3},
+	}
+
+	points := CalculateBurnDown(project, workhours, start, end)
+	if len(points) != 5 {
+		t.Fatalf("got %d points, want 5", len(points))
+	}
+
+	wantRemaining := []float64{10, 6, 6, 3, 3}
+	for i, want := range wantRemaining {
+		if points[i].RemainingHours != want {
+			t.Errorf("day %d: got remaining %v, want %v", i+1, points[i].RemainingHours, want)
+		}
+	}
+	if points[4].IdealRemaining != 0 {
+		t.Errorf("got ideal remaining %v on the last day, want 0", points[4].IdealRemaining)
+	}
+}
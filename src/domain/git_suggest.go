@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GitRepo is a local git repository whose commits suggest workhours for a project
+type GitRepo struct {
+	ID        int
+	Path      string
+	ProjectID int
+	DetailsID int // Workhour type of the suggestions, 0 to choose it when accepting
+}
+
+// GitCommit is a commit of a GitRepo, at the time it was authored
+type GitCommit struct {
+	Hash    string
+	Time    time.Time
+	Subject string
+}
+
+// WorkhourSuggestion is a workhour proposed from the commits made in a repository on a day.
+// It is only saved once the user accepted or edited it.
+type WorkhourSuggestion struct {
+	Repo        GitRepo
+	Workhour    Workhour
+	Description string // Subjects of the commits, oldest first
+	Commits     []GitCommit
+	Logged      bool // The day already has an entry for the project
+}
+
+const (
+	// GitSessionGap separates work sessions: commits further apart start a new session
+	GitSessionGap = 2 * time.Hour
+	// GitSessionLead is the time counted before the first commit of a session
+	GitSessionLead = 30 * time.Minute
+	// gitSuggestionStep rounds session starts down and ends up, in minutes
+	gitSuggestionStep = 15
+)
+
+// SuggestWorkhour estimates the time spent in a repository on date from its commits.
+// Commits closer than GitSessionGap form a session lasting from GitSessionLead before
+// its first commit to its last one; the time between sessions becomes the break of
+// the suggested entry. It returns nil when no commit was made on date.
+func SuggestWorkhour(repo GitRepo, date time.Time, commits []GitCommit) *WorkhourSuggestion {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+
+	var dayCommits []GitCommit
+	for _, c := range commits {
+		local := c.Time.In(time.Local)
+		if local.Year() == day.Year() && local.Month() == day.Month() && local.Day() == day.Day() {
+			dayCommits = append(dayCommits, c)
+		}
+	}
+	if len(dayCommits) == 0 {
+		return nil
+	}
+	sort.SliceStable(dayCommits, func(i, j int) bool { return dayCommits[i].Time.Before(dayCommits[j].Time) })
+
+	type session struct{ start, end int } // Minutes of the day
+	minuteOf := func(t time.Time) int { return int(t.In(time.Local).Sub(day).Minutes()) }
+
+	var sessions []session
+	for i, c := range dayCommits {
+		minute := minuteOf(c.Time)
+		if i > 0 && c.Time.Sub(dayCommits[i-1].Time) <= GitSessionGap {
+			sessions[len(sessions)-1].end = minute
+			continue
+		}
+		sessions = append(sessions, session{start: max(minute-int(GitSessionLead.Minutes()), 0), end: minute})
+	}
+
+	// Rounding can make sessions touch, those are merged
+	var merged []session
+	for _, s := range sessions {
+		s.start -= s.start % gitSuggestionStep
+		if s.end%gitSuggestionStep != 0 || s.end == s.start {
+			s.end += gitSuggestionStep - s.end%gitSuggestionStep
+		}
+		s.end = min(s.end, 24*60)
+		if n := len(merged); n > 0 && s.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, s.end)
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	workhour := Workhour{
+		Date:      day,
+		ProjectID: repo.ProjectID,
+		DetailsID: repo.DetailsID,
+		Start:     ClockTime(merged[0].start),
+		End:       ClockTime(merged[len(merged)-1].end),
+	}
+	for i := 1; i < len(merged); i++ {
+		workhour.BreakMinutes += merged[i].start - merged[i-1].end
+	}
+	workhour.Hours = workhour.TimedHours()
+
+	var subjects []string
+	for _, c := range dayCommits {
+		if subject := strings.TrimSpace(c.Subject); subject != "" && !slices.Contains(subjects, subject) {
+			subjects = append(subjects, subject)
+		}
+	}
+
+	return &WorkhourSuggestion{
+		Repo:        repo,
+		Workhour:    workhour,
+		Description: strings.Join(subjects, "; "),
+		Commits:     dayCommits,
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSuggestWorkhour(t *testing.T) {
+	repo := GitRepo{ID: 1, Path: "/src/api", ProjectID: 3, DetailsID: 2}
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time { return day.Add(time.Duration(hour*60+minute) * time.Minute) }
+
+	tests := []struct {
+		name      string
+		commits   []GitCommit
+		wantRange string
+		wantBreak int
+		wantHours float64
+		wantDesc  string
+	}{
+		{
+			name:      "single commit counts the lead time",
+			commits:   []GitCommit{{Hash: "a", Time: at(10, 20), Subject: "Fix login"}},
+			wantRange: "09:45-10:30",
+			wantHours: 0.75,
+			wantDesc:  "Fix login",
+		},
+		{
+			name: "close commits form one session",
+			commits: []GitCommit{
+				{Hash: "b", Time: at(11, 20), Subject: "Add tests"},
+				{Hash: "a", Time: at(9, 30), Subject: "Start API"},
+				{Hash: "c", Time: at(12, 55), Subject: "Add tests"},
+			},
+			wantRange: "09:00-13:00",
+			wantHours: 4,
+			wantDesc:  "Start API; Add tests",
+		},
+		{
+			name: "a long gap becomes the break",
+			commits: []GitCommit{
+				{Hash: "a", Time: at(9, 30), Subject: "Morning"},
+				{Hash: "b", Time: at(10, 0), Subject: "More"},
+				{Hash: "c", Time: at(14, 10), Subject: "Afternoon"},
+				{Hash: "d", Time: at(15, 50), Subject: "Done"},
+			},
+			wantRange: "09:00-16:00",
+			wantBreak: 210, // 10:00 to 13:30
+			wantHours: 3.5,
+			wantDesc:  "Morning; More; Afternoon; Done",
+		},
+		{
+			name: "commits of other days are ignored",
+			commits: []GitCommit{
+				{Hash: "a", Time: at(-1, 0), Subject: "Yesterday"},
+				{Hash: "b", Time: at(0, 10), Subject: "Just after midnight"},
+				{Hash: "c", Time: at(24, 30), Subject: "Tomorrow"},
+			},
+			wantRange: "00:00-00:15",
+			wantHours: 0.25,
+			wantDesc:  "Just after midnight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestWorkhour(repo, day, tt.commits)
+			if got == nil {
+				t.Fatal("expected a suggestion")
+			}
+			wh := got.Workhour
+			if wh.TimeRange() != tt.wantRange || wh.BreakMinutes != tt.wantBreak || wh.Hours != tt.wantHours {
+				t.Errorf("got %s -%dm %gh, want %s -%dm %gh", wh.TimeRange(), wh.BreakMinutes, wh.Hours, tt.wantRange, tt.wantBreak, tt.wantHours)
+			}
+			if err := wh.ValidateTimes(); err != nil {
+				t.Errorf("suggested times are invalid: %v", err)
+			}
+			if wh.ProjectID != 3 || wh.DetailsID != 2 || !wh.Date.Equal(day) {
+				t.Errorf("expected the repo's project and type on the day, got %+v", wh)
+			}
+			if got.Description != tt.wantDesc {
+				t.Errorf("Description = %q, want %q", got.Description, tt.wantDesc)
+			}
+		})
+	}
+
+	if got := SuggestWorkhour(repo, day, []GitCommit{{Time: at(30, 0)}}); got != nil {
+		t.Errorf("expected no suggestion without commits on the day, got %+v", got)
+	}
+}
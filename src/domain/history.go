@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// ChangeSource tells where a change to the data came from
+type ChangeSource string
+
+const (
+	ChangeSourceTUI    ChangeSource = "tui"
+	ChangeSourceCLI    ChangeSource = "cli"
+	ChangeSourceImport ChangeSource = "import"
+	ChangeSourceAPI    ChangeSource = "api"
+	ChangeSourceSync   ChangeSource = "sync" // Replayed from another replica's change log
+)
+
+// ChangeAction is the kind of change recorded in the history
+type ChangeAction string
+
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+)
+
+// WorkhourChange is an entry of the append-only workhour history. Old is nil for
+// creations and New is nil for deletions.
+type WorkhourChange struct {
+	ID         int
+	WorkhourID int
+	Action     ChangeAction
+	Old        *Workhour
+	New        *Workhour
+	ChangedAt  time.Time
+	Source     ChangeSource
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarEvent is a workhour as an iCalendar VEVENT
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time // All-day events end on the day after their last day
+	AllDay      bool
+	OutOfOffice bool // Leave, shown as busy and out of office
+	Busy        bool // Timed work blocks the calendar, all-day work totals do not
+}
+
+// ICSFilter limits an export to some projects and workhour types; empty lists keep everything
+type ICSFilter struct {
+	ProjectIDs []int
+	DetailsIDs []int
+}
+
+// Matches reports whether a workhour passes the filter
+func (f ICSFilter) Matches(wh Workhour) bool {
+	if len(f.ProjectIDs) > 0 && !slices.Contains(f.ProjectIDs, wh.ProjectID) {
+		return false
+	}
+	if len(f.DetailsIDs) > 0 && !slices.Contains(f.DetailsIDs, wh.DetailsID) {
+		return false
+	}
+	return true
+}
+
+// WorkhourEvents maps workhours to calendar events. Entries with start and end
+// times become timed events, the others all-day events of their date. Entries of
+// the leaveTypes become out-of-office events named after the type only, so
+// calendars shared with managers do not show what else was logged. All-day leave
+// shorter than dailyTarget is labelled with its hours.
+func WorkhourEvents(workhours []Workhour, detailsMap map[int]WorkhourDetails, projectsMap map[int]Project, tasksMap map[int]Task, leaveTypes map[int]bool, dailyTarget float64) []CalendarEvent {
+	events := make([]CalendarEvent, 0, len(workhours))
+	for _, wh := range workhours {
+		details := detailsMap[wh.DetailsID]
+		typeName := details.Name
+		if typeName == "" {
+			typeName = fmt.Sprintf("Type %d", wh.DetailsID)
+		}
+		hours := fmt.Sprintf("%gh", math.Round(wh.Hours*100)/100)
+
+		event := CalendarEvent{UID: wh.UUID + "@tltui", AllDay: !wh.HasTimes()}
+		if wh.UUID == "" {
+			event.UID = fmt.Sprintf("workhour-%d@tltui", wh.ID)
+		}
+
+		day := time.Date(wh.Date.Year(), wh.Date.Month(), wh.Date.Day(), 0, 0, 0, 0, time.Local)
+		if event.AllDay {
+			event.Start, event.End = day, day.AddDate(0, 0, 1)
+		} else {
+			event.Start = day.Add(time.Duration(wh.Start) * time.Minute)
+			event.End = day.Add(time.Duration(wh.End) * time.Minute)
+		}
+
+		if leaveTypes[wh.DetailsID] {
+			event.OutOfOffice = true
+			event.Summary = "Out of office: " + typeName
+			if event.AllDay && wh.Hours < dailyTarget {
+				event.Summary += " (" + hours + ")"
+			}
+			event.Description = typeName + ", " + hours
+			events = append(events, event)
+			continue
+		}
+
+		projectName := projectsMap[wh.ProjectID].Name
+		if projectName == "" {
+			projectName = fmt.Sprintf("Project %d", wh.ProjectID)
+		}
+		label := details.ShortName
+		if label == "" {
+			label = typeName
+		}
+		event.Busy = !event.AllDay
+		event.Summary = fmt.Sprintf("%s: %s %s", projectName, label, hours)
+		event.Description = fmt.Sprintf("%s, %s\nProject: %s", typeName, hours, projectName)
+		if task, ok := tasksMap[wh.TaskID]; ok && wh.TaskID != 0 {
+			event.Description += "\nTask: " + task.Name
+		}
+		if wh.BreakMinutes > 0 {
+			event.Description += fmt.Sprintf("\nBreak: %dm", wh.BreakMinutes)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// WriteICS writes events as an iCalendar document named name. Timed events are
+// written in UTC; stamp is the DTSTAMP of every event.
+func WriteICS(w io.Writer, name string, events []CalendarEvent, stamp time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(content string) {
+		out.WriteString(foldICSLine(content))
+		out.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//tltui//timesheet//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeICSText(name))
+
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeICSText(e.UID))
+		line("DTSTAMP:" + stamp.UTC().Format(icsTimeLayout))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE:" + e.Start.Format(icsDateLayout))
+			line("DTEND;VALUE=DATE:" + e.End.Format(icsDateLayout))
+		} else {
+			line("DTSTART:" + e.Start.UTC().Format(icsTimeLayout))
+			line("DTEND:" + e.End.UTC().Format(icsTimeLayout))
+		}
+		line("SUMMARY:" + escapeICSText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeICSText(e.Description))
+		}
+
+		switch {
+		case e.OutOfOffice:
+			line("CATEGORIES:Leave")
+			line("TRANSP:OPAQUE")
+			line("X-MICROSOFT-CDO-BUSYSTATUS:OOF")
+		case e.Busy:
+			line("CATEGORIES:Work")
+			line("TRANSP:OPAQUE")
+			line("X-MICROSOFT-CDO-BUSYSTATUS:BUSY")
+		default:
+			line("CATEGORIES:Work")
+			line("TRANSP:TRANSPARENT")
+			line("X-MICROSOFT-CDO-BUSYSTATUS:FREE")
+		}
+		line("STATUS:CONFIRMED")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return out.Flush()
+}
+
+const (
+	icsDateLayout = "20060102"
+	icsTimeLayout = "20060102T150405Z"
+)
+
+// escapeICSText escapes a TEXT value as required by RFC 5545
+func escapeICSText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// foldICSLine splits a content line into lines of at most 75 octets, continued
+// with a leading space, without breaking UTF-8 sequences
+func foldICSLine(content string) string {
+	const limit = 75
+	if len(content) <= limit {
+		return content
+	}
+
+	var sb strings.Builder
+	width := 0
+	for len(content) > 0 {
+		_, size := utf8.DecodeRuneInString(content)
+		if width+size > limit {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteString(content[:size])
+		width += size
+		content = content[size:]
+	}
+	return sb.String()
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseICS reads the VEVENTs of an iCalendar document back into property maps,
+// unfolding and unescaping the lines
+func parseICS(t *testing.T, data string) []map[string]string {
+	t.Helper()
+	if strings.Contains(strings.ReplaceAll(data, "\r\n", ""), "\n") {
+		t.Fatal("expected CRLF line endings only")
+	}
+
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line longer than 75 octets: %q", l)
+		}
+		if strings.HasPrefix(l, " ") && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Fatalf("expected a VCALENDAR, got %q ... %q", lines[0], lines[len(lines)-1])
+	}
+
+	unescape := strings.NewReplacer(`\n`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	var events []map[string]string
+	var current map[string]string
+	for _, l := range lines {
+		switch l {
+		case "BEGIN:VEVENT":
+			current = map[string]string{}
+			continue
+		case "END:VEVENT":
+			events = append(events, current)
+			current = nil
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		name, value, ok := strings.Cut(l, ":")
+		if !ok {
+			t.Fatalf("invalid content line %q", l)
+		}
+		current[name] = unescape.Replace(value)
+	}
+	return events
+}
+
+func TestWriteICS_RoundTrip(t *testing.T) {
+	detailsMap := map[int]WorkhourDetails{
+		1: {ID: 1, Name: "Development", ShortName: "DEV", IsWork: true},
+		2: {ID: 2, Name: "Vacation", ShortName: "VAC"},
+	}
+	projectsMap := map[int]Project{1: {ID: 1, Name: "Arnia, Inc; Romania"}}
+	tasksMap := map[int]Task{4: {ID: 4, ProjectID: 1, Name: "API"}}
+	leaveTypes := map[int]bool{2: true}
+
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	workhours := []Workhour{
+		{ID: 1, UUID: "a1", Date: day, DetailsID: 1, ProjectID: 1, TaskID: 4, Hours: 2.5, Start: NewClockTime(9, 0), End: NewClockTime(11, 30)},
+		{ID: 2, UUID: "a2", Date: day, DetailsID: 1, ProjectID: 1, Hours: 5},
+		{ID: 3, UUID: "a3", Date: day.AddDate(0, 0, 1), DetailsID: 2, ProjectID: 1, Hours: 8},
+		{ID: 4, Date: day.AddDate(0, 0, 2), DetailsID: 2, ProjectID: 1, Hours: 4},
+	}
+
+	events := WorkhourEvents(workhours, detailsMap, projectsMap, tasksMap, leaveTypes, 8)
+	var buf bytes.Buffer
+	stamp := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	if err := WriteICS(&buf, "Timesheet of "+strings.Repeat("a very long name ", 6), events, stamp); err != nil {
+		t.Fatalf("WriteICS failed: %v", err)
+	}
+
+	parsed := parseICS(t, buf.String())
+	if len(parsed) != len(workhours) {
+		t.Fatalf("got %d events, want %d", len(parsed), len(workhours))
+	}
+
+	for i, wh := range workhours {
+		got := parsed[i]
+		if got["DTSTAMP"] != "20250310T120000Z" {
+			t.Errorf("event %d: DTSTAMP = %q", i, got["DTSTAMP"])
+		}
+		if want := events[i].UID; got["UID"] != want {
+			t.Errorf("event %d: UID = %q, want %q", i, got["UID"], want)
+		}
+
+		if wh.HasTimes() {
+			start, err := time.Parse(icsTimeLayout, got["DTSTART"])
+			if err != nil || !start.Equal(day.Add(9*time.Hour)) {
+				t.Errorf("event %d: DTSTART = %q (%v), want 09:00 local", i, got["DTSTART"], err)
+			}
+			end, _ := time.Parse(icsTimeLayout, got["DTEND"])
+			if hours := end.Sub(start).Hours(); hours != wh.Hours {
+				t.Errorf("event %d: lasts %vh, want %vh", i, hours, wh.Hours)
+			}
+		} else {
+			date := wh.Date.Format(icsDateLayout)
+			next := wh.Date.AddDate(0, 0, 1).Format(icsDateLayout)
+			if got["DTSTART;VALUE=DATE"] != date || got["DTEND;VALUE=DATE"] != next {
+				t.Errorf("event %d: all-day %q-%q, want %q-%q", i, got["DTSTART;VALUE=DATE"], got["DTEND;VALUE=DATE"], date, next)
+			}
+		}
+
+		wantStatus := map[bool]string{true: "OOF", false: "FREE"}[leaveTypes[wh.DetailsID]]
+		if wh.HasTimes() {
+			wantStatus = "BUSY"
+		}
+		if got["X-MICROSOFT-CDO-BUSYSTATUS"] != wantStatus {
+			t.Errorf("event %d: busy status = %q, want %q", i, got["X-MICROSOFT-CDO-BUSYSTATUS"], wantStatus)
+		}
+	}
+
+	if got := parsed[0]["SUMMARY"]; got != "Arnia, Inc; Romania: DEV 2.5h" {
+		t.Errorf("SUMMARY = %q", got)
+	}
+	if got := parsed[0]["DESCRIPTION"]; got != "Development, 2.5h\nProject: Arnia, Inc; Romania\nTask: API" {
+		t.Errorf("DESCRIPTION = %q", got)
+	}
+	if got := parsed[2]["SUMMARY"]; got != "Out of office: Vacation" {
+		t.Errorf("leave SUMMARY = %q", got)
+	}
+	if got := parsed[3]["SUMMARY"]; got != "Out of office: Vacation (4h)" {
+		t.Errorf("partial leave SUMMARY = %q", got)
+	}
+	if got := parsed[3]["UID"]; got != "workhour-4@tltui" {
+		t.Errorf("expected a UID from the ID without a UUID, got %q", got)
+	}
+
+	// A half day of leave is a full day for a 4h daily target
+	short := WorkhourEvents(workhours[3:], detailsMap, projectsMap, tasksMap, leaveTypes, 4)
+	if got := short[0].Summary; got != "Out of office: Vacation" {
+		t.Errorf("leave SUMMARY for a 4h target = %q", got)
+	}
+}
+
+func TestICSFilter_Matches(t *testing.T) {
+	wh := Workhour{ProjectID: 1, DetailsID: 2}
+	tests := []struct {
+		filter ICSFilter
+		want   bool
+	}{
+		{ICSFilter{}, true},
+		{ICSFilter{ProjectIDs: []int{1, 3}}, true},
+		{ICSFilter{ProjectIDs: []int{3}}, false},
+		{ICSFilter{DetailsIDs: []int{2}}, true},
+		{ICSFilter{ProjectIDs: []int{1}, DetailsIDs: []int{1}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Matches(wh); got != tt.want {
+			t.Errorf("%+v.Matches() = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
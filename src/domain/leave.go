@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultDailyTargetHours is the expected working time of a regular day
+const DefaultDailyTargetHours = 8.0
+
+// LeaveBalance is the state of a leave entitlement at a given date
+type LeaveBalance struct {
+	Entitlement   LeaveEntitlement
+	UsedDays      float64
+	ExpiredDays   float64
+	RemainingDays float64
+}
+
+// TotalDays returns the days granted by the entitlement, carry-over included
+func (b LeaveBalance) TotalDays() float64 {
+	return b.Entitlement.AllowanceDays + b.Entitlement.CarryOverDays
+}
+
+// CalculateLeaveBalance computes used and remaining days of an entitlement.
+// Only workhours of the entitlement's type and year are counted, converting
+// hours to days against dailyTargetHours. Leave taken up to the carry-over
+// expiry consumes carry-over days first; whatever is left of the carry-over
+// lapses once asOf is past the expiry date.
+func CalculateLeaveBalance(entitlement LeaveEntitlement, workhours []Workhour, dailyTargetHours float64, asOf time.Time) LeaveBalance {
+	if dailyTargetHours <= 0 {
+		dailyTargetHours = DefaultDailyTargetHours
+	}
+
+	relevant := make([]Workhour, 0, len(workhours))
+	for _, wh := range workhours {
+		if wh.DetailsID == entitlement.DetailsID && wh.Date.Year() == entitlement.Year {
+			relevant = append(relevant, wh)
+		}
+	}
+	sort.SliceStable(relevant, func(i, j int) bool {
+		return relevant[i].Date.Before(relevant[j].Date)
+	})
+
+	balance := LeaveBalance{Entitlement: entitlement}
+	carryOverLeft := entitlement.CarryOverDays
+
+	for _, wh := range relevant {
+		days := wh.Hours / dailyTargetHours
+		balance.UsedDays += days
+
+		if carryOverLeft > 0 && !afterDay(wh.Date, entitlement.CarryOverExpiry) {
+			carryOverLeft -= min(days, carryOverLeft)
+		}
+	}
+
+	if carryOverLeft > 0 && afterDay(asOf, entitlement.CarryOverExpiry) {
+		balance.ExpiredDays = carryOverLeft
+	}
+
+	balance.RemainingDays = balance.TotalDays() - balance.UsedDays - balance.ExpiredDays
+	return balance
+}
+
+// afterDay reports whether date falls on a later calendar day than expiry.
+// A nil expiry never expires.
+func afterDay(date time.Time, expiry *time.Time) bool {
+	if expiry == nil {
+		return false
+	}
+	y1, m1, d1 := date.Date()
+	y2, m2, d2 := expiry.Date()
+	if y1 != y2 {
+		return y1 > y2
+	}
+	if m1 != m2 {
+		return m1 > m2
+	}
+	return d1 > d2
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateLeaveBalance(t *testing.T) {
+	expiry := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	entitlement := LeaveEntitlement{
+		Year:            2025,
+		DetailsID:       3,
+		AllowanceDays:   21,
+		CarryOverDays:   5,
+		CarryOverExpiry: &expiry,
+	}
+
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		workhours     []Workhour
+		asOf          time.Time
+		wantUsed      float64
+		wantExpired   float64
+		wantRemaining float64
+	}{
+		{
+			name:          "nothing used before expiry",
+			asOf:          day(time.February, 1),
+			wantRemaining: 26,
+		},
+		{
+			name:          "unused carry-over lapses after expiry",
+			asOf:          day(time.April, 1),
+			wantExpired:   5,
+			wantRemaining: 21,
+		},
+		{
+			name: "leave before expiry consumes carry-over first",
+			workhours: []Workhour{
+				{Date: day(time.January, 10), DetailsID: 3, Hours: 8},
+				{Date: day(time.January, 11), DetailsID: 3, Hours: 8},
+				{Date: day(time.January, 12), DetailsID: 3, Hours: 4},
+			},
+			asOf:          day(time.May, 1),
+			wantUsed:      2.5,
+			wantExpired:   2.5,
+			wantRemaining: 21,
+		},
+		{
+			name: "leave after expiry draws from the allowance",
+			workhours: []Workhour{
+				{Date: day(time.June, 2), DetailsID: 3, Hours: 16},
+			},
+			asOf:          day(time.June, 30),
+			wantUsed:      2,
+			wantExpired:   5,
+			wantRemaining: 19,
+		},
+		{
+			name: "other types and years are ignored",
+			workhours: []Workhour{
+				{Date: day(time.June, 2), DetailsID: 1, Hours: 8},
+				{Date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), DetailsID: 3, Hours: 8},
+			},
+			asOf:          day(time.February, 1),
+			wantRemaining: 26,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateLeaveBalance(entitlement, tt.workhours, 8, tt.asOf)
+
+			if got.UsedDays != tt.wantUsed {
+				t.Errorf("got used %v, want %v", got.UsedDays, tt.wantUsed)
+			}
+			if got.ExpiredDays != tt.wantExpired {
+				t.Errorf("got expired %v, want %v", got.ExpiredDays, tt.wantExpired)
+			}
+			if got.RemainingDays != tt.wantRemaining {
+				t.Errorf("got remaining %v, want %v", got.RemainingDays, tt.wantRemaining)
+			}
+		})
+	}
+}
@@ -3,22 +3,87 @@ package domain
 import "time"
 
 type Project struct {
-	ID     int
-	Name   string
-	OdooID int
+	ID       int
+	Name     string
+	OdooID   int
+	ClientID int // 0 when the project has no client
+
+	BudgetHours  float64 // 0 when the project has no budget
+	BudgetPeriod BudgetPeriod
 }
 
-type WorkhourDetails struct {
+// HasBudget reports whether the project has an hour budget
+func (p Project) HasBudget() bool {
+	return p.BudgetHours > 0
+}
+
+// Client is the company projects are billed to
+type Client struct {
+	ID               int
+	Name             string
+	LegalName        string
+	TaxID            string
+	Address          string
+	Language         string // Report language code, e.g. "ro" or "en"
+	BillingContact   string
+	ReportRecipients []string // Addresses generated reports are emailed to
+}
+
+// DisplayLegalName returns the legal name, falling back to the short name
+func (c Client) DisplayLegalName() string {
+	if c.LegalName != "" {
+		return c.LegalName
+	}
+	return c.Name
+}
+
+// Task is a sub-item of a project that hours can be booked against
+type Task struct {
 	ID        int
+	ProjectID int
 	Name      string
-	ShortName string
-	IsWork    bool
+	OdooID    int // 0 when the task is not linked to an Odoo task
+}
+
+// OvertimeRole describes how a workhour details type affects the overtime balance
+type OvertimeRole int
+
+const (
+	OvertimeRoleNone          OvertimeRole = iota
+	OvertimeRoleOvertime                   // Hours count entirely as overtime
+	OvertimeRoleTimeOffInLieu              // Hours cover the daily target but consume the balance
+)
+
+type WorkhourDetails struct {
+	ID           int
+	Name         string
+	ShortName    string
+	IsWork       bool
+	OvertimeRole OvertimeRole
 }
 
 type Workhour struct {
 	ID        int
+	UUID      string // Stable identity shared by every synced replica
 	Date      time.Time
 	DetailsID int
 	ProjectID int
+	TaskID    int // 0 when no task is set
 	Hours     float64
+
+	// Optional clock times; End is 0 when the entry only has a total
+	Start        ClockTime
+	End          ClockTime
+	BreakMinutes int
+
+	IssueKey string // Issue the hours are logged on in a tracker, e.g. "API-12" or "group/api#12"
+}
+
+type LeaveEntitlement struct {
+	ID              int
+	Year            int
+	DetailsID       int
+	AllowanceDays   float64
+	CarryOverDays   float64
+	CarryOverExpiry *time.Time // Unused carry-over lapses after this date
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMonthLocked is returned when a change touches a locked month
+var ErrMonthLocked = errors.New("month is locked")
+
+// MonthLock closes a month for changes once its reports were sent. Unlocking keeps the
+// record and notes who unlocked it, when and why.
+type MonthLock struct {
+	ID       int
+	Year     int
+	Month    time.Month
+	LockedAt time.Time
+	LockedBy string
+
+	UnlockedAt   time.Time // Zero while the lock is active
+	UnlockedBy   string
+	UnlockReason string
+}
+
+// IsActive reports whether the lock still applies
+func (l MonthLock) IsActive() bool {
+	return l.UnlockedAt.IsZero()
+}
+
+// Contains reports whether a date falls in the locked month
+func (l MonthLock) Contains(date time.Time) bool {
+	return date.Year() == l.Year && date.Month() == l.Month
+}
+
+// Label formats the locked month as "March 2025"
+func (l MonthLock) Label() string {
+	return fmt.Sprintf("%s %d", l.Month, l.Year)
+}
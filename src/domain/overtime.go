@@ -0,0 +1,118 @@
+package domain
+
+import "time"
+
+// OvertimeTargets is the expected working time used by the overtime ledger
+type OvertimeTargets struct {
+	DailyHours  float64 // Expected hours on each weekday
+	WeeklyHours float64 // Cap on the expected hours of a Monday-Sunday week
+}
+
+// OvertimeMonth is one month of the overtime ledger
+type OvertimeMonth struct {
+	Month          time.Month
+	ExpectedHours  float64 // Target hours of the elapsed weekdays
+	WorkedHours    float64 // Regular work plus absences that count towards the target
+	OvertimeHours  float64 // Hours logged with an explicit overtime type
+	TimeOffHours   float64 // Time off in lieu taken, consuming the balance
+	Balance        float64 // Overtime gained (or lost) during the month
+	RunningBalance float64 // Balance accumulated since the start of the year
+}
+
+// OvertimeLedger is the month-by-month overtime account of a year
+type OvertimeLedger struct {
+	Year    int
+	Months  []OvertimeMonth
+	Balance float64
+}
+
+// MonthEntry returns the ledger row of a month, or nil when it is not covered
+func (l OvertimeLedger) MonthEntry(month time.Month) *OvertimeMonth {
+	for i := range l.Months {
+		if l.Months[i].Month == month {
+			return &l.Months[i]
+		}
+	}
+	return nil
+}
+
+// CalculateOvertimeLedger compares logged hours against the expected working
+// time of each weekday of the year, up to and including asOf.
+//
+// Regular work and non-work absences (leave, holidays) count towards the
+// target. Hours of OvertimeRoleOvertime types are added to the balance as a
+// whole, while OvertimeRoleTimeOffInLieu hours cover the target but are taken
+// from the balance. Weekdays stop expecting hours once the week reaches
+// WeeklyHours.
+func CalculateOvertimeLedger(year int, workhours []Workhour, detailsMap map[int]WorkhourDetails, targets OvertimeTargets, asOf time.Time) OvertimeLedger {
+	if targets.DailyHours <= 0 {
+		targets.DailyHours = DefaultDailyTargetHours
+	}
+	if targets.WeeklyHours <= 0 {
+		targets.WeeklyHours = targets.DailyHours * 5
+	}
+
+	ledger := OvertimeLedger{Year: year}
+
+	lastDay := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	asOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	if asOfDay.Before(lastDay) {
+		lastDay = asOfDay
+	}
+	if lastDay.Year() < year {
+		return ledger
+	}
+
+	months := make(map[time.Month]*OvertimeMonth)
+	monthFor := func(month time.Month) *OvertimeMonth {
+		if months[month] == nil {
+			months[month] = &OvertimeMonth{Month: month}
+		}
+		return months[month]
+	}
+
+	weekExpected := 0.0
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Monday {
+			weekExpected = 0
+		}
+		entry := monthFor(day.Month())
+
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			expected := min(targets.DailyHours, max(targets.WeeklyHours-weekExpected, 0))
+			weekExpected += expected
+			entry.ExpectedHours += expected
+		}
+	}
+
+	for _, wh := range workhours {
+		date := time.Date(wh.Date.Year(), wh.Date.Month(), wh.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if date.Year() != year || date.After(lastDay) {
+			continue
+		}
+
+		entry := monthFor(date.Month())
+		switch detailsMap[wh.DetailsID].OvertimeRole {
+		case OvertimeRoleOvertime:
+			entry.OvertimeHours += wh.Hours
+		case OvertimeRoleTimeOffInLieu:
+			entry.TimeOffHours += wh.Hours
+		default:
+			entry.WorkedHours += wh.Hours
+		}
+	}
+
+	running := 0.0
+	for month := time.January; month <= lastDay.Month(); month++ {
+		entry := monthFor(month)
+		// Time off in lieu covers the target and is taken from the balance,
+		// so it cancels out and only the remaining gap is counted
+		entry.Balance = entry.WorkedHours + entry.OvertimeHours - entry.ExpectedHours
+		running += entry.Balance
+		entry.RunningBalance = running
+		ledger.Months = append(ledger.Months, *entry)
+	}
+	ledger.Balance = running
+
+	return ledger
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateOvertimeLedger(t *testing.T) {
+	detailsMap := map[int]WorkhourDetails{
+		1: {ID: 1, Name: "Development", IsWork: true},
+		2: {ID: 2, Name: "Development Overtime", IsWork: true, OvertimeRole: OvertimeRoleOvertime},
+		3: {ID: 3, Name: "Time Off In Lieu", OvertimeRole: OvertimeRoleTimeOffInLieu},
+		4: {ID: 4, Name: "Vacation"},
+	}
+	targets := OvertimeTargets{DailyHours: 8, WeeklyHours: 40}
+
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Monday 6 January to Friday 10 January 2025
+	fullWeek := func(detailsID int) []Workhour {
+		var workhours []Workhour
+		for d := 6; d <= 10; d++ {
+			workhours = append(workhours, Workhour{Date: day(time.January, d), DetailsID: detailsID, Hours: 8})
+		}
+		return workhours
+	}
+
+	tests := []struct {
+		name        string
+		workhours   []Workhour
+		asOf        time.Time
+		wantMonths  int
+		wantBalance float64
+	}{
+		{
+			name:        "full week of regular work is balanced",
+			workhours:   fullWeek(1),
+			asOf:        day(time.January, 10),
+			wantMonths:  1,
+			wantBalance: -24, // 1-3 January expected but not logged
+		},
+		{
+			name:        "leave counts towards the target",
+			workhours:   append(fullWeek(4), Workhour{Date: day(time.January, 2), DetailsID: 1, Hours: 8}, Workhour{Date: day(time.January, 3), DetailsID: 1, Hours: 8}, Workhour{Date: day(time.January, 1), DetailsID: 4, Hours: 8}),
+			asOf:        day(time.January, 10),
+			wantMonths:  1,
+			wantBalance: 0,
+		},
+		{
+			name: "explicit overtime adds to the balance",
+			workhours: []Workhour{
+				{Date: day(time.January, 1), DetailsID: 1, Hours: 8},
+				{Date: day(time.January, 1), DetailsID: 2, Hours: 2},
+			},
+			asOf:        day(time.January, 1),
+			wantMonths:  1,
+			wantBalance: 2,
+		},
+		{
+			name: "time off in lieu consumes the balance",
+			workhours: []Workhour{
+				{Date: day(time.January, 1), DetailsID: 1, Hours: 8},
+				{Date: day(time.January, 1), DetailsID: 2, Hours: 8},
+				{Date: day(time.January, 2), DetailsID: 3, Hours: 8},
+			},
+			asOf:        day(time.January, 2),
+			wantMonths:  1,
+			wantBalance: 0,
+		},
+		{
+			name:        "weekend work is overtime",
+			workhours:   []Workhour{{Date: day(time.January, 4), DetailsID: 1, Hours: 4}},
+			asOf:        day(time.January, 5),
+			wantMonths:  1,
+			wantBalance: -24 + 4,
+		},
+		{
+			name: "future hours are ignored",
+			workhours: []Workhour{
+				{Date: day(time.February, 3), DetailsID: 1, Hours: 8},
+			},
+			asOf:        day(time.January, 1),
+			wantMonths:  1,
+			wantBalance: -8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := CalculateOvertimeLedger(2025, tt.workhours, detailsMap, targets, tt.asOf)
+
+			if len(ledger.Months) != tt.wantMonths {
+				t.Errorf("got %d months, want %d", len(ledger.Months), tt.wantMonths)
+			}
+			if ledger.Balance != tt.wantBalance {
+				t.Errorf("got balance %v, want %v", ledger.Balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestCalculateOvertimeLedger_RunningBalance(t *testing.T) {
+	detailsMap := map[int]WorkhourDetails{1: {ID: 1, IsWork: true}}
+	targets := OvertimeTargets{DailyHours: 8, WeeklyHours: 40}
+
+	// Log exactly the target every weekday of January and February, plus
+	// two extra hours on 31 January
+	var workhours []Workhour
+	for day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); day.Month() <= time.February; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		workhours = append(workhours, Workhour{Date: day, DetailsID: 1, Hours: 8})
+	}
+	workhours = append(workhours, Workhour{Date: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), DetailsID: 1, Hours: 2})
+
+	ledger := CalculateOvertimeLedger(2025, workhours, detailsMap, targets, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+
+	january := ledger.MonthEntry(time.January)
+	february := ledger.MonthEntry(time.February)
+	if january == nil || february == nil {
+		t.Fatal("expected January and February entries")
+	}
+	if january.ExpectedHours != 23*8 {
+		t.Errorf("got January expected %v, want %v", january.ExpectedHours, 23*8)
+	}
+	if january.Balance != 2 || january.RunningBalance != 2 {
+		t.Errorf("got January balance %v/%v, want 2/2", january.Balance, january.RunningBalance)
+	}
+	if february.Balance != 0 || february.RunningBalance != 2 {
+		t.Errorf("got February balance %v/%v, want 0/2", february.Balance, february.RunningBalance)
+	}
+	if ledger.MonthEntry(time.March) != nil {
+		t.Error("expected no entry after asOf")
+	}
+}
+
+func TestCalculateOvertimeLedger_WeeklyCap(t *testing.T) {
+	targets := OvertimeTargets{DailyHours: 8, WeeklyHours: 20}
+
+	// Monday 6 January to Sunday 12 January 2025
+	ledger := CalculateOvertimeLedger(2025, nil, nil, targets, time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC))
+
+	// 1-3 January (Wed-Fri) expects 20h, the full week of the 6th another 20h
+	if got := ledger.MonthEntry(time.January).ExpectedHours; got != 40 {
+		t.Errorf("got expected hours %v, want 40", got)
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default templates of the emails reports are sent with, used until others are configured
+const (
+	DefaultEmailSubjectTemplate = "Activity report {period}"
+	DefaultEmailBodyTemplate    = "Hello,\n\nPlease find attached the activity report for {period}.\n\nBest regards,\n{from}\n"
+)
+
+// EmailPlaceholders lists the placeholders of the email templates with their description
+var EmailPlaceholders = [][2]string{
+	{"{period}", "reported month, e.g. March 2025"},
+	{"{month}", "name of the reported month"},
+	{"{year}", "reported year"},
+	{"{invoice}", "invoice name of a mail report"},
+	{"{from}", "From Company of a mail report"},
+	{"{to}", "To Company of a mail report"},
+	{"{client}", "name of the client the report is for"},
+	{"{report}", "kind of report, e.g. Mail Report"},
+}
+
+// RenderEmailTemplate fills the placeholders of an email template with the values
+// of a generated report. Placeholders without a value are left empty.
+func RenderEmailTemplate(template string, record ReportRecord, clientName string) string {
+	replacer := strings.NewReplacer(
+		"{period}", record.Period(),
+		"{month}", record.Month.String(),
+		"{year}", strconv.Itoa(record.Year),
+		"{invoice}", record.Params.InvoiceName,
+		"{from}", record.Params.FromCompany,
+		"{to}", record.Params.ToCompany,
+		"{client}", clientName,
+		"{report}", record.Kind.Label(),
+	)
+	return replacer.Replace(template)
+}
+
+// ParseEmailList splits a list of addresses separated by commas or semicolons,
+// e.g. "ana@example.com, Billing <billing@example.com>". Duplicates are dropped.
+func ParseEmailList(value string) ([]string, error) {
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		address, err := mail.ParseAddress(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid email address", part)
+		}
+		key := strings.ToLower(address.Address)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		addresses = append(addresses, part)
+	}
+	return addresses, nil
+}
+
+// SentEmail records a generated report emailed to its recipients
+type SentEmail struct {
+	ID          int
+	ReportID    int // Report history entry that was sent, 0 when it was removed since
+	Recipients  []string
+	Subject     string
+	Attachments []string // File names of the attachments
+	SentAt      time.Time
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderEmailTemplate(t *testing.T) {
+	record := ReportRecord{
+		Kind:   ReportKindMailReport,
+		Year:   2025,
+		Month:  3,
+		Params: ReportParams{FromCompany: "Me S.R.L.", InvoiceName: "INV-7"},
+	}
+
+	got := RenderEmailTemplate("{report} {period} ({month}/{year}) {invoice} for {client} from {from}{to}", record, "Arnia")
+	want := "Mail Report March 2025 (March/2025) INV-7 for Arnia from Me S.R.L."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseEmailList(t *testing.T) {
+	got, err := ParseEmailList("ana@example.com; Billing <billing@example.com>, ANA@example.com,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"ana@example.com", "Billing <billing@example.com>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, err := ParseEmailList("  "); err != nil || len(got) != 0 {
+		t.Errorf("expected no addresses for a blank list, got %q, %v", got, err)
+	}
+	if _, err := ParseEmailList("ana@example.com, not an address"); err == nil {
+		t.Error("expected an invalid address to be refused")
+	}
+}
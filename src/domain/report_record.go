@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ReportKind identifies the generator that produced a report
+type ReportKind string
+
+const (
+	ReportKindOdooCSV    ReportKind = "odoo_csv"
+	ReportKindMailReport ReportKind = "mail_report"
+	ReportKindXLSX       ReportKind = "xlsx"
+	ReportKindMarkdown   ReportKind = "markdown_summary"
+	ReportKindHTML       ReportKind = "html_summary"
+)
+
+// Label is the name of the report kind shown to the user
+func (k ReportKind) Label() string {
+	switch k {
+	case ReportKindOdooCSV:
+		return "Odoo CSV"
+	case ReportKindMailReport:
+		return "Mail Report"
+	case ReportKindXLSX:
+		return "XLSX Timesheet"
+	case ReportKindMarkdown:
+		return "Wiki Summary (Markdown)"
+	case ReportKindHTML:
+		return "Email Summary (HTML)"
+	default:
+		return string(k)
+	}
+}
+
+// ReportParams are the inputs a report was generated with, enough to generate it again
+type ReportParams struct {
+	ClientID           int                        `json:"client_id,omitempty"`
+	FromCompany        string                     `json:"from_company,omitempty"`
+	ToCompany          string                     `json:"to_company,omitempty"`
+	InvoiceName        string                     `json:"invoice_name,omitempty"`
+	SignatureImagePath string                     `json:"signature_image_path,omitempty"`
+	SelectedItems      map[string]map[string]bool `json:"selected_items,omitempty"` // project -> activity -> selected
+}
+
+// ReportRecord is a generated report kept in the report history. ContentHash is
+// the hash of the written file and DataHash the fingerprint of the workhours it
+// was generated from, used to tell whether either changed since.
+type ReportRecord struct {
+	ID          int
+	Kind        ReportKind
+	Year        int
+	Month       time.Month
+	Params      ReportParams
+	Path        string
+	ContentHash string
+	DataHash    string
+	GeneratedAt time.Time
+}
+
+// Period is the reported month, e.g. "March 2025"
+func (r ReportRecord) Period() string {
+	return fmt.Sprintf("%s %d", r.Month, r.Year)
+}
+
+// ReportStatus tells how a generated report compares to its file and data today
+type ReportStatus int
+
+const (
+	ReportUpToDate    ReportStatus = iota
+	ReportDataChanged              // The workhours of the period changed since generation
+	ReportFileChanged              // The file on disk differs from the generated one
+	ReportFileMissing              // The file was moved or deleted
+)
+
+func (s ReportStatus) String() string {
+	switch s {
+	case ReportDataChanged:
+		return "data changed"
+	case ReportFileChanged:
+		return "file modified"
+	case ReportFileMissing:
+		return "file missing"
+	default:
+		return "up to date"
+	}
+}
+
+// WorkhoursFingerprint hashes the workhours a report is built from. Only their values
+// count, so the result depends neither on the order of the entries nor on their IDs,
+// e.g. of entries deleted and pasted back unchanged.
+func WorkhoursFingerprint(workhours []Workhour) string {
+	lines := make([]string, len(workhours))
+	for i, wh := range workhours {
+		lines[i] = fmt.Sprintf("%s|%d|%d|%d|%g|%d|%d|%d\n",
+			wh.Date.Format("2006-01-02"), wh.DetailsID, wh.ProjectID, wh.TaskID,
+			wh.Hours, wh.Start, wh.End, wh.BreakMinutes)
+	}
+	sort.Strings(lines)
+
+	hash := sha256.New()
+	for _, line := range lines {
+		hash.Write([]byte(line))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
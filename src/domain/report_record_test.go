@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkhoursFingerprint(t *testing.T) {
+	date := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	a := Workhour{ID: 1, Date: date, DetailsID: 1, ProjectID: 1, Hours: 4}
+	b := Workhour{ID: 2, Date: date.AddDate(0, 0, 1), DetailsID: 1, ProjectID: 2, Hours: 3.5}
+
+	fingerprint := WorkhoursFingerprint([]Workhour{a, b})
+	if fingerprint != WorkhoursFingerprint([]Workhour{b, a}) {
+		t.Error("expected the fingerprint not to depend on the order of entries")
+	}
+
+	changed := b
+	changed.Hours = 4
+	if fingerprint == WorkhoursFingerprint([]Workhour{a, changed}) {
+		t.Error("expected changed hours to change the fingerprint")
+	}
+	if fingerprint == WorkhoursFingerprint([]Workhour{a}) {
+		t.Error("expected a removed entry to change the fingerprint")
+	}
+	pasted := a
+	pasted.ID = 7
+	if fingerprint != WorkhoursFingerprint([]Workhour{b, pasted}) {
+		t.Error("expected an entry pasted back unchanged under a new ID to keep the fingerprint")
+	}
+	if fingerprint == WorkhoursFingerprint([]Workhour{a, pasted, b}) {
+		t.Error("expected a duplicated entry to change the fingerprint")
+	}
+	if WorkhoursFingerprint(nil) == "" {
+		t.Error("expected a fingerprint for an empty month")
+	}
+}
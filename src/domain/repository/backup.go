@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBackupKeep is the number of automatic backups kept when not configured
+const DefaultBackupKeep = 7
+
+const (
+	backupTimeLayout = "20060102-150405"
+	autoBackupPrefix = "auto-"
+)
+
+// BackupDir returns the directory backups of the open database are written to,
+// next to the database file
+func BackupDir() (string, error) {
+	if dbPath == "" {
+		return "", fmt.Errorf("the open database is not stored in a file")
+	}
+	return filepath.Join(filepath.Dir(dbPath), "backups"), nil
+}
+
+// Backup writes a consistent copy of the open database to dest, which must not
+// exist yet. An empty dest writes a timestamped file to the backup directory.
+// It returns the file written.
+func Backup(dest string) (string, error) {
+	if dest == "" {
+		name, err := newBackupPath("data-")
+		if err != nil {
+			return "", err
+		}
+		dest = name
+	}
+	return dest, backupTo(dest)
+}
+
+// AutoBackup makes the daily automatic backup of the open database unless one
+// was already made today, then removes the oldest automatic backups beyond the
+// configured number to keep. Read-only databases are not backed up.
+func AutoBackup() error {
+	keep := GetBackupKeep()
+	if readOnly || dbPath == "" || keep == 0 {
+		return nil
+	}
+
+	dir, err := BackupDir()
+	if err != nil {
+		return err
+	}
+	backups, err := autoBackups(dir)
+	if err != nil {
+		return err
+	}
+
+	today := autoBackupPrefix + time.Now().Format("20060102")
+	if len(backups) == 0 || !strings.HasPrefix(backups[0], today) {
+		path, err := newBackupPath(autoBackupPrefix)
+		if err != nil {
+			return err
+		}
+		if err := backupTo(path); err != nil {
+			return err
+		}
+		backups = append([]string{filepath.Base(path)}, backups...)
+	}
+
+	for _, name := range backups[min(keep, len(backups)):] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("failed to remove old backup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Restore replaces the open database with the backup at src and reopens it.
+// The backup must be a tltui database whose schema is not newer than this build;
+// older ones are migrated when reopened. The replaced database is first backed up
+// to the backup directory, and the path of that safety backup is returned.
+func Restore(src string) (string, error) {
+	if readOnly {
+		return "", fmt.Errorf("cannot restore in read-only mode")
+	}
+	if err := CheckBackup(src); err != nil {
+		return "", err
+	}
+
+	safety, err := newBackupPath("pre-restore-")
+	if err != nil {
+		return "", err
+	}
+	if err := backupTo(safety); err != nil {
+		return "", err
+	}
+
+	if err := CloseDB(); err != nil {
+		return "", fmt.Errorf("failed to close database: %w", err)
+	}
+	path := dbPath
+	if err := copyFile(src, path); err != nil {
+		return "", fmt.Errorf("failed to restore database, the previous data is in %s: %w", safety, err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to remove %s: %w", path+suffix, err)
+		}
+	}
+
+	if err := openDB(path); err != nil {
+		return "", fmt.Errorf("failed to open restored database, the previous data is in %s: %w", safety, err)
+	}
+	return safety, nil
+}
+
+// CheckBackup verifies that the file at path is an intact tltui database that
+// this build can open
+func CheckBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+
+	conn, err := sql.Open("sqlite", path+"?"+url.Values{"_pragma": {"query_only(1)"}}.Encode())
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer conn.Close()
+
+	var result string
+	if err := conn.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("%s is not a tltui database: %w", path, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("backup %s is damaged: %s", path, result)
+	}
+
+	var tables int
+	err = conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'workhours'").Scan(&tables)
+	if err != nil {
+		return fmt.Errorf("failed to inspect backup: %w", err)
+	}
+	if tables == 0 {
+		return fmt.Errorf("%s is not a tltui database", path)
+	}
+
+	version, err := schemaVersion(conn)
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion {
+		return fmt.Errorf("backup schema version %d is newer than the supported version %d, update tltui", version, SchemaVersion)
+	}
+
+	return nil
+}
+
+func backupTo(dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	if _, err := db.Exec("VACUUM INTO ?", dest); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
+
+// newBackupPath returns a timestamped file in the backup directory
+func newBackupPath(prefix string) (string, error) {
+	dir, err := BackupDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, prefix+time.Now().Format(backupTimeLayout)+".db"), nil
+}
+
+// autoBackups returns the names of the automatic backups in dir, newest first
+func autoBackups(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, autoBackupPrefix) && strings.HasSuffix(name, ".db") {
+			names = append(names, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	return names, nil
+}
+
+// copyFile copies src over dest through a temporary file, so dest is never left half written
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), filepath.Base(dest)+".restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckBackup_RejectsNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.db")
+	if err := InitDBAt(path); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	CloseDB()
+
+	if err := CheckBackup(path); err != nil {
+		t.Fatalf("expected a current database to pass, got %v", err)
+	}
+
+	conn, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if _, err := conn.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion+1)); err != nil {
+		t.Fatalf("failed to set schema version: %v", err)
+	}
+	conn.Close()
+
+	err = CheckBackup(path)
+	if err == nil || !strings.Contains(err.Error(), "newer than the supported version") {
+		t.Errorf("expected a newer schema to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"time"
+	"tltui/src/domain"
+)
+
+// GetProjectBudgetStatus returns the budget consumption of a project in the period
+// containing date, or nil when the project has no budget
+func GetProjectBudgetStatus(project domain.Project, date time.Time) (*domain.BudgetStatus, error) {
+	if !project.HasBudget() {
+		return nil, nil
+	}
+
+	workhours, err := getProjectWorkhours(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.CalculateBudgetStatus(project, workhours, date)
+	return &status, nil
+}
+
+// budgetWarnings must be called after a write that replaced the workhours before
+// with after, e.g. an edit with the stored and the updated entry. It returns a
+// warning for each project budget the write made cross a warning threshold.
+// Hours moved within a budget period leave its consumption unchanged and never warn.
+func budgetWarnings(before, after []domain.Workhour) ([]domain.BudgetWarning, error) {
+	type periodKey struct {
+		projectID int
+		start     time.Time
+	}
+	var keys []periodKey
+	deltas := make(map[periodKey]float64)
+	dates := make(map[periodKey]time.Time)
+	projects := make(map[int]*domain.Project)
+
+	add := func(wh domain.Workhour, sign float64) error {
+		project, ok := projects[wh.ProjectID]
+		if !ok {
+			var err error
+			if project, err = GetProjectByID(wh.ProjectID); err != nil {
+				return err
+			}
+			projects[wh.ProjectID] = project
+		}
+		if project == nil || !project.HasBudget() {
+			return nil
+		}
+
+		start, _ := domain.BudgetPeriodRange(project.BudgetPeriod, wh.Date)
+		key := periodKey{projectID: project.ID, start: start}
+		if _, ok := dates[key]; !ok {
+			keys = append(keys, key)
+			dates[key] = wh.Date
+		}
+		deltas[key] += sign * wh.Hours
+		return nil
+	}
+	for _, wh := range after {
+		if err := add(wh, 1); err != nil {
+			return nil, err
+		}
+	}
+	for _, wh := range before {
+		if err := add(wh, -1); err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []domain.BudgetWarning
+	for _, key := range keys {
+		warning, err := checkBudgetCrossing(*projects[key.projectID], dates[key], deltas[key])
+		if err != nil {
+			return nil, err
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings, nil
+}
+
+// checkBudgetCrossing returns a warning when adding deltaHours on date made the
+// project budget cross one of the warning thresholds, or nil otherwise
+func checkBudgetCrossing(project domain.Project, date time.Time, deltaHours float64) (*domain.BudgetWarning, error) {
+	if deltaHours <= 0 {
+		return nil, nil
+	}
+
+	status, err := GetProjectBudgetStatus(project, date)
+	if err != nil || status == nil {
+		return nil, err
+	}
+
+	before := status.ConsumedHours - deltaHours
+	threshold := domain.CrossedBudgetThreshold(project.BudgetHours, before, status.ConsumedHours)
+	if threshold == 0 {
+		return nil, nil
+	}
+
+	return &domain.BudgetWarning{Status: *status, Threshold: threshold}, nil
+}
+
+// GetBudgetBurnDown returns the daily burn-down of the budget period containing date.
+// Total budgets run from the first logged entry to the last, or to date if later.
+func GetBudgetBurnDown(project domain.Project, date time.Time) ([]domain.BurnDownPoint, error) {
+	workhours, err := getProjectWorkhours(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := domain.BudgetPeriodRange(project.BudgetPeriod, date)
+	if project.BudgetPeriod == domain.BudgetPeriodTotal {
+		start, end = date, date
+		for _, wh := range workhours {
+			if wh.Date.Before(start) {
+				start = wh.Date
+			}
+			if wh.Date.After(end) {
+				end = wh.Date
+			}
+		}
+	}
+
+	return domain.CalculateBurnDown(project, workhours, start, end), nil
+}
+
+func getProjectWorkhours(projectID int) ([]domain.Workhour, error) {
+	return getWorkhoursBy("project_id", projectID)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"tltui/src/domain"
+)
+
+const clientColumns = "id, name, legal_name, tax_id, address, language, billing_contact, report_recipients"
+
+func GetAllClients() ([]domain.Client, error) {
+	rows, err := db.Query("SELECT " + clientColumns + " FROM clients ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clients: %w", err)
+	}
+	defer rows.Close()
+
+	var clients []domain.Client
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+func GetClientByID(id int) (*domain.Client, error) {
+	client, err := scanClient(db.QueryRow("SELECT "+clientColumns+" FROM clients WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+func CreateClient(client domain.Client) (int, error) {
+	result, err := db.Exec(
+		"INSERT INTO clients (name, legal_name, tax_id, address, language, billing_contact, report_recipients) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		client.Name, client.LegalName, client.TaxID, client.Address, client.Language, client.BillingContact,
+		strings.Join(client.ReportRecipients, ", "),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return int(id), nil
+}
+
+func UpdateClient(client domain.Client) error {
+	result, err := db.Exec(
+		"UPDATE clients SET name = ?, legal_name = ?, tax_id = ?, address = ?, language = ?, billing_contact = ?, report_recipients = ? WHERE id = ?",
+		client.Name, client.LegalName, client.TaxID, client.Address, client.Language, client.BillingContact,
+		strings.Join(client.ReportRecipients, ", "), client.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("client not found")
+	}
+
+	return nil
+}
+
+func DeleteClient(id int) error {
+	result, err := db.Exec("DELETE FROM clients WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("client not found")
+	}
+
+	return nil
+}
+
+func scanClient(row rowScanner) (domain.Client, error) {
+	var c domain.Client
+	var recipients string
+	err := row.Scan(&c.ID, &c.Name, &c.LegalName, &c.TaxID, &c.Address, &c.Language, &c.BillingContact, &recipients)
+	if err == sql.ErrNoRows {
+		return c, err
+	}
+	if err != nil {
+		return c, fmt.Errorf("failed to scan client: %w", err)
+	}
+	// Stored lists were validated when saved
+	c.ReportRecipients, _ = domain.ParseEmailList(recipients)
+	return c, nil
+}
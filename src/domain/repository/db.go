@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -13,38 +14,135 @@ import (
 
 var db *sql.DB
 
-func InitDB() error {
+// SchemaVersion is stored in the user_version of databases created or migrated
+// by this build. Older databases are migrated when opened, newer ones are refused
+// when restoring or loading.
+const SchemaVersion = 4
+
+var (
+	dbPath   string // File of the open database
+	readOnly bool   // Open databases in query-only mode
+)
+
+// DataDir returns the directory tltui keeps its databases in
+func DataDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	var dbDir string
-
 	if runtime.GOOS == "darwin" {
-		dbDir = filepath.Join(homeDir, "Library", "Application Support", "tltui")
-	} else {
-		configDir := os.Getenv("XDG_CONFIG_HOME")
-		if configDir == "" {
-			configDir = filepath.Join(homeDir, ".config")
+		return filepath.Join(homeDir, "Library", "Application Support", "tltui"), nil
+	}
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "tltui"), nil
+}
+
+// InitDB opens the database of a profile, creating it when needed.
+// An empty profile name opens the default profile.
+func InitDB(profile string) error {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	dbDir, err := ProfileDir(profile)
+	if err != nil {
+		return err
+	}
+
+	if !readOnly {
+		if err := os.MkdirAll(dbDir, 0755); err != nil {
+			return fmt.Errorf("failed to create database directory: %w", err)
 		}
-		dbDir = filepath.Join(configDir, "tltui")
 	}
 
-	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		return fmt.Errorf("failed to create database directory: %w", err)
+	if err := openDB(filepath.Join(dbDir, "data.db")); err != nil {
+		return err
+	}
+	currentProfile = profile
+
+	return nil
+}
+
+// InitDBAt opens the database file at path instead of a profile's, creating it
+// when needed unless read-only
+func InitDBAt(path string) error {
+	if err := openDB(path); err != nil {
+		return err
 	}
+	currentProfile = ""
+
+	return nil
+}
+
+// SetReadOnly makes databases opened afterwards query-only. Every change is then
+// rejected by SQLite, and the database file must already exist.
+func SetReadOnly(enabled bool) {
+	readOnly = enabled
+}
+
+// IsReadOnly reports whether the open database is query-only
+func IsReadOnly() bool {
+	return readOnly
+}
+
+// DBPath returns the file of the open database
+func DBPath() string {
+	return dbPath
+}
+
+// busyTimeout is how long a connection waits for another process holding a lock,
+// e.g. the TUI and 'tltui serve' sharing a database
+const busyTimeout = 5 * time.Second
 
-	dbPath := filepath.Join(dbDir, "data.db")
-	db, err = sql.Open("sqlite", dbPath)
+func openDB(path string) error {
+	// Pragmas in the DSN apply to every connection of the pool
+	pragmas := []string{"foreign_keys(1)", fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds())}
+	if readOnly {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		pragmas = append(pragmas, "query_only(1)")
+	} else {
+		// WAL lets readers work while another process writes
+		pragmas = append(pragmas, "journal_mode(WAL)")
+	}
+
+	opened, err := sql.Open("sqlite", path+"?"+url.Values{"_pragma": pragmas}.Encode())
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
-		return fmt.Errorf("failed to enable foreign keys: %w", err)
+	// A single connection serializes the app's own writes, and lets PRAGMA data_version
+	// tell the commits of other processes apart from them, see DataVersion
+	opened.SetMaxOpenConns(1)
+
+	if err := opened.Ping(); err != nil {
+		opened.Close()
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	version, err := schemaVersion(opened)
+	if err != nil {
+		opened.Close()
+		return err
+	}
+	if version > SchemaVersion {
+		opened.Close()
+		return fmt.Errorf("database schema version %d is newer than the supported version %d, update tltui", version, SchemaVersion)
 	}
 
+	db = opened
+	dbPath = path
+
+	// A read-only database is used with the schema it has
+	if readOnly {
+		return nil
+	}
 	if err := createSchema(); err != nil {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
@@ -52,6 +150,33 @@ func InitDB() error {
 	return nil
 }
 
+// schemaVersion returns the schema version stored in a database
+func schemaVersion(conn *sql.DB) (int, error) {
+	var version int
+	if err := conn.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// DataVersion returns a number that changes whenever another process, such as a
+// second TUI or "tltui serve", commits to the database. Changes made by this
+// process leave it unchanged.
+func DataVersion() (int64, error) {
+	return DataVersionOf(db)
+}
+
+// DataVersionOf reads the DataVersion through a handle taken with GetDB. Work running
+// on another goroutine than the one opening databases takes the handle beforehand,
+// as SwitchProfile and Restore replace the open database meanwhile.
+func DataVersionOf(conn *sql.DB) (int64, error) {
+	var version int64
+	if err := conn.QueryRow("PRAGMA data_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read data version: %w", err)
+	}
+	return version, nil
+}
+
 func CloseDB() error {
 	if db != nil {
 		return db.Close()
@@ -65,17 +190,42 @@ func GetDB() *sql.DB {
 
 func createSchema() error {
 	schema := `
+	CREATE TABLE IF NOT EXISTS clients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		legal_name TEXT NOT NULL DEFAULT '',
+		tax_id TEXT NOT NULL DEFAULT '',
+		address TEXT NOT NULL DEFAULT '',
+		language TEXT NOT NULL DEFAULT 'ro',
+		billing_contact TEXT NOT NULL DEFAULT '',
+		report_recipients TEXT NOT NULL DEFAULT ''
+	);
+
 	CREATE TABLE IF NOT EXISTS projects (
 		id INTEGER PRIMARY KEY,
 		odoo_id TEXT NOT NULL,
-		name TEXT NOT NULL
+		name TEXT NOT NULL,
+		client_id INTEGER REFERENCES clients(id) ON DELETE SET NULL,
+		budget_hours REAL NOT NULL DEFAULT 0,
+		budget_period INTEGER NOT NULL DEFAULT 0
 	);
 
+	CREATE TABLE IF NOT EXISTS tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		project_id INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		odoo_id INTEGER NOT NULL DEFAULT 0,
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_tasks_project ON tasks(project_id);
+
 	CREATE TABLE IF NOT EXISTS workhour_details (
 		id INTEGER PRIMARY KEY,
 		name TEXT NOT NULL,
 		short_name TEXT NOT NULL,
-		is_work INTEGER NOT NULL DEFAULT 1
+		is_work INTEGER NOT NULL DEFAULT 1,
+		overtime_role INTEGER NOT NULL DEFAULT 0
 	);
 
 	CREATE TABLE IF NOT EXISTS workhours (
@@ -83,18 +233,197 @@ func createSchema() error {
 		date TEXT NOT NULL,
 		details_id INTEGER NOT NULL,
 		project_id INTEGER NOT NULL,
+		task_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
 		hours REAL NOT NULL,
+		start_time INTEGER,
+		end_time INTEGER,
+		break_minutes INTEGER NOT NULL DEFAULT 0,
+		uuid TEXT,
+		issue_key TEXT,
 		FOREIGN KEY (details_id) REFERENCES workhour_details(id) ON DELETE CASCADE,
 		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_workhours_date ON workhours(date);
+
+	CREATE TABLE IF NOT EXISTS settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS recent_selections (
+		kind TEXT NOT NULL,
+		item_id INTEGER NOT NULL,
+		used_at INTEGER NOT NULL,
+		use_count INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (kind, item_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS month_locks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		year INTEGER NOT NULL,
+		month INTEGER NOT NULL,
+		locked_at INTEGER NOT NULL,
+		locked_by TEXT NOT NULL,
+		unlocked_at INTEGER,
+		unlocked_by TEXT,
+		unlock_reason TEXT
+	);
+
+	CREATE TABLE IF NOT EXISTS workhour_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		workhour_id INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		old_date TEXT,
+		new_date TEXT,
+		old_value TEXT,
+		new_value TEXT,
+		changed_at INTEGER NOT NULL,
+		source TEXT NOT NULL,
+		workhour_uuid TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_workhour_history_old_date ON workhour_history(old_date);
+	CREATE INDEX IF NOT EXISTS idx_workhour_history_new_date ON workhour_history(new_date);
+
+	CREATE TABLE IF NOT EXISTS report_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL,
+		year INTEGER NOT NULL,
+		month INTEGER NOT NULL,
+		params TEXT NOT NULL,
+		path TEXT NOT NULL,
+		content_hash TEXT NOT NULL,
+		data_hash TEXT NOT NULL,
+		generated_at INTEGER NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS leave_entitlements (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		year INTEGER NOT NULL,
+		details_id INTEGER NOT NULL,
+		allowance_days REAL NOT NULL DEFAULT 0,
+		carry_over_days REAL NOT NULL DEFAULT 0,
+		carry_over_expiry TEXT,
+		UNIQUE (year, details_id),
+		FOREIGN KEY (details_id) REFERENCES workhour_details(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS sync_positions (
+		file TEXT PRIMARY KEY,
+		applied_lines INTEGER NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS sync_conflicts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		workhour_uuid TEXT NOT NULL,
+		local_value TEXT,
+		remote_value TEXT,
+		remote_replica TEXT NOT NULL,
+		kept TEXT NOT NULL,
+		detected_at INTEGER NOT NULL,
+		resolved_at INTEGER
+	);
+
+	CREATE TABLE IF NOT EXISTS git_repos (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		path TEXT NOT NULL UNIQUE,
+		project_id INTEGER NOT NULL,
+		details_id INTEGER REFERENCES workhour_details(id) ON DELETE SET NULL,
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS workhour_exports (
+		workhour_id INTEGER NOT NULL,
+		sink TEXT NOT NULL,
+		remote_id TEXT NOT NULL,
+		issue_key TEXT NOT NULL,
+		date TEXT NOT NULL,
+		hours REAL NOT NULL,
+		pushed_at INTEGER NOT NULL,
+		PRIMARY KEY (workhour_id, sink)
+	);
+
+	CREATE TABLE IF NOT EXISTS sent_emails (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		report_id INTEGER REFERENCES report_history(id) ON DELETE SET NULL,
+		recipients TEXT NOT NULL,
+		subject TEXT NOT NULL,
+		attachments TEXT NOT NULL,
+		sent_at INTEGER NOT NULL
+	);
 	`
 
-	_, err := db.Exec(schema)
+	if _, err := db.Exec(schema); err != nil {
+		return err
+	}
+
+	if err := migrateSchema(); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion))
 	return err
 }
 
+// migrateSchema brings databases created by older versions up to date
+func migrateSchema() error {
+	if err := addColumnIfMissing("workhour_details", "overtime_role", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhours", "task_id", "INTEGER REFERENCES tasks(id) ON DELETE SET NULL"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("projects", "client_id", "INTEGER REFERENCES clients(id) ON DELETE SET NULL"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("projects", "budget_hours", "REAL NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("projects", "budget_period", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhours", "start_time", "INTEGER"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhours", "end_time", "INTEGER"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhours", "break_minutes", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhours", "uuid", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhour_history", "workhour_uuid", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("workhours", "issue_key", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("clients", "report_recipients", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return backfillWorkhourUUIDs()
+}
+
+func addColumnIfMissing(table, column, definition string) error {
+	columns, err := tableColumns(db, table)
+	if err != nil {
+		return err
+	}
+	if containsString(columns, column) {
+		return nil
+	}
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+
+	return nil
+}
+
 func DateToString(t time.Time) string {
 	return t.Format("2006-01-02")
 }
@@ -0,0 +1,410 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"tltui/src/domain"
+)
+
+const (
+	dumpFormat  = "tltui-dump"
+	dumpVersion = 1
+)
+
+// dumpTables lists the tables in a dump, parents before the tables referencing them.
+// Sync state and git repository paths belong to one machine and are left out, as
+// are the sync settings, see localSettingPrefix.
+var dumpTables = []string{
+	"clients",
+	"projects",
+	"tasks",
+	"workhour_details",
+	"workhours",
+	"workhour_exports",
+	"leave_entitlements",
+	"settings",
+	"recent_selections",
+	"month_locks",
+	"workhour_history",
+	"report_history",
+	"sent_emails",
+}
+
+// localSettingPrefix starts the keys of the settings that belong to one machine, such
+// as the sync replica ID. They are not dumped, and a load keeps those of the database
+// it replaces, so that a second machine does not take over the replica of the first.
+const localSettingPrefix = "sync_"
+
+// dumpFile is the portable JSON form of a database. Rows keep their IDs and
+// their values in the order of the table's columns.
+type dumpFile struct {
+	Format        string      `json:"format"`
+	Version       int         `json:"version"`
+	SchemaVersion int         `json:"schema_version"`
+	Tables        []dumpTable `json:"tables"`
+}
+
+type dumpTable struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// DumpJSON writes all data of the open database as JSON. Loading the dump with
+// LoadJSON reproduces the same data, and loading it over the same database changes
+// nothing, so dumping again gives identical output.
+func DumpJSON(w io.Writer) error {
+	dump := dumpFile{Format: dumpFormat, Version: dumpVersion, SchemaVersion: SchemaVersion}
+
+	for _, table := range dumpTables {
+		columns, err := tableColumns(db, table)
+		if err != nil {
+			return err
+		}
+		rows, err := dumpRows(table, columns)
+		if err != nil {
+			return err
+		}
+		dump.Tables = append(dump.Tables, dumpTable{Name: table, Columns: columns, Rows: rows})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(dump); err != nil {
+		return fmt.Errorf("failed to write dump: %w", err)
+	}
+	return nil
+}
+
+// LoadJSON replaces the data of the open database with a dump written by DumpJSON.
+// A database that already has logged hours is only replaced when replace is set,
+// and hours of its locked months are only changed when allowLocked is set too.
+// The dump is loaded in one transaction, so a failing load changes nothing. The
+// history is the one of the dump, followed by the changes of the load as imports.
+// The returned warnings name the project budgets the loaded hours made cross a
+// threshold and the rules the loaded days break.
+func LoadJSON(r io.Reader, replace, allowLocked bool) ([]string, error) {
+	var dump dumpFile
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&dump); err != nil {
+		return nil, fmt.Errorf("failed to read dump: %w", err)
+	}
+	if dump.Format != dumpFormat {
+		return nil, fmt.Errorf("not a tltui dump")
+	}
+	if dump.Version != dumpVersion {
+		return nil, fmt.Errorf("unsupported dump version %d", dump.Version)
+	}
+	if dump.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("dump schema version %d is newer than the supported version %d, update tltui", dump.SchemaVersion, SchemaVersion)
+	}
+
+	before, err := GetAllWorkhours()
+	if err != nil {
+		return nil, err
+	}
+	locks, err := getLockedMonths()
+	if err != nil {
+		return nil, err
+	}
+
+	err = withTx(func(tx *sql.Tx) error {
+		if !replace {
+			var count int
+			if err := tx.QueryRow("SELECT COUNT(*) FROM workhours").Scan(&count); err != nil {
+				return fmt.Errorf("failed to count workhours: %w", err)
+			}
+			if count > 0 {
+				return fmt.Errorf("the database already has %d logged entries, load with replace to overwrite them", count)
+			}
+		}
+
+		for i := len(dumpTables) - 1; i >= 0; i-- {
+			query, args := "DELETE FROM "+dumpTables[i], []any(nil)
+			if dumpTables[i] == "settings" {
+				query, args = query+" WHERE substr(key, 1, ?) != ?", []any{len(localSettingPrefix), localSettingPrefix}
+			}
+			if _, err := tx.Exec(query, args...); err != nil {
+				return fmt.Errorf("failed to clear %s: %w", dumpTables[i], err)
+			}
+		}
+
+		byName := make(map[string]dumpTable, len(dump.Tables))
+		for _, table := range dump.Tables {
+			byName[table.Name] = table
+		}
+		for name := range byName {
+			if !isDumpTable(name) {
+				return fmt.Errorf("unknown table %q in dump", name)
+			}
+		}
+
+		for _, name := range dumpTables {
+			if table, ok := byName[name]; ok {
+				if err := loadTable(tx, table); err != nil {
+					return err
+				}
+			}
+		}
+
+		loaded, err := getAllWorkhours(tx)
+		if err != nil {
+			return err
+		}
+		if changed := changedLockedMonths(locks, before, loaded); len(changed) > 0 && !allowLocked {
+			return fmt.Errorf("%w: the dump changes the hours of %s, load with -allow-locked to overwrite them",
+				domain.ErrMonthLocked, strings.Join(changed, ", "))
+		}
+		return recordImport(tx, before, loaded)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Dumps of older versions have workhours without UUIDs
+	if err := backfillWorkhourUUIDs(); err != nil {
+		return nil, err
+	}
+
+	after, err := GetAllWorkhours()
+	if err != nil {
+		return nil, err
+	}
+	budget, err := budgetWarnings(before, after)
+	if err != nil {
+		return nil, err
+	}
+	violations, err := evaluateDays(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, warning := range budget {
+		warnings = append(warnings, warning.Message())
+	}
+	for _, violation := range violations {
+		warnings = append(warnings, violation.Message)
+	}
+	return warnings, nil
+}
+
+// recordImport appends the changes a load made to the workhours to the loaded history,
+// with the import source. A replica sharing its changes through sync shares these, but
+// not the history of the dumped database.
+func recordImport(tx *sql.Tx, before, after []domain.Workhour) error {
+	if _, err := tx.Exec(
+		"UPDATE settings SET value = (SELECT COALESCE(MAX(id), 0) FROM workhour_history) WHERE key = ?",
+		settingSyncExportedID,
+	); err != nil {
+		return fmt.Errorf("failed to reset sync state: %w", err)
+	}
+
+	// Workhours of dumps written before they had UUIDs are told apart by their ID
+	key := func(wh domain.Workhour) string {
+		if wh.UUID != "" {
+			return wh.UUID
+		}
+		return fmt.Sprintf("id %d", wh.ID)
+	}
+	previous := make(map[string]domain.Workhour, len(before))
+	for _, wh := range before {
+		previous[key(wh)] = wh
+	}
+
+	now := time.Now()
+	kept := make(map[string]bool, len(after))
+	for _, wh := range after {
+		kept[key(wh)] = true
+		old, ok := previous[key(wh)]
+		var err error
+		switch {
+		case !ok:
+			err = recordWorkhourChangeAt(tx, wh.ID, domain.ChangeCreate, nil, &wh, domain.ChangeSourceImport, now)
+		case !sameSnapshot(snapshotOf(&old), snapshotOf(&wh)):
+			err = recordWorkhourChangeAt(tx, wh.ID, domain.ChangeUpdate, &old, &wh, domain.ChangeSourceImport, now)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, wh := range before {
+		if kept[key(wh)] {
+			continue
+		}
+		if err := recordWorkhourChangeAt(tx, wh.ID, domain.ChangeDelete, &wh, nil, domain.ChangeSourceImport, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changedLockedMonths returns the labels of the locked months whose hours differ
+// between the workhours before and after
+func changedLockedMonths(locks []domain.MonthLock, before, after []domain.Workhour) []string {
+	monthHours := func(workhours []domain.Workhour, lock domain.MonthLock) []string {
+		var entries []string
+		for _, wh := range workhours {
+			if wh.Date.Year() == lock.Year && wh.Date.Month() == lock.Month {
+				entries = append(entries, fmt.Sprintf("%+v", *snapshotOf(&wh)))
+			}
+		}
+		sort.Strings(entries)
+		return entries
+	}
+
+	var changed []string
+	for _, lock := range locks {
+		if !slices.Equal(monthHours(before, lock), monthHours(after, lock)) {
+			changed = append(changed, lock.Label())
+		}
+	}
+	return changed
+}
+
+func dumpRows(table string, columns []string) ([][]any, error) {
+	rows, err := db.Query(fmt.Sprintf("SELECT %s FROM %s ORDER BY rowid", strings.Join(columns, ", "), table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	result := [][]any{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		for i, value := range values {
+			if bytes, ok := value.([]byte); ok {
+				values[i] = string(bytes)
+			}
+		}
+		if isLocalSetting(table, columns, values) {
+			continue
+		}
+		result = append(result, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s: %w", table, err)
+	}
+
+	return result, nil
+}
+
+func loadTable(tx *sql.Tx, table dumpTable) error {
+	known, err := tableColumns(tx, table.Name)
+	if err != nil {
+		return err
+	}
+	for _, column := range table.Columns {
+		if !containsString(known, column) {
+			return fmt.Errorf("unknown column %s.%s in dump", table.Name, column)
+		}
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(table.Columns)), ", ")
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, strings.Join(table.Columns, ", "), placeholders)
+
+	for i, row := range table.Rows {
+		if len(row) != len(table.Columns) {
+			return fmt.Errorf("row %d of %s has %d values, expected %d", i+1, table.Name, len(row), len(table.Columns))
+		}
+		// Dumps of older versions carry the sync settings of the dumped machine
+		if isLocalSetting(table.Name, table.Columns, row) {
+			continue
+		}
+		values := make([]any, len(row))
+		for j, value := range row {
+			values[j] = dumpValue(value)
+		}
+		if _, err := tx.Exec(query, values...); err != nil {
+			return fmt.Errorf("failed to load row %d of %s: %w", i+1, table.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// isLocalSetting reports whether a row of table is a setting of one machine, see localSettingPrefix
+func isLocalSetting(table string, columns []string, row []any) bool {
+	if table != "settings" {
+		return false
+	}
+	i := slices.Index(columns, "key")
+	if i < 0 {
+		return false
+	}
+	key, _ := row[i].(string)
+	return strings.HasPrefix(key, localSettingPrefix)
+}
+
+// dumpValue turns a decoded JSON number back into the integer or real it was dumped from
+func dumpValue(value any) any {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if integer, err := strconv.ParseInt(string(number), 10, 64); err == nil {
+		return integer
+	}
+	if real, err := number.Float64(); err == nil {
+		return real
+	}
+	return string(number)
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// tableColumns returns the columns of a table in their declared order
+func tableColumns(q querier, table string) ([]string, error) {
+	rows, err := q.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating columns of %s: %w", table, err)
+	}
+
+	return columns, nil
+}
+
+func isDumpTable(name string) bool {
+	return containsString(dumpTables, name)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/domain"
+)
+
+func TestDumpJSON_LoadRoundTrip(t *testing.T) {
+	cleanup := SetupTest(t)
+	CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	CreateTestWorkhourDetails(t, 2, "Vacation", "CO", false)
+	client := CreateTestClient(t, "Arnia", "Arnia SRL")
+	project := CreateTestProject(t, 7, "Arnia API", 100)
+	project.ClientID = client.ID
+	project.BudgetHours = 120.5
+	if err := UpdateProject(project); err != nil {
+		t.Fatalf("failed to update project: %v", err)
+	}
+	task := CreateTestTask(t, project.ID, "Sprint \"12\"", 44)
+	CreateTestLeaveEntitlement(t, 2025, 2, 21, 2.5, nil)
+	SetSetting(SettingHoursRounding, "0.25")
+
+	date := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local)
+	timed := domain.Workhour{
+		Date: date, DetailsID: 1, ProjectID: project.ID, TaskID: task.ID, IssueKey: "API-12",
+		Start: domain.NewClockTime(9, 0), End: domain.NewClockTime(17, 30), BreakMinutes: 30,
+	}
+	if _, _, err := CreateWorkhour(timed); err != nil {
+		t.Fatalf("failed to create workhour: %v", err)
+	}
+	CreateTestWorkhour(t, date.AddDate(0, 0, 1), 2, project.ID, 1.1)
+	LockMonth(2025, time.February, "tester")
+
+	var dump bytes.Buffer
+	if err := DumpJSON(&dump); err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	cleanup()
+
+	defer SetupTest(t)()
+	if _, err := LoadJSON(bytes.NewReader(dump.Bytes()), false, false); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	// The load is recorded on top of the loaded history
+	changes, err := GetWorkhourHistory(date, date.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	var imported int
+	for _, change := range changes {
+		if change.Source == domain.ChangeSourceImport && change.Action == domain.ChangeCreate {
+			imported++
+		}
+	}
+	if len(changes) != 4 || imported != 2 {
+		t.Errorf("expected the 2 loaded creations and 2 imported ones, got %+v", changes)
+	}
+
+	// Loading over the same data changes nothing, so the dump round-trips
+	dump.Reset()
+	if err := DumpJSON(&dump); err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	if _, err := LoadJSON(bytes.NewReader(dump.Bytes()), true, false); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	var again bytes.Buffer
+	if err := DumpJSON(&again); err != nil {
+		t.Fatalf("second dump failed: %v", err)
+	}
+
+	if !bytes.Equal(dump.Bytes(), again.Bytes()) {
+		t.Errorf("expected the dump to round-trip byte for byte\nfirst:  %s\nsecond: %s", dump.String(), again.String())
+	}
+}
+
+func TestLoadJSON_KeepsSyncSettings(t *testing.T) {
+	cleanup := SetupTest(t)
+	SetSetting(SettingSyncReplicaID, "laptop")
+	SetSetting(SettingHoursRounding, "0.25")
+	var dump bytes.Buffer
+	if err := DumpJSON(&dump); err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	cleanup()
+	if bytes.Contains(dump.Bytes(), []byte("laptop")) {
+		t.Errorf("expected the replica ID to be left out of the dump, got %s", dump.String())
+	}
+
+	defer SetupTest(t)()
+	SetSetting(SettingSyncReplicaID, "desktop")
+	// Dumps of older versions carry the sync settings of the dumped machine
+	older := `{"format": "tltui-dump", "version": 1, "schema_version": 4, "tables": [
+		{"name": "settings", "columns": ["key", "value"], "rows": [["sync_replica_id", "laptop"], ["hours_rounding", "0.25"]]}
+	]}`
+	for _, data := range []string{dump.String(), older} {
+		if _, err := LoadJSON(strings.NewReader(data), true, false); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		if replica, _, _ := GetSetting(SettingSyncReplicaID); replica != "desktop" {
+			t.Errorf("expected the replica ID to be kept, got %q", replica)
+		}
+		if rounding, _, _ := GetSetting(SettingHoursRounding); rounding != "0.25" {
+			t.Errorf("expected the other settings to be loaded, got rounding %q", rounding)
+		}
+	}
+}
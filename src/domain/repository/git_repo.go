@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"tltui/src/domain"
+)
+
+// SettingGitAuthorEmail is the author email whose commits suggest workhours. When
+// unset, the user.email of each repository's git configuration is used.
+const SettingGitAuthorEmail = "git_author_email"
+
+func GetGitRepos() ([]domain.GitRepo, error) {
+	rows, err := db.Query("SELECT id, path, project_id, details_id FROM git_repos ORDER BY path")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query git repositories: %w", err)
+	}
+	defer rows.Close()
+
+	var repos []domain.GitRepo
+	for rows.Next() {
+		var repo domain.GitRepo
+		var detailsID sql.NullInt64
+		if err := rows.Scan(&repo.ID, &repo.Path, &repo.ProjectID, &detailsID); err != nil {
+			return nil, fmt.Errorf("failed to scan git repository: %w", err)
+		}
+		repo.DetailsID = int(detailsID.Int64)
+		repos = append(repos, repo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating git repositories: %w", err)
+	}
+
+	return repos, nil
+}
+
+// SaveGitRepo maps a repository to a project, replacing the mapping of the same path
+func SaveGitRepo(repo domain.GitRepo) (int, error) {
+	var id int
+	err := db.QueryRow(
+		`INSERT INTO git_repos (path, project_id, details_id) VALUES (?, ?, ?)
+		ON CONFLICT(path) DO UPDATE SET project_id = excluded.project_id, details_id = excluded.details_id
+		RETURNING id`,
+		repo.Path, repo.ProjectID, nullableID(repo.DetailsID),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save git repository: %w", err)
+	}
+	return id, nil
+}
+
+func DeleteGitRepo(id int) error {
+	result, err := db.Exec("DELETE FROM git_repos WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete git repository: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("git repository %d does not exist", id)
+	}
+	return nil
+}
+
+// GetGitAuthorEmail returns the configured author email, "" when unset
+func GetGitAuthorEmail() string {
+	value, _, err := GetSetting(SettingGitAuthorEmail)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// ResolveGitRepoPath returns the absolute top-level directory of the git
+// repository containing path
+func ResolveGitRepoPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	output, err := gitOutput(abs, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("%s is not a git repository: %w", path, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// GetGitCommits returns the commits authored in a repository on date by email,
+// across all branches. Merge commits are left out.
+func GetGitCommits(repoPath, email string, date time.Time) ([]domain.GitCommit, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+
+	// --since filters on the commit date, which is never before the author date, so
+	// commits rebased later are still found; the author date is checked below
+	output, err := gitOutput(repoPath, "log", "--all", "--no-merges",
+		"--since="+day.Format(time.RFC3339), "--format=%H%x1f%ae%x1f%aI%x1f%s")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the git log of %s: %w", repoPath, err)
+	}
+
+	var commits []domain.GitCommit
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) != 4 || !strings.EqualFold(fields[1], email) {
+			continue
+		}
+		authored, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			continue
+		}
+		if local := authored.In(time.Local); local.Before(day) || !local.Before(day.AddDate(0, 0, 1)) {
+			continue
+		}
+		commits = append(commits, domain.GitCommit{Hash: fields[0], Time: authored, Subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// SuggestWorkhours proposes a workhour for every configured repository with
+// commits of the author on date. Repositories that cannot be read are skipped and
+// reported in the returned error, next to the suggestions of the others.
+func SuggestWorkhours(date time.Time) ([]domain.WorkhourSuggestion, error) {
+	repos, err := GetGitRepos()
+	if err != nil {
+		return nil, err
+	}
+	workhours, err := GetWorkhoursByDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []domain.WorkhourSuggestion
+	var errs []error
+	for _, repo := range repos {
+		email := GetGitAuthorEmail()
+		if email == "" {
+			configured, err := gitOutput(repo.Path, "config", "user.email")
+			if err != nil {
+				errs = append(errs, fmt.Errorf("no author email for %s, set %s: %w", repo.Path, SettingGitAuthorEmail, err))
+				continue
+			}
+			email = strings.TrimSpace(configured)
+		}
+
+		commits, err := GetGitCommits(repo.Path, email, date)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		suggestion := domain.SuggestWorkhour(repo, date, commits)
+		if suggestion == nil {
+			continue
+		}
+		for _, wh := range workhours {
+			if wh.ProjectID == repo.ProjectID {
+				suggestion.Logged = true
+			}
+		}
+		suggestions = append(suggestions, *suggestion)
+	}
+	return suggestions, errors.Join(errs...)
+}
+
+// gitOutput runs git in dir and returns its standard output
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return string(output), nil
+}
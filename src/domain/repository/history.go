@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+	"tltui/src/domain"
+)
+
+// changeSource is recorded with every change; the CLI and API switch it at startup
+var changeSource = domain.ChangeSourceTUI
+
+// SetChangeSource sets the source recorded with the changes that follow
+func SetChangeSource(source domain.ChangeSource) {
+	changeSource = source
+}
+
+// workhourSnapshot is the stored form of a workhour in the history
+type workhourSnapshot struct {
+	Date         string  `json:"date"`
+	DetailsID    int     `json:"details_id"`
+	ProjectID    int     `json:"project_id"`
+	TaskID       int     `json:"task_id,omitempty"`
+	Hours        float64 `json:"hours"`
+	Start        int     `json:"start,omitempty"`
+	End          int     `json:"end,omitempty"`
+	BreakMinutes int     `json:"break_minutes,omitempty"`
+	IssueKey     string  `json:"issue_key,omitempty"`
+}
+
+// recordWorkhourChange appends a change to the history inside the transaction of the change
+func recordWorkhourChange(exec execer, workhourID int, action domain.ChangeAction, old, new *domain.Workhour) error {
+	return recordWorkhourChangeAt(exec, workhourID, action, old, new, changeSource, time.Now())
+}
+
+// recordWorkhourChangeAt records a change made at another time or by another source,
+// such as one replayed from a sync replica
+func recordWorkhourChangeAt(exec execer, workhourID int, action domain.ChangeAction, old, new *domain.Workhour, source domain.ChangeSource, changedAt time.Time) error {
+	oldValue, err := encodeWorkhourSnapshot(old)
+	if err != nil {
+		return err
+	}
+	newValue, err := encodeWorkhourSnapshot(new)
+	if err != nil {
+		return err
+	}
+
+	var oldDate, newDate, workhourUUID any
+	if old != nil {
+		oldDate = DateToString(old.Date)
+		workhourUUID = nullableString(old.UUID)
+	}
+	if new != nil {
+		newDate = DateToString(new.Date)
+		workhourUUID = nullableString(new.UUID)
+	}
+
+	_, err = exec.Exec(
+		`INSERT INTO workhour_history (workhour_id, action, old_date, new_date, old_value, new_value, changed_at, source, workhour_uuid)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		workhourID, string(action), oldDate, newDate, oldValue, newValue, changedAt.UnixNano(), string(source), workhourUUID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record workhour history: %w", err)
+	}
+	return nil
+}
+
+// GetWorkhourHistory returns the changes touching days between start and end, oldest first
+func GetWorkhourHistory(start, end time.Time) ([]domain.WorkhourChange, error) {
+	startStr, endStr := DateToString(start), DateToString(end)
+	rows, err := db.Query(
+		`SELECT id, workhour_id, action, old_value, new_value, changed_at, source FROM workhour_history
+		WHERE old_date BETWEEN ? AND ? OR new_date BETWEEN ? AND ? ORDER BY id`,
+		startStr, endStr, startStr, endStr,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workhour history: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.WorkhourChange
+	for rows.Next() {
+		var change domain.WorkhourChange
+		var action, source string
+		var oldValue, newValue sql.NullString
+		var changedAt int64
+		if err := rows.Scan(&change.ID, &change.WorkhourID, &action, &oldValue, &newValue, &changedAt, &source); err != nil {
+			return nil, fmt.Errorf("failed to scan workhour history: %w", err)
+		}
+
+		change.Action = domain.ChangeAction(action)
+		change.Source = domain.ChangeSource(source)
+		change.ChangedAt = time.Unix(0, changedAt)
+		if change.Old, err = decodeWorkhourSnapshot(oldValue, change.WorkhourID); err != nil {
+			return nil, err
+		}
+		if change.New, err = decodeWorkhourSnapshot(newValue, change.WorkhourID); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workhour history: %w", err)
+	}
+
+	return changes, nil
+}
+
+func encodeWorkhourSnapshot(wh *domain.Workhour) (any, error) {
+	return encodeSnapshot(snapshotOf(wh))
+}
+
+func decodeWorkhourSnapshot(value sql.NullString, workhourID int) (*domain.Workhour, error) {
+	snapshot, err := decodeSnapshot(value)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return snapshot.workhour(workhourID, "")
+}
+
+// snapshotOf returns the stored form of a workhour, nil for nil
+func snapshotOf(wh *domain.Workhour) *workhourSnapshot {
+	if wh == nil {
+		return nil
+	}
+	return &workhourSnapshot{
+		Date:         DateToString(wh.Date),
+		DetailsID:    wh.DetailsID,
+		ProjectID:    wh.ProjectID,
+		TaskID:       wh.TaskID,
+		Hours:        wh.Hours,
+		Start:        int(wh.Start),
+		End:          int(wh.End),
+		BreakMinutes: wh.BreakMinutes,
+		IssueKey:     wh.IssueKey,
+	}
+}
+
+func (s workhourSnapshot) workhour(id int, uuid string) (*domain.Workhour, error) {
+	date, err := StringToDate(s.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse date: %w", err)
+	}
+
+	return &domain.Workhour{
+		ID:           id,
+		UUID:         uuid,
+		Date:         date,
+		DetailsID:    s.DetailsID,
+		ProjectID:    s.ProjectID,
+		TaskID:       s.TaskID,
+		Hours:        s.Hours,
+		Start:        domain.ClockTime(s.Start),
+		End:          domain.ClockTime(s.End),
+		BreakMinutes: s.BreakMinutes,
+		IssueKey:     s.IssueKey,
+	}, nil
+}
+
+func encodeSnapshot(snapshot *workhourSnapshot) (any, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workhour: %w", err)
+	}
+	return string(data), nil
+}
+
+func decodeSnapshot(value sql.NullString) (*workhourSnapshot, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	var snapshot workhourSnapshot
+	if err := json.Unmarshal([]byte(value.String), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode workhour history: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// sameSnapshot reports whether two stored workhours are equal, nil meaning absent
+func sameSnapshot(a, b *workhourSnapshot) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// withTx runs fn in a transaction, committing when it succeeds
+func withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
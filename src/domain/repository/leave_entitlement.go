@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"tltui/src/domain"
+)
+
+func GetAllLeaveEntitlements() ([]domain.LeaveEntitlement, error) {
+	rows, err := db.Query(
+		"SELECT id, year, details_id, allowance_days, carry_over_days, carry_over_expiry FROM leave_entitlements ORDER BY year DESC, details_id",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leave entitlements: %w", err)
+	}
+	defer rows.Close()
+
+	var entitlements []domain.LeaveEntitlement
+	for rows.Next() {
+		e, err := scanLeaveEntitlement(rows)
+		if err != nil {
+			return nil, err
+		}
+		entitlements = append(entitlements, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating leave entitlements: %w", err)
+	}
+
+	return entitlements, nil
+}
+
+func GetLeaveEntitlement(year, detailsID int) (*domain.LeaveEntitlement, error) {
+	row := db.QueryRow(
+		"SELECT id, year, details_id, allowance_days, carry_over_days, carry_over_expiry FROM leave_entitlements WHERE year = ? AND details_id = ?",
+		year, detailsID,
+	)
+
+	e, err := scanLeaveEntitlement(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+func CreateLeaveEntitlement(entitlement domain.LeaveEntitlement) (int, error) {
+	result, err := db.Exec(
+		"INSERT INTO leave_entitlements (year, details_id, allowance_days, carry_over_days, carry_over_expiry) VALUES (?, ?, ?, ?, ?)",
+		entitlement.Year, entitlement.DetailsID, entitlement.AllowanceDays, entitlement.CarryOverDays, nullableDate(entitlement.CarryOverExpiry),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create leave entitlement: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return int(id), nil
+}
+
+func UpdateLeaveEntitlement(entitlement domain.LeaveEntitlement) error {
+	result, err := db.Exec(
+		"UPDATE leave_entitlements SET year = ?, details_id = ?, allowance_days = ?, carry_over_days = ?, carry_over_expiry = ? WHERE id = ?",
+		entitlement.Year, entitlement.DetailsID, entitlement.AllowanceDays, entitlement.CarryOverDays, nullableDate(entitlement.CarryOverExpiry), entitlement.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update leave entitlement: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("leave entitlement not found")
+	}
+
+	return nil
+}
+
+func DeleteLeaveEntitlement(id int) error {
+	result, err := db.Exec("DELETE FROM leave_entitlements WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete leave entitlement: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("leave entitlement not found")
+	}
+
+	return nil
+}
+
+// GetLeaveBalance computes the balance of an entitlement as of the given date
+func GetLeaveBalance(entitlement domain.LeaveEntitlement, asOf time.Time) (domain.LeaveBalance, error) {
+	startDate := time.Date(entitlement.Year, time.January, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(entitlement.Year, time.December, 31, 0, 0, 0, 0, time.Local)
+
+	workhours, err := GetWorkhoursByDateRange(startDate, endDate)
+	if err != nil {
+		return domain.LeaveBalance{}, err
+	}
+
+	return domain.CalculateLeaveBalance(entitlement, workhours, GetDailyTargetHours(), asOf), nil
+}
+
+// GetLeaveBalancesForYear returns the balance of every entitlement of a year
+func GetLeaveBalancesForYear(year int, asOf time.Time) ([]domain.LeaveBalance, error) {
+	entitlements, err := GetAllLeaveEntitlements()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []domain.LeaveBalance
+	for _, e := range entitlements {
+		if e.Year != year {
+			continue
+		}
+		balance, err := GetLeaveBalance(e, asOf)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLeaveEntitlement(row rowScanner) (domain.LeaveEntitlement, error) {
+	var e domain.LeaveEntitlement
+	var expiry sql.NullString
+	if err := row.Scan(&e.ID, &e.Year, &e.DetailsID, &e.AllowanceDays, &e.CarryOverDays, &expiry); err != nil {
+		if err == sql.ErrNoRows {
+			return e, err
+		}
+		return e, fmt.Errorf("failed to scan leave entitlement: %w", err)
+	}
+
+	if expiry.Valid && expiry.String != "" {
+		date, err := StringToDate(expiry.String)
+		if err != nil {
+			return e, fmt.Errorf("failed to parse carry-over expiry: %w", err)
+		}
+		e.CarryOverExpiry = &date
+	}
+
+	return e, nil
+}
+
+func nullableDate(date *time.Time) any {
+	if date == nil {
+		return nil
+	}
+	return DateToString(*date)
+}
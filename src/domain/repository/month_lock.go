@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+	"tltui/src/domain"
+)
+
+const monthLockColumns = "id, year, month, locked_at, locked_by, unlocked_at, unlocked_by, unlock_reason"
+
+// CurrentUserName names the person making changes, for lock records
+func CurrentUserName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// LockMonth closes a month for changes. Locking an already locked month does nothing.
+func LockMonth(year int, month time.Month, lockedBy string) error {
+	existing, err := GetMonthLock(year, month)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	_, err = db.Exec(
+		"INSERT INTO month_locks (year, month, locked_at, locked_by) VALUES (?, ?, ?, ?)",
+		year, int(month), time.Now().Unix(), lockedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lock month: %w", err)
+	}
+	return nil
+}
+
+// UnlockMonth reopens a locked month, recording who unlocked it and why
+func UnlockMonth(year int, month time.Month, unlockedBy, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to unlock a month")
+	}
+
+	result, err := db.Exec(
+		"UPDATE month_locks SET unlocked_at = ?, unlocked_by = ?, unlock_reason = ? WHERE year = ? AND month = ? AND unlocked_at IS NULL",
+		time.Now().Unix(), unlockedBy, strings.TrimSpace(reason), year, int(month),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unlock month: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("month is not locked")
+	}
+
+	return nil
+}
+
+// GetMonthLock returns the active lock of a month, or nil when the month is open
+func GetMonthLock(year int, month time.Month) (*domain.MonthLock, error) {
+	return getMonthLock(db, year, month)
+}
+
+// rowQuerier is implemented by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func getMonthLock(q rowQuerier, year int, month time.Month) (*domain.MonthLock, error) {
+	lock, err := scanMonthLock(q.QueryRow(
+		"SELECT "+monthLockColumns+" FROM month_locks WHERE year = ? AND month = ? AND unlocked_at IS NULL",
+		year, int(month),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get month lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// getLockedMonths returns the active month locks, oldest month first
+func getLockedMonths() ([]domain.MonthLock, error) {
+	rows, err := db.Query("SELECT " + monthLockColumns + " FROM month_locks WHERE unlocked_at IS NULL ORDER BY year, month")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query month locks: %w", err)
+	}
+	defer rows.Close()
+
+	var locks []domain.MonthLock
+	for rows.Next() {
+		lock, err := scanMonthLock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan month lock: %w", err)
+		}
+		locks = append(locks, lock)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating month locks: %w", err)
+	}
+
+	return locks, nil
+}
+
+// GetMonthLockHistory returns every lock of a month, oldest first, including unlocked ones
+func GetMonthLockHistory(year int, month time.Month) ([]domain.MonthLock, error) {
+	rows, err := db.Query(
+		"SELECT "+monthLockColumns+" FROM month_locks WHERE year = ? AND month = ? ORDER BY id",
+		year, int(month),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query month locks: %w", err)
+	}
+	defer rows.Close()
+
+	var locks []domain.MonthLock
+	for rows.Next() {
+		lock, err := scanMonthLock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan month lock: %w", err)
+		}
+		locks = append(locks, lock)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating month locks: %w", err)
+	}
+
+	return locks, nil
+}
+
+// checkDatesUnlocked returns an error wrapping domain.ErrMonthLocked when any date is in a locked month
+func checkDatesUnlocked(dates ...time.Time) error {
+	return checkDatesUnlockedIn(db, dates...)
+}
+
+// checkDatesUnlockedIn is checkDatesUnlocked reading the locks through q, e.g. an open transaction
+func checkDatesUnlockedIn(q rowQuerier, dates ...time.Time) error {
+	for _, date := range dates {
+		lock, err := getMonthLock(q, date.Year(), date.Month())
+		if err != nil {
+			return err
+		}
+		if lock != nil {
+			return fmt.Errorf("%w: %s was locked by %s on %s, unlock it to make changes",
+				domain.ErrMonthLocked, lock.Label(), lock.LockedBy, lock.LockedAt.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+func scanMonthLock(row rowScanner) (domain.MonthLock, error) {
+	var lock domain.MonthLock
+	var month int
+	var lockedAt int64
+	var unlockedAt sql.NullInt64
+	var unlockedBy, unlockReason sql.NullString
+	if err := row.Scan(&lock.ID, &lock.Year, &month, &lockedAt, &lock.LockedBy, &unlockedAt, &unlockedBy, &unlockReason); err != nil {
+		return lock, err
+	}
+
+	lock.Month = time.Month(month)
+	lock.LockedAt = time.Unix(lockedAt, 0)
+	if unlockedAt.Valid {
+		lock.UnlockedAt = time.Unix(unlockedAt.Int64, 0)
+	}
+	lock.UnlockedBy = unlockedBy.String
+	lock.UnlockReason = unlockReason.String
+
+	return lock, nil
+}
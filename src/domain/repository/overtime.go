@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+	"tltui/src/domain"
+)
+
+// GetOvertimeLedger computes the overtime ledger of a year up to asOf
+func GetOvertimeLedger(year int, asOf time.Time) (domain.OvertimeLedger, error) {
+	startDate := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+
+	workhours, err := GetWorkhoursByDateRange(startDate, endDate)
+	if err != nil {
+		return domain.OvertimeLedger{}, err
+	}
+
+	workhourDetails, err := GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return domain.OvertimeLedger{}, fmt.Errorf("failed to get workhour details: %w", err)
+	}
+
+	detailsMap := make(map[int]domain.WorkhourDetails)
+	for _, wd := range workhourDetails {
+		detailsMap[wd.ID] = wd
+	}
+
+	targets := domain.OvertimeTargets{
+		DailyHours:  GetDailyTargetHours(),
+		WeeklyHours: GetWeeklyTargetHours(),
+	}
+
+	return domain.CalculateOvertimeLedger(year, workhours, detailsMap, targets, asOf), nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultProfile is the profile used when none is chosen. Its database is the
+// data.db of the data directory, so installs from before profiles keep their data.
+const DefaultProfile = "default"
+
+// currentProfile is the profile whose database is open
+var currentProfile = DefaultProfile
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,39}$`)
+
+// CurrentProfile returns the name of the open profile, empty when a database file was opened directly
+func CurrentProfile() string {
+	return currentProfile
+}
+
+// ValidateProfileName checks that a name can be used as a profile directory
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q, use up to 40 letters, digits, - and _", name)
+	}
+	return nil
+}
+
+// ProfileDir returns the directory holding a profile's database
+func ProfileDir(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	if name == DefaultProfile {
+		return dataDir, nil
+	}
+	return filepath.Join(dataDir, "profiles", name), nil
+}
+
+// ListProfiles returns the default profile followed by the created ones by name
+func ListProfiles() ([]string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dataDir, "profiles"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultProfile && ValidateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// ProfileExists reports whether a profile was created
+func ProfileExists(name string) (bool, error) {
+	if name == DefaultProfile {
+		return true, nil
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to check profile %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// CreateProfile creates an empty profile. Its database is set up when it is first opened.
+func CreateProfile(name string) error {
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	return nil
+}
+
+// RenameProfile renames a created profile. The default and the open profile can't be renamed.
+func RenameProfile(oldName, newName string) error {
+	if err := checkProfileChangeable(oldName); err != nil {
+		return err
+	}
+	exists, err := ProfileExists(newName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("profile %q already exists", newName)
+	}
+
+	oldDir, err := ProfileDir(oldName)
+	if err != nil {
+		return err
+	}
+	newDir, err := ProfileDir(newName)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return fmt.Errorf("failed to rename profile: %w", err)
+	}
+	return nil
+}
+
+// DeleteProfile removes a created profile with all its data. The default and the
+// open profile can't be deleted.
+func DeleteProfile(name string) error {
+	if err := checkProfileChangeable(name); err != nil {
+		return err
+	}
+
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
+
+// SwitchProfile closes the open database and opens the one of another profile,
+// keeping the current one open when that fails. Read-only mode carries over.
+func SwitchProfile(name string) error {
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	previousDB, previousProfile, previousPath := db, currentProfile, dbPath
+	restore := func() {
+		if db != nil && db != previousDB {
+			db.Close()
+		}
+		db, currentProfile, dbPath = previousDB, previousProfile, previousPath
+	}
+
+	if err := InitDB(name); err != nil {
+		restore()
+		return err
+	}
+	if !readOnly {
+		if err := SeedProjects(); err != nil {
+			restore()
+			return err
+		}
+		if err := SeedWorkhourDetails(); err != nil {
+			restore()
+			return err
+		}
+	}
+
+	if previousDB != nil {
+		previousDB.Close()
+	}
+	return nil
+}
+
+func checkProfileChangeable(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile can't be renamed or deleted", DefaultProfile)
+	}
+	if name == currentProfile {
+		return fmt.Errorf("profile %q is in use, switch to another profile first", name)
+	}
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"tltui/src/domain"
 )
 
+const projectColumns = "id, odoo_id, name, client_id, budget_hours, budget_period"
+
 func GetAllProjectsFromDB() ([]domain.Project, error) {
-	rows, err := db.Query("SELECT id, odoo_id, name FROM projects ORDER BY id")
+	rows, err := db.Query("SELECT " + projectColumns + " FROM projects ORDER BY id")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query projects: %w", err)
 	}
@@ -15,8 +17,8 @@ func GetAllProjectsFromDB() ([]domain.Project, error) {
 
 	var projects []domain.Project
 	for rows.Next() {
-		var p domain.Project
-		if err := rows.Scan(&p.ID, &p.OdooID, &p.Name); err != nil {
+		p, err := scanProject(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan project: %w", err)
 		}
 		projects = append(projects, p)
@@ -30,9 +32,7 @@ func GetAllProjectsFromDB() ([]domain.Project, error) {
 }
 
 func GetProjectByID(id int) (*domain.Project, error) {
-	var p domain.Project
-	err := db.QueryRow("SELECT id, odoo_id, name FROM projects WHERE id = ?", id).
-		Scan(&p.ID, &p.OdooID, &p.Name)
+	p, err := scanProject(db.QueryRow("SELECT "+projectColumns+" FROM projects WHERE id = ?", id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -46,8 +46,8 @@ func GetProjectByID(id int) (*domain.Project, error) {
 
 func CreateProject(project domain.Project) error {
 	_, err := db.Exec(
-		"INSERT INTO projects (id, odoo_id, name) VALUES (?, ?, ?)",
-		project.ID, project.OdooID, project.Name,
+		"INSERT INTO projects (id, odoo_id, name, client_id, budget_hours, budget_period) VALUES (?, ?, ?, ?, ?, ?)",
+		project.ID, project.OdooID, project.Name, nullableID(project.ClientID), project.BudgetHours, int(project.BudgetPeriod),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
@@ -57,8 +57,8 @@ func CreateProject(project domain.Project) error {
 
 func UpdateProject(project domain.Project) error {
 	result, err := db.Exec(
-		"UPDATE projects SET odoo_id = ?, name = ? WHERE id = ?",
-		project.OdooID, project.Name, project.ID,
+		"UPDATE projects SET odoo_id = ?, name = ?, client_id = ?, budget_hours = ?, budget_period = ? WHERE id = ?",
+		project.OdooID, project.Name, nullableID(project.ClientID), project.BudgetHours, int(project.BudgetPeriod), project.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
@@ -75,21 +75,67 @@ func UpdateProject(project domain.Project) error {
 	return nil
 }
 
+// DeleteProject removes a project with its tasks and logged hours. The hours are
+// deleted like single entries, so the history and sync see them, and none of them
+// may be in a locked month.
 func DeleteProject(id int) error {
-	result, err := db.Exec("DELETE FROM projects WHERE id = ?", id)
+	workhours, err := getWorkhoursBy("project_id", id)
 	if err != nil {
-		return fmt.Errorf("failed to delete project: %w", err)
+		return err
+	}
+	if err := checkDatesUnlocked(workhourDates(workhours)...); err != nil {
+		return err
 	}
 
-	rows, err := result.RowsAffected()
+	return withTx(func(tx *sql.Tx) error {
+		for _, wh := range workhours {
+			if err := deleteWorkhour(tx, wh); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.Exec("DELETE FROM projects WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete project: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("project not found")
+		}
+
+		return nil
+	})
+}
+
+func GetProjectsByClient(clientID int) ([]domain.Project, error) {
+	projects, err := GetAllProjectsFromDB()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, err
 	}
-	if rows == 0 {
-		return fmt.Errorf("project not found")
+
+	var clientProjects []domain.Project
+	for _, p := range projects {
+		if p.ClientID == clientID {
+			clientProjects = append(clientProjects, p)
+		}
 	}
+	return clientProjects, nil
+}
 
-	return nil
+func scanProject(row rowScanner) (domain.Project, error) {
+	var p domain.Project
+	var clientID sql.NullInt64
+	var budgetPeriod int
+	if err := row.Scan(&p.ID, &p.OdooID, &p.Name, &clientID, &p.BudgetHours, &budgetPeriod); err != nil {
+		return domain.Project{}, err
+	}
+	p.ClientID = int(clientID.Int64)
+	p.BudgetPeriod = domain.BudgetPeriod(budgetPeriod)
+	return p, nil
 }
 
 func SeedProjects() error {
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of items whose selections are remembered for most-recently-used ordering
+const (
+	SelectionKindProject         = "project"
+	SelectionKindWorkhourDetails = "workhour_details"
+	SelectionKindTask            = "task"
+	SelectionKindClient          = "client"
+)
+
+// RecordSelection marks an item as just used
+func RecordSelection(kind string, itemID int) error {
+	_, err := db.Exec(
+		`INSERT INTO recent_selections (kind, item_id, used_at, use_count) VALUES (?, ?, ?, 1)
+		ON CONFLICT(kind, item_id) DO UPDATE SET used_at = excluded.used_at, use_count = use_count + 1`,
+		kind, itemID, time.Now().UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record %s selection: %w", kind, err)
+	}
+	return nil
+}
+
+// GetRecentSelections returns the IDs of used items of a kind, most recently used first
+func GetRecentSelections(kind string) ([]int, error) {
+	rows, err := db.Query(
+		"SELECT item_id FROM recent_selections WHERE kind = ? ORDER BY used_at DESC",
+		kind,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent %s selections: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan recent selection: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recent selections: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"tltui/src/domain"
+)
+
+const reportRecordColumns = "id, kind, year, month, params, path, content_hash, data_hash, generated_at"
+
+// CreateReportRecord adds a generated report to the report history
+func CreateReportRecord(record domain.ReportRecord) (int, error) {
+	params, err := json.Marshal(record.Params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode report parameters: %w", err)
+	}
+	if record.GeneratedAt.IsZero() {
+		record.GeneratedAt = time.Now()
+	}
+
+	result, err := db.Exec(
+		"INSERT INTO report_history (kind, year, month, params, path, content_hash, data_hash, generated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		string(record.Kind), record.Year, int(record.Month), string(params), record.Path,
+		record.ContentHash, record.DataHash, record.GeneratedAt.Unix(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create report record: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return int(id), nil
+}
+
+// GetReportRecords returns the report history, most recently generated first
+func GetReportRecords() ([]domain.ReportRecord, error) {
+	rows, err := db.Query("SELECT " + reportRecordColumns + " FROM report_history ORDER BY generated_at DESC, id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report history: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.ReportRecord
+	for rows.Next() {
+		record, err := scanReportRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating report history: %w", err)
+	}
+
+	return records, nil
+}
+
+func scanReportRecord(row rowScanner) (domain.ReportRecord, error) {
+	var record domain.ReportRecord
+	var kind, params string
+	var month int
+	var generatedAt int64
+	if err := row.Scan(&record.ID, &kind, &record.Year, &month, &params, &record.Path,
+		&record.ContentHash, &record.DataHash, &generatedAt); err != nil {
+		return record, fmt.Errorf("failed to scan report record: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(params), &record.Params); err != nil {
+		return record, fmt.Errorf("failed to decode report parameters: %w", err)
+	}
+	record.Kind = domain.ReportKind(kind)
+	record.Month = time.Month(month)
+	record.GeneratedAt = time.Unix(generatedAt, 0)
+
+	return record, nil
+}
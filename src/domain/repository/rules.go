@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"tltui/src/domain"
+)
+
+// settingRulePrefix prefixes the setting keys of rules, e.g. "rule_max_daily_hours"
+const settingRulePrefix = "rule_"
+
+// RuleSettingKey returns the setting key a rule is stored under
+func RuleSettingKey(kind domain.RuleKind) string {
+	return settingRulePrefix + string(kind)
+}
+
+// GetRules returns the configured rules, using the defaults for unset or invalid settings
+func GetRules() []domain.Rule {
+	return getRules(db)
+}
+
+func getRules(q rowQuerier) []domain.Rule {
+	rules := domain.DefaultRules()
+	for i, rule := range rules {
+		value, ok, err := getSetting(q, RuleSettingKey(rule.Kind))
+		if err != nil || !ok {
+			continue
+		}
+		if configured, err := domain.ParseRule(rule.Kind, value); err == nil {
+			rules[i] = configured
+		}
+	}
+	return rules
+}
+
+func SetRule(rule domain.Rule) error {
+	return SetSetting(RuleSettingKey(rule.Kind), rule.SettingValue())
+}
+
+// EvaluateDay checks the rules against the entries a date would have after a change.
+// The other days of the week are read from the database, without the stored entries
+// the day replaces, e.g. an edited entry moved from another day of the week.
+func EvaluateDay(date time.Time, day []domain.Workhour) ([]domain.RuleViolation, error) {
+	return evaluateDay(db, date, day)
+}
+
+// dbQuerier is implemented by both *sql.DB and *sql.Tx
+type dbQuerier interface {
+	querier
+	rowQuerier
+}
+
+func evaluateDay(q dbQuerier, date time.Time, day []domain.Workhour) ([]domain.RuleViolation, error) {
+	weekStart := domain.WeekStart(date)
+	stored, err := getWorkhoursByDateRange(q, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := make(map[int]bool)
+	for _, wh := range day {
+		if wh.ID != 0 {
+			replaced[wh.ID] = true
+		}
+	}
+	dateStr := DateToString(date)
+	week := make([]domain.Workhour, 0, len(stored)+len(day))
+	for _, wh := range stored {
+		if DateToString(wh.Date) != dateStr && !replaced[wh.ID] {
+			week = append(week, wh)
+		}
+	}
+	week = append(week, day...)
+
+	details, err := getWorkhourDetailsMap(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.EvaluateRules(getRules(q), domain.RuleInput{
+		Date:        date,
+		Day:         day,
+		Week:        week,
+		Details:     details,
+		DailyTarget: getDailyTargetHours(q),
+		Today:       time.Now(),
+	}), nil
+}
+
+// PreviewWorkhourRules returns the violations the day of a workhour would have once
+// it is saved. Workhours with an ID replace the stored entry with the same ID.
+func PreviewWorkhourRules(workhour domain.Workhour) ([]domain.RuleViolation, error) {
+	stored, err := GetWorkhoursByDate(workhour.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	day := make([]domain.Workhour, 0, len(stored)+1)
+	for _, wh := range stored {
+		if workhour.ID == 0 || wh.ID != workhour.ID {
+			day = append(day, wh)
+		}
+	}
+	day = append(day, workhour)
+
+	return EvaluateDay(workhour.Date, day)
+}
+
+// GetViolationsForDate checks the rules against the stored entries of a date
+func GetViolationsForDate(date time.Time) ([]domain.RuleViolation, error) {
+	day, err := GetWorkhoursByDate(date)
+	if err != nil {
+		return nil, err
+	}
+	return EvaluateDay(date, day)
+}
+
+// getViolationsForDates checks the rules against the stored entries of the dates
+func getViolationsForDates(dates []time.Time) ([]domain.RuleViolation, error) {
+	var workhours []domain.Workhour
+	seen := make(map[string]bool)
+	for _, date := range dates {
+		if seen[DateToString(date)] {
+			continue
+		}
+		seen[DateToString(date)] = true
+		day, err := GetWorkhoursByDate(date)
+		if err != nil {
+			return nil, err
+		}
+		workhours = append(workhours, day...)
+	}
+	return evaluateDays(workhours)
+}
+
+// GetMonthViolations lists the rule violations of every day in a month, in date order.
+// Weekly violations are reported once per week.
+func GetMonthViolations(year int, month time.Month) ([]domain.RuleViolation, error) {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, -1)
+	workhours, err := GetWorkhoursByDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateDays(workhours)
+}
+
+// evaluateDays checks the rules against the days of the given stored entries, in
+// date order. Weekly violations are reported once per week.
+func evaluateDays(workhours []domain.Workhour) ([]domain.RuleViolation, error) {
+	days := make(map[string][]domain.Workhour)
+	var dates []time.Time
+	for _, wh := range workhours {
+		key := DateToString(wh.Date)
+		if _, ok := days[key]; !ok {
+			dates = append(dates, wh.Date)
+		}
+		days[key] = append(days[key], wh)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var violations []domain.RuleViolation
+	seen := make(map[string]bool)
+	for _, date := range dates {
+		dayViolations, err := EvaluateDay(date, days[DateToString(date)])
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range dayViolations {
+			key := string(v.Rule.Kind) + DateToString(v.Date)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			violations = append(violations, v)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Date.Before(violations[j].Date)
+	})
+	return violations, nil
+}
+
+// checkDayRules rejects a change, made through q but not committed yet, that leaves
+// one of the dates breaking a blocking rule
+func checkDayRules(q dbQuerier, dates ...time.Time) error {
+	seen := make(map[string]bool)
+	for _, date := range dates {
+		if seen[DateToString(date)] {
+			continue
+		}
+		seen[DateToString(date)] = true
+		day, err := getWorkhoursByDate(q, date)
+		if err != nil {
+			return err
+		}
+		violations, err := evaluateDay(q, date, day)
+		if err != nil {
+			return err
+		}
+		if err := blockingViolationsError(violations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockingViolationsError returns an error wrapping domain.ErrRuleBlocked when any violation blocks
+func blockingViolationsError(violations []domain.RuleViolation) error {
+	var blocking []domain.RuleViolation
+	for _, v := range violations {
+		if v.Blocking() {
+			blocking = append(blocking, v)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", domain.ErrRuleBlocked, domain.FormatViolations(blocking))
+}
+
+func getWorkhourDetailsMap(q querier) (map[int]domain.WorkhourDetails, error) {
+	allDetails, err := getAllWorkhourDetails(q)
+	if err != nil {
+		return nil, err
+	}
+	details := make(map[int]domain.WorkhourDetails, len(allDetails))
+	for _, d := range allDetails {
+		details[d.ID] = d
+	}
+	return details, nil
+}
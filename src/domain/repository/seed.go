@@ -12,7 +12,7 @@ func fetchAllProjects() []domain.Project {
 func fetchAllWorkhourDetails() []domain.WorkhourDetails {
 	return []domain.WorkhourDetails{
 		{ID: 1, Name: "Development", ShortName: "🔧", IsWork: true},
-		{ID: 2, Name: "Development Overtime", ShortName: "🕐", IsWork: true},
+		{ID: 2, Name: "Development Overtime", ShortName: "🕐", IsWork: true, OvertimeRole: domain.OvertimeRoleOvertime},
 		{ID: 3, Name: "Leave", ShortName: "🏖️", IsWork: false},
 		{ID: 4, Name: "National Day", ShortName: "🇷🇴", IsWork: false},
 	}
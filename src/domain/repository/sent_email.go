@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+	"tltui/src/domain"
+)
+
+// Mail server and templates reports are emailed with. The SMTP password is read
+// from the environment and never stored.
+const (
+	SettingSMTPServer           = "smtp_server"
+	SettingSMTPUsername         = "smtp_username"
+	SettingSMTPFrom             = "smtp_from"
+	SettingEmailSubjectTemplate = "email_subject_template"
+	SettingEmailBodyTemplate    = "email_body_template"
+)
+
+// GetEmailTemplates returns the subject and body templates of report emails,
+// falling back to the defaults when not configured
+func GetEmailTemplates() (subject, body string, err error) {
+	subject, ok, err := GetSetting(SettingEmailSubjectTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok || subject == "" {
+		subject = domain.DefaultEmailSubjectTemplate
+	}
+
+	body, ok, err = GetSetting(SettingEmailBodyTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok || body == "" {
+		body = domain.DefaultEmailBodyTemplate
+	}
+
+	return subject, body, nil
+}
+
+// CreateSentEmail records a report emailed to its recipients
+func CreateSentEmail(email domain.SentEmail) (int, error) {
+	recipients, err := json.Marshal(email.Recipients)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode recipients: %w", err)
+	}
+	attachments, err := json.Marshal(email.Attachments)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode attachments: %w", err)
+	}
+	if email.SentAt.IsZero() {
+		email.SentAt = time.Now()
+	}
+
+	result, err := db.Exec(
+		"INSERT INTO sent_emails (report_id, recipients, subject, attachments, sent_at) VALUES (?, ?, ?, ?, ?)",
+		nullableID(email.ReportID), string(recipients), email.Subject, string(attachments), email.SentAt.Unix(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record sent email: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return int(id), nil
+}
+
+// GetSentEmails returns the emailed reports, most recently sent first
+func GetSentEmails() ([]domain.SentEmail, error) {
+	rows, err := db.Query("SELECT id, report_id, recipients, subject, attachments, sent_at FROM sent_emails ORDER BY sent_at DESC, id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sent emails: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []domain.SentEmail
+	for rows.Next() {
+		var email domain.SentEmail
+		var reportID sql.NullInt64
+		var recipients, attachments string
+		var sentAt int64
+		if err := rows.Scan(&email.ID, &reportID, &recipients, &email.Subject, &attachments, &sentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sent email: %w", err)
+		}
+		if err := json.Unmarshal([]byte(recipients), &email.Recipients); err != nil {
+			return nil, fmt.Errorf("failed to decode recipients: %w", err)
+		}
+		if err := json.Unmarshal([]byte(attachments), &email.Attachments); err != nil {
+			return nil, fmt.Errorf("failed to decode attachments: %w", err)
+		}
+		email.ReportID = int(reportID.Int64)
+		email.SentAt = time.Unix(sentAt, 0)
+		emails = append(emails, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sent emails: %w", err)
+	}
+
+	return emails, nil
+}
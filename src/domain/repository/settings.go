@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"tltui/src/domain"
+)
+
+const (
+	SettingDailyTargetHours  = "daily_target_hours"
+	SettingWeeklyTargetHours = "weekly_target_hours"
+	SettingHoursRounding     = "hours_rounding"
+	SettingBackupKeep        = "backup_keep"
+)
+
+func GetSetting(key string) (string, bool, error) {
+	return getSetting(db, key)
+}
+
+func getSetting(q rowQuerier, key string) (string, bool, error) {
+	var value string
+	err := q.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+
+	return value, true, nil
+}
+
+func SetSetting(key, value string) error {
+	_, err := db.Exec(
+		"INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value",
+		key, value,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetDailyTargetHours returns the configured working hours per day,
+// falling back to domain.DefaultDailyTargetHours when unset or invalid
+func GetDailyTargetHours() float64 {
+	return getDailyTargetHours(db)
+}
+
+func getDailyTargetHours(q rowQuerier) float64 {
+	value, ok, err := getSetting(q, SettingDailyTargetHours)
+	if err != nil || !ok {
+		return domain.DefaultDailyTargetHours
+	}
+
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil || hours <= 0 {
+		return domain.DefaultDailyTargetHours
+	}
+
+	return hours
+}
+
+// GetWeeklyTargetHours returns the configured working hours per week,
+// falling back to five times the daily target when unset or invalid
+func GetWeeklyTargetHours() float64 {
+	value, ok, err := GetSetting(SettingWeeklyTargetHours)
+	if err != nil || !ok {
+		return GetDailyTargetHours() * 5
+	}
+
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil || hours <= 0 {
+		return GetDailyTargetHours() * 5
+	}
+
+	return hours
+}
+
+// GetHoursRounding returns the step entered durations are rounded to, e.g. 0.25,
+// or 0 when unset or invalid, which keeps durations unrounded
+func GetHoursRounding() float64 {
+	value, ok, err := GetSetting(SettingHoursRounding)
+	if err != nil || !ok {
+		return 0
+	}
+
+	step, err := strconv.ParseFloat(value, 64)
+	if err != nil || step < 0 {
+		return 0
+	}
+
+	return step
+}
+
+// GetBackupKeep returns how many automatic backups are kept, falling back to
+// DefaultBackupKeep when unset or invalid. 0 disables automatic backups.
+func GetBackupKeep() int {
+	value, ok, err := GetSetting(SettingBackupKeep)
+	if err != nil || !ok {
+		return DefaultBackupKeep
+	}
+
+	keep, err := strconv.Atoi(value)
+	if err != nil || keep < 0 {
+		return DefaultBackupKeep
+	}
+
+	return keep
+}
@@ -0,0 +1,629 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"tltui/src/domain"
+
+	"github.com/google/uuid"
+)
+
+// Sync keeps the workhours of several replicas, e.g. a laptop and a desktop, in
+// step through a shared folder. Every replica appends its own changes to
+// <folder>/<replica id>.jsonl and replays the logs of the others. A change
+// carries the value it replaced, so a change made on both sides since they
+// last agreed is detected; the newest one wins and the conflict is kept for review.
+// Changes touching a locked month are not applied but kept as conflicts as well.
+const (
+	SettingSyncDir        = "sync_dir"
+	SettingSyncReplicaID  = "sync_replica_id"
+	settingSyncExportedID = "sync_exported_id" // Last workhour_history id appended to the log
+)
+
+const syncLogSuffix = ".jsonl"
+
+// workhourUUIDNamespace derives the UUIDs of workhours stored before they had one
+var workhourUUIDNamespace = uuid.MustParse("6f1c7f64-3b8e-4a53-9c57-1d1e0f4b2a90")
+
+// syncChange is a line of a replica's change log
+type syncChange struct {
+	Replica   string              `json:"replica"`
+	UUID      string              `json:"uuid"`
+	Action    domain.ChangeAction `json:"action"`
+	Old       *workhourSnapshot   `json:"old,omitempty"`
+	New       *workhourSnapshot   `json:"new,omitempty"`
+	ChangedAt int64               `json:"changed_at"`
+}
+
+// syncReplay collects what replaying the logs of the other replicas did
+type syncReplay struct {
+	result *domain.SyncResult
+	before []domain.Workhour // Values the applied changes replaced
+	after  []domain.Workhour // Values the applied changes stored
+}
+
+// applied records a change of a workhour from before to after, nil meaning absent
+func (r *syncReplay) applied(before, after *domain.Workhour) {
+	if before != nil {
+		r.before = append(r.before, *before)
+	}
+	if after != nil {
+		r.after = append(r.after, *after)
+	}
+}
+
+// dates returns the dates of the workhours the replay changed
+func (r *syncReplay) dates() []time.Time {
+	var dates []time.Time
+	for _, wh := range append(r.before, r.after...) {
+		dates = append(dates, wh.Date)
+	}
+	return dates
+}
+
+// GetSyncConfig returns the shared folder and the ID of this replica, both empty
+// when sync is not set up
+func GetSyncConfig() (dir, replica string, err error) {
+	if dir, _, err = GetSetting(SettingSyncDir); err != nil {
+		return "", "", err
+	}
+	if replica, _, err = GetSetting(SettingSyncReplicaID); err != nil {
+		return "", "", err
+	}
+	if dir == "" || replica == "" {
+		return "", "", nil
+	}
+	return dir, replica, nil
+}
+
+// InitSync sets up syncing through the folder dir under a new replica ID, which
+// is returned. Every device must be set up on its own, so copies of a database
+// never share a replica ID. The first sync afterwards shares all workhours and
+// replays the other replicas' logs from the start.
+func InitSync(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve sync folder: %w", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to open sync folder: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a folder", dir)
+	}
+
+	replica := uuid.NewString()
+	err = withTx(func(tx *sql.Tx) error {
+		for key, value := range map[string]string{SettingSyncDir: dir, SettingSyncReplicaID: replica} {
+			_, err := tx.Exec(
+				"INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value",
+				key, value,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to set setting %s: %w", key, err)
+			}
+		}
+		if _, err := tx.Exec("DELETE FROM settings WHERE key = ?", settingSyncExportedID); err != nil {
+			return fmt.Errorf("failed to reset sync state: %w", err)
+		}
+		if _, err := tx.Exec("DELETE FROM sync_positions"); err != nil {
+			return fmt.Errorf("failed to reset sync state: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return replica, nil
+}
+
+// Sync replays the changes other replicas logged in the shared folder since the
+// last run, then appends the local changes made since to this replica's log
+func Sync() (domain.SyncResult, error) {
+	var result domain.SyncResult
+	if readOnly {
+		return result, fmt.Errorf("cannot sync in read-only mode")
+	}
+
+	dir, replica, err := GetSyncConfig()
+	if err != nil {
+		return result, err
+	}
+	if dir == "" {
+		return result, fmt.Errorf("sync is not set up, run 'tltui sync init FOLDER'")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return result, fmt.Errorf("failed to read sync folder: %w", err)
+	}
+	replay := &syncReplay{result: &result}
+	for _, entry := range entries {
+		name := entry.Name()
+		other := strings.TrimSuffix(name, syncLogSuffix)
+		if entry.IsDir() || other == name || other == replica || uuid.Validate(other) != nil {
+			continue
+		}
+		if err := applySyncLog(filepath.Join(dir, name), name, replay); err != nil {
+			return result, err
+		}
+	}
+	if result.BudgetWarnings, err = budgetWarnings(replay.before, replay.after); err != nil {
+		return result, err
+	}
+	if result.RuleViolations, err = getViolationsForDates(replay.dates()); err != nil {
+		return result, err
+	}
+
+	if result.Exported, err = exportSyncChanges(dir, replica); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// GetSyncConflicts returns the conflicts not reviewed yet, oldest first
+func GetSyncConflicts() ([]domain.SyncConflict, error) {
+	rows, err := db.Query(
+		`SELECT id, workhour_uuid, local_value, remote_value, remote_replica, kept, detected_at
+		FROM sync_conflicts WHERE resolved_at IS NULL ORDER BY id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	var conflicts []domain.SyncConflict
+	for rows.Next() {
+		var conflict domain.SyncConflict
+		var localValue, remoteValue sql.NullString
+		var kept string
+		var detectedAt int64
+		if err := rows.Scan(&conflict.ID, &conflict.WorkhourUUID, &localValue, &remoteValue, &conflict.RemoteReplica, &kept, &detectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sync conflict: %w", err)
+		}
+
+		conflict.Kept = domain.SyncSide(kept)
+		conflict.DetectedAt = time.Unix(0, detectedAt)
+		if conflict.Local, err = decodeConflictValue(localValue, conflict.WorkhourUUID); err != nil {
+			return nil, err
+		}
+		if conflict.Remote, err = decodeConflictValue(remoteValue, conflict.WorkhourUUID); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sync conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// ResolveSyncConflict marks a conflict as reviewed. With useOther the workhour is
+// set to the value the conflict discarded, as a local change the next sync shares.
+func ResolveSyncConflict(id int, useOther bool) error {
+	return withTx(func(tx *sql.Tx) error {
+		var workhourUUID, kept string
+		var localValue, remoteValue sql.NullString
+		err := tx.QueryRow(
+			"SELECT workhour_uuid, local_value, remote_value, kept FROM sync_conflicts WHERE id = ? AND resolved_at IS NULL", id,
+		).Scan(&workhourUUID, &localValue, &remoteValue, &kept)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("sync conflict not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get sync conflict: %w", err)
+		}
+
+		if useOther {
+			other := remoteValue
+			if domain.SyncSide(kept) == domain.SyncSideRemote {
+				other = localValue
+			}
+			value, err := decodeSnapshot(other)
+			if err != nil {
+				return err
+			}
+			stored, err := workhourByUUID(tx, workhourUUID)
+			if err != nil {
+				return err
+			}
+			if err := checkSyncValueUnlocked(tx, stored, value); err != nil {
+				return err
+			}
+			if _, err := setWorkhourValue(tx, workhourUUID, stored, value, changeSource, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec("UPDATE sync_conflicts SET resolved_at = ? WHERE id = ?", time.Now().UnixNano(), id); err != nil {
+			return fmt.Errorf("failed to resolve sync conflict: %w", err)
+		}
+		return nil
+	})
+}
+
+// applySyncLog replays the complete lines of another replica's log not applied yet.
+// A last line without a newline may still be being written and waits for the next run.
+func applySyncLog(path, file string, replay *syncReplay) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	lines = lines[:len(lines)-1]
+
+	var applied int
+	err = db.QueryRow("SELECT applied_lines FROM sync_positions WHERE file = ?", file).Scan(&applied)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get sync position: %w", err)
+	}
+	if applied >= len(lines) {
+		return nil
+	}
+
+	return withTx(func(tx *sql.Tx) error {
+		for i := applied; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "" {
+				continue
+			}
+			var change syncChange
+			if err := json.Unmarshal([]byte(lines[i]), &change); err != nil {
+				return fmt.Errorf("invalid change on line %d of %s: %w", i+1, file, err)
+			}
+			if err := applySyncChange(tx, change, replay); err != nil {
+				return fmt.Errorf("failed to apply line %d of %s: %w", i+1, file, err)
+			}
+		}
+
+		_, err := tx.Exec(
+			"INSERT INTO sync_positions (file, applied_lines) VALUES (?, ?) ON CONFLICT(file) DO UPDATE SET applied_lines = excluded.applied_lines",
+			file, len(lines),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save sync position: %w", err)
+		}
+		return nil
+	})
+}
+
+// applySyncChange applies a change of another replica when the workhour still has
+// the value the change replaced. Otherwise both sides changed it: the newest change
+// wins, last writer wins, and the conflict is recorded.
+func applySyncChange(tx *sql.Tx, change syncChange, replay *syncReplay) error {
+	stored, err := workhourByUUID(tx, change.UUID)
+	if err != nil {
+		return err
+	}
+	local := snapshotOf(stored)
+	changedAt := time.Unix(0, change.ChangedAt)
+
+	if sameSnapshot(local, change.New) {
+		return nil
+	}
+
+	// Locked months keep their entries, the change waits as a conflict until they are unlocked
+	if err := checkSyncValueUnlocked(tx, stored, change.New); err != nil {
+		if !errors.Is(err, domain.ErrMonthLocked) {
+			return err
+		}
+		replay.result.Locked++
+		return recordSyncConflict(tx, change, local, domain.SyncSideLocal, replay.result)
+	}
+
+	if sameSnapshot(local, change.Old) {
+		value, err := setWorkhourValue(tx, change.UUID, stored, change.New, domain.ChangeSourceSync, changedAt)
+		if err != nil {
+			return err
+		}
+		replay.result.Applied++
+		replay.applied(stored, value)
+		return nil
+	}
+
+	var localChangedAt int64
+	err = tx.QueryRow("SELECT COALESCE(MAX(changed_at), 0) FROM workhour_history WHERE workhour_uuid = ?", change.UUID).Scan(&localChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to get last change of workhour: %w", err)
+	}
+
+	kept := domain.SyncSideLocal
+	if change.ChangedAt > localChangedAt {
+		kept = domain.SyncSideRemote
+		value, err := setWorkhourValue(tx, change.UUID, stored, change.New, domain.ChangeSourceSync, changedAt)
+		if err != nil {
+			return err
+		}
+		replay.applied(stored, value)
+	}
+
+	return recordSyncConflict(tx, change, local, kept, replay.result)
+}
+
+// recordSyncConflict stores a change of another replica that clashed with the local
+// value of the workhour, for review
+func recordSyncConflict(tx *sql.Tx, change syncChange, local *workhourSnapshot, kept domain.SyncSide, result *domain.SyncResult) error {
+	localValue, err := encodeSnapshot(local)
+	if err != nil {
+		return err
+	}
+	remoteValue, err := encodeSnapshot(change.New)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`INSERT INTO sync_conflicts (workhour_uuid, local_value, remote_value, remote_replica, kept, detected_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		change.UUID, localValue, remoteValue, change.Replica, string(kept), time.Now().UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record sync conflict: %w", err)
+	}
+	result.Conflicts++
+
+	return nil
+}
+
+// checkSyncValueUnlocked rejects setting a workhour to value, nil meaning deleted,
+// when the stored workhour or the value is in a locked month
+func checkSyncValueUnlocked(tx *sql.Tx, stored *domain.Workhour, value *workhourSnapshot) error {
+	var dates []time.Time
+	if stored != nil {
+		dates = append(dates, stored.Date)
+	}
+	if value != nil {
+		date, err := StringToDate(value.Date)
+		if err != nil {
+			return fmt.Errorf("failed to parse date: %w", err)
+		}
+		dates = append(dates, date)
+	}
+	return checkDatesUnlockedIn(tx, dates...)
+}
+
+// setWorkhourValue creates, updates or deletes the workhour with the UUID so it has
+// the value, nil meaning deleted, and records the change in the history. The stored
+// workhour is returned, nil when it was deleted.
+func setWorkhourValue(tx *sql.Tx, workhourUUID string, stored *domain.Workhour, value *workhourSnapshot, source domain.ChangeSource, changedAt time.Time) (*domain.Workhour, error) {
+	if value == nil {
+		if stored == nil {
+			return nil, nil
+		}
+		if _, err := tx.Exec("DELETE FROM workhours WHERE id = ?", stored.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete workhour: %w", err)
+		}
+		return nil, recordWorkhourChangeAt(tx, stored.ID, domain.ChangeDelete, stored, nil, source, changedAt)
+	}
+
+	id := 0
+	if stored != nil {
+		id = stored.ID
+	}
+	workhour, err := value.workhour(id, workhourUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored == nil {
+		if workhour.ID, err = insertWorkhourRow(tx, *workhour); err != nil {
+			return nil, fmt.Errorf("%w, sync only shares workhours, so their project, task and type must exist on every device", err)
+		}
+		return workhour, recordWorkhourChangeAt(tx, workhour.ID, domain.ChangeCreate, nil, workhour, source, changedAt)
+	}
+
+	if err := updateWorkhourRow(tx, *workhour); err != nil {
+		return nil, fmt.Errorf("%w, sync only shares workhours, so their project, task and type must exist on every device", err)
+	}
+	return workhour, recordWorkhourChangeAt(tx, workhour.ID, domain.ChangeUpdate, stored, workhour, source, changedAt)
+}
+
+// exportSyncChanges appends the local changes not shared yet to the replica's log.
+// The first export after setting up sync shares every stored workhour instead.
+func exportSyncChanges(dir, replica string) (int, error) {
+	exported, ok, err := GetSetting(settingSyncExportedID)
+	if err != nil {
+		return 0, err
+	}
+
+	var changes []syncChange
+	var lastID int
+	if ok {
+		if lastID, err = strconv.Atoi(exported); err != nil {
+			return 0, fmt.Errorf("invalid sync state %q: %w", exported, err)
+		}
+		changes, lastID, err = historySyncChanges(replica, lastID)
+	} else {
+		changes, lastID, err = snapshotSyncChanges(replica)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if len(changes) > 0 {
+		if err := appendSyncLog(filepath.Join(dir, replica+syncLogSuffix), changes); err != nil {
+			return 0, err
+		}
+	}
+	if err := SetSetting(settingSyncExportedID, strconv.Itoa(lastID)); err != nil {
+		return 0, err
+	}
+
+	return len(changes), nil
+}
+
+// historySyncChanges returns the local changes recorded after the history entry afterID,
+// leaving out the ones replayed from other replicas
+func historySyncChanges(replica string, afterID int) ([]syncChange, int, error) {
+	rows, err := db.Query(
+		`SELECT id, workhour_uuid, action, old_value, new_value, changed_at, source
+		FROM workhour_history WHERE id > ? ORDER BY id`,
+		afterID,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query workhour history: %w", err)
+	}
+	defer rows.Close()
+
+	lastID := afterID
+	var changes []syncChange
+	for rows.Next() {
+		var workhourUUID, oldValue, newValue sql.NullString
+		var action, source string
+		var changedAt int64
+		if err := rows.Scan(&lastID, &workhourUUID, &action, &oldValue, &newValue, &changedAt, &source); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan workhour history: %w", err)
+		}
+		if !workhourUUID.Valid || domain.ChangeSource(source) == domain.ChangeSourceSync {
+			continue
+		}
+
+		change := syncChange{Replica: replica, UUID: workhourUUID.String, Action: domain.ChangeAction(action), ChangedAt: changedAt}
+		if change.Old, err = decodeSnapshot(oldValue); err != nil {
+			return nil, 0, err
+		}
+		if change.New, err = decodeSnapshot(newValue); err != nil {
+			return nil, 0, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating workhour history: %w", err)
+	}
+
+	return changes, lastID, nil
+}
+
+// snapshotSyncChanges returns a creation for every stored workhour, and the last
+// history entry they include
+func snapshotSyncChanges(replica string) ([]syncChange, int, error) {
+	var lastID int
+	if err := db.QueryRow("SELECT COALESCE(MAX(id), 0) FROM workhour_history").Scan(&lastID); err != nil {
+		return nil, 0, fmt.Errorf("failed to query workhour history: %w", err)
+	}
+
+	workhours, err := GetAllWorkhours()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	changes := make([]syncChange, 0, len(workhours))
+	now := time.Now().UnixNano()
+	for _, wh := range workhours {
+		var changedAt int64
+		err := db.QueryRow("SELECT COALESCE(MAX(changed_at), ?) FROM workhour_history WHERE workhour_uuid = ?", now, wh.UUID).Scan(&changedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get last change of workhour: %w", err)
+		}
+		changes = append(changes, syncChange{
+			Replica:   replica,
+			UUID:      wh.UUID,
+			Action:    domain.ChangeCreate,
+			New:       snapshotOf(&wh),
+			ChangedAt: changedAt,
+		})
+	}
+
+	return changes, lastID, nil
+}
+
+func appendSyncLog(path string, changes []syncChange) error {
+	var sb strings.Builder
+	for _, change := range changes {
+		line, err := json.Marshal(change)
+		if err != nil {
+			return fmt.Errorf("failed to encode change: %w", err)
+		}
+		sb.Write(line)
+		sb.WriteString("\n")
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open sync log: %w", err)
+	}
+	if _, err := file.WriteString(sb.String()); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write sync log: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write sync log: %w", err)
+	}
+	return nil
+}
+
+func workhourByUUID(tx *sql.Tx, workhourUUID string) (*domain.Workhour, error) {
+	wh, err := scanWorkhour(tx.QueryRow("SELECT "+workhourColumns+" FROM workhours WHERE uuid = ?", workhourUUID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+func decodeConflictValue(value sql.NullString, workhourUUID string) (*domain.Workhour, error) {
+	snapshot, err := decodeSnapshot(value)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return snapshot.workhour(0, workhourUUID)
+}
+
+// backfillWorkhourUUIDs gives workhours stored before sync existed a UUID derived
+// from their content, so copies of one database agree on them
+func backfillWorkhourUUIDs() error {
+	rows, err := db.Query("SELECT id, date, details_id, project_id, hours FROM workhours WHERE uuid IS NULL")
+	if err != nil {
+		return fmt.Errorf("failed to query workhours: %w", err)
+	}
+	defer rows.Close()
+
+	uuids := map[int]string{}
+	for rows.Next() {
+		var id, detailsID, projectID int
+		var date string
+		var hours float64
+		if err := rows.Scan(&id, &date, &detailsID, &projectID, &hours); err != nil {
+			return fmt.Errorf("failed to scan workhour: %w", err)
+		}
+		key := fmt.Sprintf("%d|%s|%d|%d|%g", id, date, detailsID, projectID, hours)
+		uuids[id] = uuid.NewSHA1(workhourUUIDNamespace, []byte(key)).String()
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating workhours: %w", err)
+	}
+	rows.Close()
+
+	for id, value := range uuids {
+		if _, err := db.Exec("UPDATE workhours SET uuid = ? WHERE id = ?", value, id); err != nil {
+			return fmt.Errorf("failed to set workhour uuid: %w", err)
+		}
+	}
+
+	statements := []string{
+		`UPDATE workhour_history SET workhour_uuid = (SELECT uuid FROM workhours WHERE workhours.id = workhour_history.workhour_id)
+		WHERE workhour_uuid IS NULL AND workhour_id IN (SELECT id FROM workhours)`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_workhours_uuid ON workhours(uuid)",
+		"CREATE INDEX IF NOT EXISTS idx_workhour_history_uuid ON workhour_history(workhour_uuid)",
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to migrate workhour uuids: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"tltui/src/domain"
+)
+
+func GetAllTasks() ([]domain.Task, error) {
+	rows, err := db.Query("SELECT id, project_id, name, odoo_id FROM tasks ORDER BY project_id, name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTasks(rows)
+}
+
+func GetTasksByProject(projectID int) ([]domain.Task, error) {
+	rows, err := db.Query(
+		"SELECT id, project_id, name, odoo_id FROM tasks WHERE project_id = ? ORDER BY name",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks by project: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTasks(rows)
+}
+
+func GetTaskByID(id int) (*domain.Task, error) {
+	var task domain.Task
+	err := db.QueryRow("SELECT id, project_id, name, odoo_id FROM tasks WHERE id = ?", id).
+		Scan(&task.ID, &task.ProjectID, &task.Name, &task.OdooID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return &task, nil
+}
+
+func CreateTask(task domain.Task) (int, error) {
+	result, err := db.Exec(
+		"INSERT INTO tasks (project_id, name, odoo_id) VALUES (?, ?, ?)",
+		task.ProjectID, task.Name, task.OdooID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return int(id), nil
+}
+
+func UpdateTask(task domain.Task) error {
+	result, err := db.Exec(
+		"UPDATE tasks SET project_id = ?, name = ?, odoo_id = ? WHERE id = ?",
+		task.ProjectID, task.Name, task.OdooID, task.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("task not found")
+	}
+
+	return nil
+}
+
+// DeleteTask removes a task. The hours logged on it stay on its project without a
+// task; they are updated like single entries, so none of them may be in a locked month.
+func DeleteTask(id int) error {
+	workhours, err := getWorkhoursBy("task_id", id)
+	if err != nil {
+		return err
+	}
+	if err := checkDatesUnlocked(workhourDates(workhours)...); err != nil {
+		return err
+	}
+
+	return withTx(func(tx *sql.Tx) error {
+		for _, old := range workhours {
+			updated := old
+			updated.TaskID = 0
+			if err := updateWorkhourRow(tx, updated); err != nil {
+				return err
+			}
+			if err := recordWorkhourChange(tx, old.ID, domain.ChangeUpdate, &old, &updated); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("task not found")
+		}
+
+		return nil
+	})
+}
+
+func scanTasks(rows *sql.Rows) ([]domain.Task, error) {
+	var tasks []domain.Task
+	for rows.Next() {
+		var task domain.Task
+		if err := rows.Scan(&task.ID, &task.ProjectID, &task.Name, &task.OdooID); err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetTaskHours returns the total logged hours of each task of a project
+func GetTaskHours(projectID int) (map[int]float64, error) {
+	rows, err := db.Query(
+		"SELECT task_id, SUM(hours) FROM workhours WHERE project_id = ? AND task_id IS NOT NULL GROUP BY task_id",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task hours: %w", err)
+	}
+	defer rows.Close()
+
+	hours := make(map[int]float64)
+	for rows.Next() {
+		var taskID int
+		var total float64
+		if err := rows.Scan(&taskID, &total); err != nil {
+			return nil, fmt.Errorf("failed to scan task hours: %w", err)
+		}
+		hours[taskID] = total
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task hours: %w", err)
+	}
+
+	return hours, nil
+}
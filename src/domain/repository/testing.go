@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 	"time"
 	"tltui/src/domain"
@@ -28,6 +31,36 @@ func CreateTestProject(t *testing.T, id int, name string, odooID int) domain.Pro
 	return p
 }
 
+// CreateTestClient creates a client for testing
+func CreateTestClient(t *testing.T, name, legalName string) domain.Client {
+	c := domain.Client{
+		Name:      name,
+		LegalName: legalName,
+		Language:  "ro",
+	}
+	id, err := CreateClient(c)
+	if err != nil {
+		t.Fatalf("failed to create test client: %v", err)
+	}
+	c.ID = id
+	return c
+}
+
+// CreateTestTask creates a task for testing
+func CreateTestTask(t *testing.T, projectID int, name string, odooID int) domain.Task {
+	task := domain.Task{
+		ProjectID: projectID,
+		Name:      name,
+		OdooID:    odooID,
+	}
+	id, err := CreateTask(task)
+	if err != nil {
+		t.Fatalf("failed to create test task: %v", err)
+	}
+	task.ID = id
+	return task
+}
+
 // CreateTestWorkhourDetails creates workhour details for testing
 func CreateTestWorkhourDetails(t *testing.T, id int, name, shortName string, isWork bool) domain.WorkhourDetails {
 	wd := domain.WorkhourDetails{
@@ -50,10 +83,59 @@ func CreateTestWorkhour(t *testing.T, date time.Time, detailsID, projectID int,
 		ProjectID: projectID,
 		Hours:     hours,
 	}
-	id, err := CreateWorkhour(wh)
+	id, _, err := CreateWorkhour(wh)
 	if err != nil {
 		t.Fatalf("failed to create test workhour: %v", err)
 	}
 	wh.ID = id
 	return wh
 }
+
+// CreateTestLeaveEntitlement creates a leave entitlement for testing
+func CreateTestLeaveEntitlement(t *testing.T, year, detailsID int, allowanceDays, carryOverDays float64, carryOverExpiry *time.Time) domain.LeaveEntitlement {
+	e := domain.LeaveEntitlement{
+		Year:            year,
+		DetailsID:       detailsID,
+		AllowanceDays:   allowanceDays,
+		CarryOverDays:   carryOverDays,
+		CarryOverExpiry: carryOverExpiry,
+	}
+	id, err := CreateLeaveEntitlement(e)
+	if err != nil {
+		t.Fatalf("failed to create test leave entitlement: %v", err)
+	}
+	e.ID = id
+	return e
+}
+
+// CreateTestGitRepo initializes a git repository in a temporary directory, with
+// email as its user.email, and returns its path
+func CreateTestGitRepo(t *testing.T, email string) string {
+	dir := t.TempDir()
+	runTestGit(t, dir, nil, "init", "--quiet")
+	runTestGit(t, dir, nil, "config", "user.email", email)
+	runTestGit(t, dir, nil, "config", "user.name", "Test")
+	return dir
+}
+
+// CreateTestGitCommit commits an empty change to a test repository, authored at
+// the given time by email
+func CreateTestGitCommit(t *testing.T, dir, email string, at time.Time, subject string) {
+	date := at.Format(time.RFC3339)
+	env := []string{
+		"GIT_AUTHOR_EMAIL=" + email,
+		"GIT_AUTHOR_DATE=" + date,
+		"GIT_COMMITTER_DATE=" + date,
+	}
+	runTestGit(t, dir, env, "commit", "--quiet", "--allow-empty", "--no-gpg-sign", "-m", subject)
+}
+
+func runTestGit(t *testing.T, dir string, env []string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	// Keep the user's global git configuration out of the tests
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL="+os.DevNull, "GIT_CONFIG_NOSYSTEM=1")
+	cmd.Env = append(cmd.Env, env...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+}
@@ -25,6 +25,7 @@ func InitTestDB(t *testing.T) {
 
 	// Set the global db to test DB
 	db = testDB
+	currentProfile = DefaultProfile
 
 	// Create schema
 	if err := createSchema(); err != nil {
@@ -41,7 +42,7 @@ func CleanupTestDB(t *testing.T) {
 
 // ClearTestData removes all data from test tables
 func ClearTestData(t *testing.T) {
-	tables := []string{"workhours", "projects", "workhour_details"}
+	tables := []string{"sent_emails", "sync_conflicts", "sync_positions", "report_history", "workhour_history", "month_locks", "recent_selections", "leave_entitlements", "settings", "workhour_exports", "workhours", "git_repos", "tasks", "projects", "clients", "workhour_details"}
 	for _, table := range tables {
 		_, err := testDB.Exec("DELETE FROM " + table)
 		if err != nil {
@@ -1,13 +1,23 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 	"tltui/src/domain"
+
+	"github.com/google/uuid"
 )
 
+const workhourColumns = "id, date, details_id, project_id, task_id, hours, start_time, end_time, break_minutes, uuid, issue_key"
+
 func GetAllWorkhours() ([]domain.Workhour, error) {
-	rows, err := db.Query("SELECT id, date, details_id, project_id, hours FROM workhours ORDER BY date DESC")
+	return getAllWorkhours(db)
+}
+
+func getAllWorkhours(q querier) ([]domain.Workhour, error) {
+	rows, err := q.Query("SELECT " + workhourColumns + " FROM workhours ORDER BY date DESC")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query workhours: %w", err)
 	}
@@ -15,15 +25,9 @@ func GetAllWorkhours() ([]domain.Workhour, error) {
 
 	var workhours []domain.Workhour
 	for rows.Next() {
-		var wh domain.Workhour
-		var dateStr string
-		if err := rows.Scan(&wh.ID, &dateStr, &wh.DetailsID, &wh.ProjectID, &wh.Hours); err != nil {
-			return nil, fmt.Errorf("failed to scan workhour: %w", err)
-		}
-
-		wh.Date, err = StringToDate(dateStr)
+		wh, err := scanWorkhour(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
+			return nil, err
 		}
 
 		workhours = append(workhours, wh)
@@ -37,9 +41,13 @@ func GetAllWorkhours() ([]domain.Workhour, error) {
 }
 
 func GetWorkhoursByDate(date time.Time) ([]domain.Workhour, error) {
+	return getWorkhoursByDate(db, date)
+}
+
+func getWorkhoursByDate(q querier, date time.Time) ([]domain.Workhour, error) {
 	dateStr := DateToString(date)
-	rows, err := db.Query(
-		"SELECT id, date, details_id, project_id, hours FROM workhours WHERE date = ? ORDER BY id",
+	rows, err := q.Query(
+		"SELECT "+workhourColumns+" FROM workhours WHERE date = ? ORDER BY id",
 		dateStr,
 	)
 	if err != nil {
@@ -49,15 +57,9 @@ func GetWorkhoursByDate(date time.Time) ([]domain.Workhour, error) {
 
 	var workhours []domain.Workhour
 	for rows.Next() {
-		var wh domain.Workhour
-		var dbDateStr string
-		if err := rows.Scan(&wh.ID, &dbDateStr, &wh.DetailsID, &wh.ProjectID, &wh.Hours); err != nil {
-			return nil, fmt.Errorf("failed to scan workhour: %w", err)
-		}
-
-		wh.Date, err = StringToDate(dbDateStr)
+		wh, err := scanWorkhour(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
+			return nil, err
 		}
 
 		workhours = append(workhours, wh)
@@ -71,11 +73,15 @@ func GetWorkhoursByDate(date time.Time) ([]domain.Workhour, error) {
 }
 
 func GetWorkhoursByDateRange(start, end time.Time) ([]domain.Workhour, error) {
+	return getWorkhoursByDateRange(db, start, end)
+}
+
+func getWorkhoursByDateRange(q querier, start, end time.Time) ([]domain.Workhour, error) {
 	startStr := DateToString(start)
 	endStr := DateToString(end)
 
-	rows, err := db.Query(
-		"SELECT id, date, details_id, project_id, hours FROM workhours WHERE date BETWEEN ? AND ? ORDER BY date",
+	rows, err := q.Query(
+		"SELECT "+workhourColumns+" FROM workhours WHERE date BETWEEN ? AND ? ORDER BY date",
 		startStr, endStr,
 	)
 	if err != nil {
@@ -85,15 +91,9 @@ func GetWorkhoursByDateRange(start, end time.Time) ([]domain.Workhour, error) {
 
 	var workhours []domain.Workhour
 	for rows.Next() {
-		var wh domain.Workhour
-		var dateStr string
-		if err := rows.Scan(&wh.ID, &dateStr, &wh.DetailsID, &wh.ProjectID, &wh.Hours); err != nil {
-			return nil, fmt.Errorf("failed to scan workhour: %w", err)
-		}
-
-		wh.Date, err = StringToDate(dateStr)
+		wh, err := scanWorkhour(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
+			return nil, err
 		}
 
 		workhours = append(workhours, wh)
@@ -106,69 +106,343 @@ func GetWorkhoursByDateRange(start, end time.Time) ([]domain.Workhour, error) {
 	return workhours, nil
 }
 
-func CreateWorkhour(workhour domain.Workhour) (int, error) {
-	dateStr := DateToString(workhour.Date)
+func GetWorkhourByID(id int) (*domain.Workhour, error) {
+	return getWorkhourByID(db, id)
+}
 
-	result, err := db.Exec(
-		"INSERT INTO workhours (date, details_id, project_id, hours) VALUES (?, ?, ?, ?)",
-		dateStr, workhour.DetailsID, workhour.ProjectID, workhour.Hours,
-	)
+func getWorkhourByID(q rowQuerier, id int) (*domain.Workhour, error) {
+	wh, err := scanWorkhour(q.QueryRow("SELECT "+workhourColumns+" FROM workhours WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
-		return 0, fmt.Errorf("failed to create workhour: %w", err)
+		return nil, err
 	}
+	return &wh, nil
+}
 
-	id, err := result.LastInsertId()
+// CreateWorkhour stores a workhour. Entries with start and end times get their
+// hours computed from them and must not overlap other timed entries of the day.
+// Changes breaking a blocking rule are rejected with domain.ErrRuleBlocked,
+// and changes in locked months with domain.ErrMonthLocked. The warnings of the
+// project budgets the entry made cross a threshold are returned with its ID.
+// The checks run in the transaction of the write, on the data it commits.
+func CreateWorkhour(workhour domain.Workhour) (int, []domain.BudgetWarning, error) {
+	var id int
+	err := withTx(func(tx *sql.Tx) error {
+		if err := checkDatesUnlockedIn(tx, workhour.Date); err != nil {
+			return err
+		}
+		var err error
+		if workhour, err = prepareTimedWorkhour(tx, workhour); err != nil {
+			return err
+		}
+		if id, err = createWorkhour(tx, workhour); err != nil {
+			return err
+		}
+		return checkDayRules(tx, workhour.Date)
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+		return 0, nil, err
 	}
 
-	return int(id), nil
+	// The entry is stored, so a failing budget lookup only loses the warnings
+	warnings, _ := budgetWarnings(nil, []domain.Workhour{workhour})
+	return id, warnings, nil
 }
 
-func UpdateWorkhour(id int, workhour domain.Workhour) error {
-	dateStr := DateToString(workhour.Date)
-
-	result, err := db.Exec(
-		"UPDATE workhours SET date = ?, details_id = ?, project_id = ?, hours = ? WHERE id = ?",
-		dateStr, workhour.DetailsID, workhour.ProjectID, workhour.Hours, id,
-	)
+// UpdateWorkhour replaces a stored workhour, checked like CreateWorkhour. The rules
+// are checked for the day the entry leaves as well. Budget warnings count the change
+// from the stored entry, wherever it is moved to.
+func UpdateWorkhour(id int, workhour domain.Workhour) ([]domain.BudgetWarning, error) {
+	var old *domain.Workhour
+	err := withTx(func(tx *sql.Tx) error {
+		var err error
+		if old, err = getWorkhourByID(tx, id); err != nil {
+			return err
+		}
+		if old == nil {
+			return fmt.Errorf("workhour not found")
+		}
+		if err := checkDatesUnlockedIn(tx, old.Date, workhour.Date); err != nil {
+			return err
+		}
+		workhour.ID = id
+		workhour.UUID = old.UUID
+		if workhour, err = prepareTimedWorkhour(tx, workhour); err != nil {
+			return err
+		}
+		if err := updateWorkhourRow(tx, workhour); err != nil {
+			return err
+		}
+		if err := recordWorkhourChange(tx, id, domain.ChangeUpdate, old, &workhour); err != nil {
+			return err
+		}
+		return checkDayRules(tx, old.Date, workhour.Date)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update workhour: %w", err)
+		return nil, err
 	}
 
-	rows, err := result.RowsAffected()
+	warnings, _ := budgetWarnings([]domain.Workhour{*old}, []domain.Workhour{workhour})
+	return warnings, nil
+}
+
+func DeleteWorkhour(id int) error {
+	if err := checkWorkhourUnlocked(id); err != nil {
+		return err
+	}
+	old, err := GetWorkhourByID(id)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return err
 	}
-	if rows == 0 {
+	if old == nil {
 		return fmt.Errorf("workhour not found")
 	}
 
-	return nil
+	return withTx(func(tx *sql.Tx) error {
+		return deleteWorkhour(tx, *old)
+	})
 }
 
-func DeleteWorkhour(id int) error {
-	result, err := db.Exec("DELETE FROM workhours WHERE id = ?", id)
+// ReplaceWorkhoursForDate swaps the entries of a date for the given ones in one
+// transaction. The rules are checked against the resulting day as a whole, and
+// the budget warnings against the hours the day gained or lost per project.
+func ReplaceWorkhoursForDate(date time.Time, workhours []domain.Workhour) ([]domain.BudgetWarning, error) {
+	day := make([]domain.Workhour, len(workhours))
+	for i, wh := range workhours {
+		wh.ID = 0
+		wh.Date = date
+		if err := wh.ValidateTimes(); err != nil {
+			return nil, err
+		}
+		if wh.HasTimes() {
+			wh.Hours = wh.TimedHours()
+		}
+		if other := domain.FindOverlap(wh, day[:i]); other != nil {
+			return nil, fmt.Errorf("%w (%s)", domain.ErrOverlappingTimes, other.TimeRange())
+		}
+		day[i] = wh
+	}
+
+	var existing []domain.Workhour
+	err := withTx(func(tx *sql.Tx) error {
+		if err := checkDatesUnlockedIn(tx, date); err != nil {
+			return err
+		}
+		var err error
+		if existing, err = getWorkhoursByDate(tx, date); err != nil {
+			return err
+		}
+		for _, wh := range existing {
+			if err := deleteWorkhour(tx, wh); err != nil {
+				return err
+			}
+		}
+		for _, wh := range day {
+			if _, err := createWorkhour(tx, wh); err != nil {
+				return err
+			}
+		}
+		return checkDayRules(tx, date)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete workhour: %w", err)
+		return nil, err
 	}
 
-	rows, err := result.RowsAffected()
+	warnings, _ := budgetWarnings(existing, day)
+	return warnings, nil
+}
+
+func DeleteWorkhoursByDate(date time.Time) error {
+	if err := checkDatesUnlocked(date); err != nil {
+		return err
+	}
+	existing, err := GetWorkhoursByDate(date)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return err
 	}
-	if rows == 0 {
-		return fmt.Errorf("workhour not found")
+
+	return withTx(func(tx *sql.Tx) error {
+		for _, wh := range existing {
+			if err := deleteWorkhour(tx, wh); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getWorkhoursBy returns the workhours referencing id in column, e.g. project_id, by date
+func getWorkhoursBy(column string, id int) ([]domain.Workhour, error) {
+	rows, err := db.Query("SELECT "+workhourColumns+" FROM workhours WHERE "+column+" = ? ORDER BY date", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workhours: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	var workhours []domain.Workhour
+	for rows.Next() {
+		wh, err := scanWorkhour(rows)
+		if err != nil {
+			return nil, err
+		}
+		workhours = append(workhours, wh)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workhours: %w", err)
+	}
+
+	return workhours, nil
 }
 
-func DeleteWorkhoursByDate(date time.Time) error {
-	dateStr := DateToString(date)
-	_, err := db.Exec("DELETE FROM workhours WHERE date = ?", dateStr)
+// workhourDates returns the dates of the workhours, e.g. for checkDatesUnlocked
+func workhourDates(workhours []domain.Workhour) []time.Time {
+	dates := make([]time.Time, len(workhours))
+	for i, wh := range workhours {
+		dates[i] = wh.Date
+	}
+	return dates
+}
+
+func scanWorkhour(row rowScanner) (domain.Workhour, error) {
+	var wh domain.Workhour
+	var dateStr string
+	var taskID, startTime, endTime sql.NullInt64
+	var uuidStr, issueKey sql.NullString
+	if err := row.Scan(&wh.ID, &dateStr, &wh.DetailsID, &wh.ProjectID, &taskID, &wh.Hours, &startTime, &endTime, &wh.BreakMinutes, &uuidStr, &issueKey); err != nil {
+		return wh, fmt.Errorf("failed to scan workhour: %w", err)
+	}
+
+	date, err := StringToDate(dateStr)
+	if err != nil {
+		return wh, fmt.Errorf("failed to parse date: %w", err)
+	}
+	wh.Date = date
+	wh.TaskID = int(taskID.Int64)
+	wh.Start = domain.ClockTime(startTime.Int64)
+	wh.End = domain.ClockTime(endTime.Int64)
+	wh.UUID = uuidStr.String
+	wh.IssueKey = issueKey.String
+
+	return wh, nil
+}
+
+// prepareTimedWorkhour validates the times of a workhour, derives its hours from
+// them and rejects overlaps with the other entries of the same day
+func prepareTimedWorkhour(q querier, workhour domain.Workhour) (domain.Workhour, error) {
+	if err := workhour.ValidateTimes(); err != nil {
+		return workhour, err
+	}
+	if !workhour.HasTimes() {
+		return workhour, nil
+	}
+	workhour.Hours = workhour.TimedHours()
+
+	sameDay, err := getWorkhoursByDate(q, workhour.Date)
+	if err != nil {
+		return workhour, err
+	}
+	if other := domain.FindOverlap(workhour, sameDay); other != nil {
+		return workhour, fmt.Errorf("%w (%s)", domain.ErrOverlappingTimes, other.TimeRange())
+	}
+
+	return workhour, nil
+}
+
+// checkWorkhourUnlocked rejects changes to a stored workhour in a locked month, and
+// moves of it into one of the given dates' locked months
+func checkWorkhourUnlocked(id int, newDates ...time.Time) error {
+	stored, err := GetWorkhourByID(id)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		newDates = append(newDates, stored.Date)
+	}
+	return checkDatesUnlocked(newDates...)
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// createWorkhour inserts a workhour under a new UUID and records its creation in the history
+func createWorkhour(exec execer, workhour domain.Workhour) (int, error) {
+	workhour.UUID = uuid.NewString()
+	id, err := insertWorkhourRow(exec, workhour)
 	if err != nil {
-		return fmt.Errorf("failed to delete workhours by date: %w", err)
+		return 0, err
+	}
+	workhour.ID = id
+
+	if err := recordWorkhourChange(exec, workhour.ID, domain.ChangeCreate, nil, &workhour); err != nil {
+		return 0, err
+	}
+	return workhour.ID, nil
+}
+
+func insertWorkhourRow(exec execer, workhour domain.Workhour) (int, error) {
+	result, err := exec.Exec(
+		"INSERT INTO workhours (date, details_id, project_id, task_id, hours, start_time, end_time, break_minutes, uuid, issue_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		DateToString(workhour.Date), workhour.DetailsID, workhour.ProjectID, nullableID(workhour.TaskID), workhour.Hours,
+		nullableClockTime(workhour, workhour.Start), nullableClockTime(workhour, workhour.End), workhour.BreakMinutes, workhour.UUID,
+		nullableString(workhour.IssueKey),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create workhour: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return int(id), nil
+}
+
+func updateWorkhourRow(exec execer, workhour domain.Workhour) error {
+	_, err := exec.Exec(
+		"UPDATE workhours SET date = ?, details_id = ?, project_id = ?, task_id = ?, hours = ?, start_time = ?, end_time = ?, break_minutes = ?, issue_key = ? WHERE id = ?",
+		DateToString(workhour.Date), workhour.DetailsID, workhour.ProjectID, nullableID(workhour.TaskID), workhour.Hours,
+		nullableClockTime(workhour, workhour.Start), nullableClockTime(workhour, workhour.End), workhour.BreakMinutes,
+		nullableString(workhour.IssueKey), workhour.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update workhour: %w", err)
 	}
 	return nil
 }
+
+// deleteWorkhour removes a stored workhour and records its deletion in the history
+func deleteWorkhour(exec execer, workhour domain.Workhour) error {
+	if _, err := exec.Exec("DELETE FROM workhours WHERE id = ?", workhour.ID); err != nil {
+		return fmt.Errorf("failed to delete workhour: %w", err)
+	}
+	return recordWorkhourChange(exec, workhour.ID, domain.ChangeDelete, &workhour, nil)
+}
+
+// nullableClockTime stores the times of entries without start and end times as NULL
+func nullableClockTime(workhour domain.Workhour, t domain.ClockTime) any {
+	if !workhour.HasTimes() {
+		return nil
+	}
+	return int(t)
+}
+
+// nullableID stores unset (zero) references as NULL
+func nullableID(id int) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// nullableString stores empty strings as NULL
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -7,7 +7,11 @@ import (
 )
 
 func GetAllWorkhourDetailsFromDB() ([]domain.WorkhourDetails, error) {
-	rows, err := db.Query("SELECT id, name, short_name, is_work FROM workhour_details ORDER BY id")
+	return getAllWorkhourDetails(db)
+}
+
+func getAllWorkhourDetails(q querier) ([]domain.WorkhourDetails, error) {
+	rows, err := q.Query("SELECT id, name, short_name, is_work, overtime_role FROM workhour_details ORDER BY id")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query workhour details: %w", err)
 	}
@@ -16,7 +20,7 @@ func GetAllWorkhourDetailsFromDB() ([]domain.WorkhourDetails, error) {
 	var details []domain.WorkhourDetails
 	for rows.Next() {
 		var d domain.WorkhourDetails
-		if err := rows.Scan(&d.ID, &d.Name, &d.ShortName, &d.IsWork); err != nil {
+		if err := rows.Scan(&d.ID, &d.Name, &d.ShortName, &d.IsWork, &d.OvertimeRole); err != nil {
 			return nil, fmt.Errorf("failed to scan workhour details: %w", err)
 		}
 		details = append(details, d)
@@ -31,8 +35,8 @@ func GetAllWorkhourDetailsFromDB() ([]domain.WorkhourDetails, error) {
 
 func GetWorkhourDetailsByID(id int) (*domain.WorkhourDetails, error) {
 	var d domain.WorkhourDetails
-	err := db.QueryRow("SELECT id, name, short_name, is_work FROM workhour_details WHERE id = ?", id).
-		Scan(&d.ID, &d.Name, &d.ShortName, &d.IsWork)
+	err := db.QueryRow("SELECT id, name, short_name, is_work, overtime_role FROM workhour_details WHERE id = ?", id).
+		Scan(&d.ID, &d.Name, &d.ShortName, &d.IsWork, &d.OvertimeRole)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -46,8 +50,8 @@ func GetWorkhourDetailsByID(id int) (*domain.WorkhourDetails, error) {
 
 func CreateWorkhourDetails(details domain.WorkhourDetails) error {
 	_, err := db.Exec(
-		"INSERT INTO workhour_details (id, name, short_name, is_work) VALUES (?, ?, ?, ?)",
-		details.ID, details.Name, details.ShortName, details.IsWork,
+		"INSERT INTO workhour_details (id, name, short_name, is_work, overtime_role) VALUES (?, ?, ?, ?, ?)",
+		details.ID, details.Name, details.ShortName, details.IsWork, details.OvertimeRole,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create workhour details: %w", err)
@@ -57,8 +61,8 @@ func CreateWorkhourDetails(details domain.WorkhourDetails) error {
 
 func UpdateWorkhourDetails(details domain.WorkhourDetails) error {
 	result, err := db.Exec(
-		"UPDATE workhour_details SET name = ?, short_name = ?, is_work = ? WHERE id = ?",
-		details.Name, details.ShortName, details.IsWork, details.ID,
+		"UPDATE workhour_details SET name = ?, short_name = ?, is_work = ?, overtime_role = ? WHERE id = ?",
+		details.Name, details.ShortName, details.IsWork, details.OvertimeRole, details.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update workhour details: %w", err)
@@ -75,21 +79,39 @@ func UpdateWorkhourDetails(details domain.WorkhourDetails) error {
 	return nil
 }
 
+// DeleteWorkhourDetails removes a workhour type with the hours logged under it,
+// checked and recorded like DeleteProject
 func DeleteWorkhourDetails(id int) error {
-	result, err := db.Exec("DELETE FROM workhour_details WHERE id = ?", id)
+	workhours, err := getWorkhoursBy("details_id", id)
 	if err != nil {
-		return fmt.Errorf("failed to delete workhour details: %w", err)
+		return err
 	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("workhour details not found")
+	if err := checkDatesUnlocked(workhourDates(workhours)...); err != nil {
+		return err
 	}
 
-	return nil
+	return withTx(func(tx *sql.Tx) error {
+		for _, wh := range workhours {
+			if err := deleteWorkhour(tx, wh); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.Exec("DELETE FROM workhour_details WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete workhour details: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("workhour details not found")
+		}
+
+		return nil
+	})
 }
 
 func SeedWorkhourDetails() error {
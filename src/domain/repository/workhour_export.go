@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+	"tltui/src/domain"
+)
+
+// Issue tracker connections of the timesheet sinks. Their API tokens are read from
+// the environment and never stored.
+const (
+	SettingJiraURL   = "jira_url"
+	SettingJiraEmail = "jira_email"
+	SettingGitLabURL = "gitlab_url"
+)
+
+const workhourExportColumns = "workhour_id, sink, remote_id, issue_key, date, hours, pushed_at"
+
+// GetWorkhourExports returns the workhours pushed to a sink, by workhour ID
+func GetWorkhourExports(sink string) (map[int]domain.WorkhourExport, error) {
+	list, err := queryWorkhourExports("SELECT "+workhourExportColumns+" FROM workhour_exports WHERE sink = ?", sink)
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make(map[int]domain.WorkhourExport)
+	for _, export := range list {
+		exports[export.WorkhourID] = export
+	}
+	return exports, nil
+}
+
+// GetDeletedWorkhourExports returns the pushes to a sink between start and end whose
+// workhour was deleted since. Exports are kept when their workhour is deleted, so that
+// the remote entry can be retracted.
+func GetDeletedWorkhourExports(sink string, start, end time.Time) ([]domain.WorkhourExport, error) {
+	return queryWorkhourExports(
+		"SELECT "+workhourExportColumns+` FROM workhour_exports
+		WHERE sink = ? AND date BETWEEN ? AND ? AND workhour_id NOT IN (SELECT id FROM workhours)
+		ORDER BY date, workhour_id`,
+		sink, DateToString(start), DateToString(end),
+	)
+}
+
+func queryWorkhourExports(query string, args ...any) ([]domain.WorkhourExport, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workhour exports: %w", err)
+	}
+	defer rows.Close()
+
+	var exports []domain.WorkhourExport
+	for rows.Next() {
+		var export domain.WorkhourExport
+		var dateStr string
+		var pushedAt int64
+		if err := rows.Scan(&export.WorkhourID, &export.Sink, &export.RemoteID, &export.IssueKey, &dateStr, &export.Hours, &pushedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workhour export: %w", err)
+		}
+		if export.Date, err = StringToDate(dateStr); err != nil {
+			return nil, fmt.Errorf("failed to parse date: %w", err)
+		}
+		export.PushedAt = time.Unix(pushedAt, 0)
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workhour exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// SaveWorkhourExport records a push of a workhour, replacing its previous push to the same sink
+func SaveWorkhourExport(export domain.WorkhourExport) error {
+	if export.PushedAt.IsZero() {
+		export.PushedAt = time.Now()
+	}
+
+	_, err := db.Exec(
+		`INSERT INTO workhour_exports (`+workhourExportColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(workhour_id, sink) DO UPDATE SET remote_id = excluded.remote_id, issue_key = excluded.issue_key,
+		date = excluded.date, hours = excluded.hours, pushed_at = excluded.pushed_at`,
+		export.WorkhourID, export.Sink, export.RemoteID, export.IssueKey, DateToString(export.Date), export.Hours, export.PushedAt.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save workhour export: %w", err)
+	}
+	return nil
+}
+
+// DeleteWorkhourExport forgets the push of a workhour to a sink, once its remote entry is retracted
+func DeleteWorkhourExport(workhourID int, sink string) error {
+	if _, err := db.Exec("DELETE FROM workhour_exports WHERE workhour_id = ? AND sink = ?", workhourID, sink); err != nil {
+		return fmt.Errorf("failed to delete workhour export: %w", err)
+	}
+	return nil
+}
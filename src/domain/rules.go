@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRuleBlocked is returned when a change violates a rule set to block
+var ErrRuleBlocked = errors.New("blocked by rule")
+
+// RuleKind identifies a validation rule
+type RuleKind string
+
+const (
+	RuleMaxDailyHours   RuleKind = "max_daily_hours"   // Total hours of a day must not exceed the limit
+	RuleMaxWeeklyHours  RuleKind = "max_weekly_hours"  // Total hours of a Monday-Sunday week must not exceed the limit
+	RuleNoWorkOnLeave   RuleKind = "no_work_on_leave"  // No work entries on days fully covered by non-work entries
+	RuleNonWorkFullDay  RuleKind = "non_work_full_day" // Non-work entries of a day must total exactly the daily target
+	RuleNoFutureEntries RuleKind = "no_future_entries" // No entries after today
+)
+
+// RuleKinds lists every rule in display order
+var RuleKinds = []RuleKind{RuleMaxDailyHours, RuleMaxWeeklyHours, RuleNoWorkOnLeave, RuleNonWorkFullDay, RuleNoFutureEntries}
+
+// RuleSeverity decides what happens when a rule is violated
+type RuleSeverity int
+
+const (
+	RuleOff   RuleSeverity = iota
+	RuleWarn               // The change is saved with a warning
+	RuleBlock              // The change is rejected
+)
+
+func (s RuleSeverity) String() string {
+	switch s {
+	case RuleWarn:
+		return "warn"
+	case RuleBlock:
+		return "block"
+	default:
+		return "off"
+	}
+}
+
+// Rule is a configured validation rule. Limit is only used by the max hours rules.
+type Rule struct {
+	Kind     RuleKind
+	Severity RuleSeverity
+	Limit    float64
+}
+
+// HasLimit reports whether the rule kind takes an hours limit
+func (k RuleKind) HasLimit() bool {
+	return k == RuleMaxDailyHours || k == RuleMaxWeeklyHours
+}
+
+// DefaultRules returns the rules used when none are configured
+func DefaultRules() []Rule {
+	return []Rule{
+		{Kind: RuleMaxDailyHours, Severity: RuleWarn, Limit: 16},
+		{Kind: RuleMaxWeeklyHours, Severity: RuleWarn, Limit: 60},
+		{Kind: RuleNoWorkOnLeave, Severity: RuleWarn},
+		{Kind: RuleNonWorkFullDay, Severity: RuleWarn},
+		{Kind: RuleNoFutureEntries, Severity: RuleOff},
+	}
+}
+
+// SettingValue formats the rule as stored in settings, e.g. "block 16" or "warn"
+func (r Rule) SettingValue() string {
+	if r.Kind.HasLimit() {
+		return fmt.Sprintf("%s %g", r.Severity, r.Limit)
+	}
+	return r.Severity.String()
+}
+
+// ParseRule reads a rule from its setting value, e.g. "block 16", "warn" or "off"
+func ParseRule(kind RuleKind, value string) (Rule, error) {
+	rule := Rule{Kind: kind}
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 {
+		return rule, fmt.Errorf("rule %s is empty", kind)
+	}
+
+	switch fields[0] {
+	case "off":
+		rule.Severity = RuleOff
+	case "warn":
+		rule.Severity = RuleWarn
+	case "block":
+		rule.Severity = RuleBlock
+	default:
+		return rule, fmt.Errorf("rule %s must start with off, warn or block", kind)
+	}
+
+	if !kind.HasLimit() {
+		if len(fields) > 1 {
+			return rule, fmt.Errorf("rule %s takes no limit", kind)
+		}
+		return rule, nil
+	}
+
+	if rule.Severity == RuleOff && len(fields) == 1 {
+		return rule, nil
+	}
+	if len(fields) != 2 {
+		return rule, fmt.Errorf("rule %s needs a limit in hours, e.g. \"%s 12\"", kind, fields[0])
+	}
+	limit, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || limit <= 0 || math.IsInf(limit, 0) {
+		return rule, fmt.Errorf("rule %s needs a positive limit", kind)
+	}
+	rule.Limit = limit
+	return rule, nil
+}
+
+// RuleViolation is a rule broken on a date. Weekly rules report the Monday of the week.
+type RuleViolation struct {
+	Rule    Rule
+	Date    time.Time
+	Message string
+}
+
+// Blocking reports whether the violation rejects the change
+func (v RuleViolation) Blocking() bool {
+	return v.Rule.Severity == RuleBlock
+}
+
+// RuleInput is the state a day is checked against, after the change being validated
+type RuleInput struct {
+	Date        time.Time
+	Day         []Workhour // Entries of Date
+	Week        []Workhour // Entries of the Monday-Sunday week containing Date, including Day
+	Details     map[int]WorkhourDetails
+	DailyTarget float64
+	Today       time.Time
+}
+
+// EvaluateRules checks one day against the rules and returns the violations, blocking ones first
+func EvaluateRules(rules []Rule, in RuleInput) []RuleViolation {
+	var blocking, warnings []RuleViolation
+	for _, rule := range rules {
+		if rule.Severity == RuleOff {
+			continue
+		}
+		violation := evaluateRule(rule, in)
+		if violation == nil {
+			continue
+		}
+		if violation.Blocking() {
+			blocking = append(blocking, *violation)
+		} else {
+			warnings = append(warnings, *violation)
+		}
+	}
+	return append(blocking, warnings...)
+}
+
+func evaluateRule(rule Rule, in RuleInput) *RuleViolation {
+	violation := func(date time.Time, format string, args ...any) *RuleViolation {
+		return &RuleViolation{Rule: rule, Date: date, Message: fmt.Sprintf(format, args...)}
+	}
+
+	var workHours, nonWorkHours float64
+	for _, wh := range in.Day {
+		if details, ok := in.Details[wh.DetailsID]; ok && !details.IsWork {
+			nonWorkHours += wh.Hours
+		} else {
+			workHours += wh.Hours
+		}
+	}
+	dateLabel := in.Date.Format("2006-01-02")
+
+	switch rule.Kind {
+	case RuleMaxDailyHours:
+		if total := workHours + nonWorkHours; total > rule.Limit+hoursEpsilon {
+			return violation(in.Date, "%s: %gh logged, more than the %gh daily maximum", dateLabel, roundHours(total), rule.Limit)
+		}
+
+	case RuleMaxWeeklyHours:
+		var total float64
+		for _, wh := range in.Week {
+			total += wh.Hours
+		}
+		if total > rule.Limit+hoursEpsilon {
+			weekStart := WeekStart(in.Date)
+			_, week := weekStart.ISOWeek()
+			return violation(weekStart, "week %d: %gh logged, more than the %gh weekly maximum", week, roundHours(total), rule.Limit)
+		}
+
+	case RuleNoWorkOnLeave:
+		if nonWorkHours > 0 && nonWorkHours >= in.DailyTarget-hoursEpsilon && workHours > 0 {
+			return violation(in.Date, "%s: %gh of work logged on a leave day", dateLabel, roundHours(workHours))
+		}
+
+	case RuleNonWorkFullDay:
+		if nonWorkHours > 0 && math.Abs(nonWorkHours-in.DailyTarget) > hoursEpsilon {
+			return violation(in.Date, "%s: non-work entries total %gh instead of the %gh daily target", dateLabel, roundHours(nonWorkHours), in.DailyTarget)
+		}
+
+	case RuleNoFutureEntries:
+		if len(in.Day) > 0 && in.Date.After(in.Today) && !sameDay(in.Date, in.Today) {
+			return violation(in.Date, "%s: entries logged in the future", dateLabel)
+		}
+	}
+
+	return nil
+}
+
+const hoursEpsilon = 0.001
+
+// WeekStart returns the Monday of the week a date falls in
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, date.Location())
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// FormatViolations joins violation messages for a notification or error
+func FormatViolations(violations []RuleViolation) string {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    RuleKind
+		value   string
+		want    Rule
+		wantErr bool
+	}{
+		{name: "block with limit", kind: RuleMaxDailyHours, value: "block 12", want: Rule{Kind: RuleMaxDailyHours, Severity: RuleBlock, Limit: 12}},
+		{name: "off without limit", kind: RuleMaxWeeklyHours, value: "off", want: Rule{Kind: RuleMaxWeeklyHours}},
+		{name: "warn", kind: RuleNoWorkOnLeave, value: "Warn", want: Rule{Kind: RuleNoWorkOnLeave, Severity: RuleWarn}},
+		{name: "missing limit", kind: RuleMaxDailyHours, value: "warn", wantErr: true},
+		{name: "unexpected limit", kind: RuleNoFutureEntries, value: "block 3", wantErr: true},
+		{name: "unknown severity", kind: RuleNoFutureEntries, value: "maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRule(tt.kind, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseRule(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	for _, rule := range DefaultRules() {
+		parsed, err := ParseRule(rule.Kind, rule.SettingValue())
+		if err != nil || parsed != rule {
+			t.Errorf("default rule %s does not round-trip: %+v, %v", rule.Kind, parsed, err)
+		}
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	date := time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC) // Wednesday
+	details := map[int]WorkhourDetails{
+		1: {ID: 1, ShortName: "DEV", IsWork: true},
+		2: {ID: 2, ShortName: "CO", IsWork: false},
+	}
+	rules := []Rule{
+		{Kind: RuleMaxDailyHours, Severity: RuleBlock, Limit: 10},
+		{Kind: RuleMaxWeeklyHours, Severity: RuleWarn, Limit: 40},
+		{Kind: RuleNoWorkOnLeave, Severity: RuleWarn},
+		{Kind: RuleNonWorkFullDay, Severity: RuleWarn},
+		{Kind: RuleNoFutureEntries, Severity: RuleBlock},
+	}
+	entry := func(detailsID int, hours float64) Workhour {
+		return Workhour{Date: date, DetailsID: detailsID, Hours: hours}
+	}
+
+	tests := []struct {
+		name      string
+		day       []Workhour
+		weekExtra float64
+		today     time.Time
+		want      []RuleKind
+	}{
+		{name: "regular day", day: []Workhour{entry(1, 8)}, today: date},
+		{name: "too long day", day: []Workhour{entry(1, 8), entry(1, 3)}, today: date, want: []RuleKind{RuleMaxDailyHours}},
+		{name: "too long week", day: []Workhour{entry(1, 8)}, weekExtra: 36, today: date, want: []RuleKind{RuleMaxWeeklyHours}},
+		{name: "work on leave day", day: []Workhour{entry(2, 8), entry(1, 1)}, today: date, want: []RuleKind{RuleNoWorkOnLeave}},
+		{name: "double leave", day: []Workhour{entry(2, 8), entry(2, 8)}, today: date, want: []RuleKind{RuleMaxDailyHours, RuleNonWorkFullDay}},
+		{name: "future", day: []Workhour{entry(1, 8)}, today: date.AddDate(0, 0, -1), want: []RuleKind{RuleNoFutureEntries}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			week := append([]Workhour{}, tt.day...)
+			if tt.weekExtra > 0 {
+				week = append(week, Workhour{Date: date.AddDate(0, 0, -2), DetailsID: 1, Hours: tt.weekExtra})
+			}
+
+			violations := EvaluateRules(rules, RuleInput{
+				Date:        date,
+				Day:         tt.day,
+				Week:        week,
+				Details:     details,
+				DailyTarget: 8,
+				Today:       tt.today,
+			})
+
+			if len(violations) != len(tt.want) {
+				t.Fatalf("got %d violations (%s), want %v", len(violations), FormatViolations(violations), tt.want)
+			}
+			for i, kind := range tt.want {
+				if violations[i].Rule.Kind != kind {
+					t.Errorf("violation %d is %s, want %s", i, violations[i].Rule.Kind, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateRules_WeeklyReportsMonday(t *testing.T) {
+	date := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC) // Friday
+	day := []Workhour{{Date: date, DetailsID: 1, Hours: 50}}
+
+	violations := EvaluateRules(
+		[]Rule{{Kind: RuleMaxWeeklyHours, Severity: RuleWarn, Limit: 40}},
+		RuleInput{Date: date, Day: day, Week: day, DailyTarget: 8, Today: date},
+	)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if got := violations[0].Date.Format("2006-01-02"); got != "2025-03-03" {
+		t.Errorf("expected the week's Monday, got %s", got)
+	}
+	if violations[0].Blocking() {
+		t.Error("expected a warning, not a block")
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// SyncSide names the replica whose value a conflict resolution kept
+type SyncSide string
+
+const (
+	SyncSideLocal  SyncSide = "local"
+	SyncSideRemote SyncSide = "remote"
+)
+
+// SyncResult counts what a sync run did
+type SyncResult struct {
+	Exported  int // Local changes appended to this replica's log
+	Applied   int // Changes of other replicas applied here
+	Conflicts int // Changes made on both sides since they last agreed, or touching a locked month
+	Locked    int // Conflicts kept because the change touched a locked month
+
+	BudgetWarnings []BudgetWarning // Project budgets the applied changes made cross a threshold
+	RuleViolations []RuleViolation // Rules the days changed by the applied changes break
+}
+
+// SyncConflict is a workhour changed on two replicas since they last agreed.
+// The newest change won; the other value is kept for review. A nil value
+// means the workhour was deleted on that side.
+type SyncConflict struct {
+	ID            int
+	WorkhourUUID  string
+	Local         *Workhour
+	Remote        *Workhour
+	RemoteReplica string
+	Kept          SyncSide
+	DetectedAt    time.Time
+}
+
+// KeptValue returns the value the workhour has after the conflict
+func (c SyncConflict) KeptValue() *Workhour {
+	if c.Kept == SyncSideRemote {
+		return c.Remote
+	}
+	return c.Local
+}
+
+// OtherValue returns the value the conflict discarded
+func (c SyncConflict) OtherValue() *Workhour {
+	if c.Kept == SyncSideRemote {
+		return c.Local
+	}
+	return c.Remote
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkhourExport records a workhour sent to a timesheet sink, so that later pushes
+// update the remote entry instead of adding another one
+type WorkhourExport struct {
+	WorkhourID int
+	Sink       string
+	RemoteID   string
+	IssueKey   string // Values as pushed, to tell whether the workhour changed since
+	Date       time.Time
+	Hours      float64
+	PushedAt   time.Time
+}
+
+// Matches reports whether the export still reflects the workhour
+func (e WorkhourExport) Matches(wh Workhour) bool {
+	return e.IssueKey == wh.IssueKey && e.Date.Equal(wh.Date) && e.Hours == wh.Hours
+}
+
+var jiraIssueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[1-9][0-9]*$`)
+
+// IsJiraIssueKey reports whether key is a Jira issue key such as "API-12"
+func IsJiraIssueKey(key string) bool {
+	return jiraIssueKeyPattern.MatchString(key)
+}
+
+// ParseGitLabIssue splits a GitLab issue reference such as "group/api#12" into the
+// path of its project and the issue number
+func ParseGitLabIssue(key string) (project string, iid int, ok bool) {
+	project, number, found := strings.Cut(key, "#")
+	if !found || project == "" || strings.HasPrefix(project, "/") || strings.HasSuffix(project, "/") || !strings.Contains(project, "/") {
+		return "", 0, false
+	}
+	iid, err := strconv.Atoi(number)
+	if err != nil || iid <= 0 {
+		return "", 0, false
+	}
+	return project, iid, true
+}
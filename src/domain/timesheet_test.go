@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueKeys(t *testing.T) {
+	tests := []struct {
+		key     string
+		jira    bool
+		gitlab  bool
+		project string
+		iid     int
+	}{
+		{key: "API-12", jira: true},
+		{key: "OPS2-7", jira: true},
+		{key: "api-12"},
+		{key: "API-0"},
+		{key: "group/api#12", gitlab: true, project: "group/api", iid: 12},
+		{key: "group/sub/api#3", gitlab: true, project: "group/sub/api", iid: 3},
+		{key: "api#12"},
+		{key: "group/api#x"},
+		{key: ""},
+	}
+
+	for _, tt := range tests {
+		if got := IsJiraIssueKey(tt.key); got != tt.jira {
+			t.Errorf("IsJiraIssueKey(%q) = %v, want %v", tt.key, got, tt.jira)
+		}
+		project, iid, ok := ParseGitLabIssue(tt.key)
+		if ok != tt.gitlab || project != tt.project || iid != tt.iid {
+			t.Errorf("ParseGitLabIssue(%q) = %q, %d, %v", tt.key, project, iid, ok)
+		}
+	}
+}
+
+func TestWorkhourExport_Matches(t *testing.T) {
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	wh := Workhour{ID: 1, Date: day, Hours: 2, IssueKey: "API-1"}
+	export := WorkhourExport{WorkhourID: 1, IssueKey: "API-1", Date: day, Hours: 2}
+
+	if !export.Matches(wh) {
+		t.Error("expected an unchanged workhour to match")
+	}
+	for _, changed := range []Workhour{
+		{Date: day, Hours: 3, IssueKey: "API-1"},
+		{Date: day.AddDate(0, 0, 1), Hours: 2, IssueKey: "API-1"},
+		{Date: day, Hours: 2, IssueKey: "API-2"},
+	} {
+		if export.Matches(changed) {
+			t.Errorf("expected %+v not to match", changed)
+		}
+	}
+}
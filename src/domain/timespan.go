@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrOverlappingTimes is returned when an entry's times overlap another entry of the same day
+var ErrOverlappingTimes = errors.New("times overlap another entry")
+
+// ClockTime is a time of day in minutes after midnight, up to 24:00
+type ClockTime int
+
+func NewClockTime(hour, minute int) ClockTime {
+	return ClockTime(hour*60 + minute)
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+// ParseClockTime parses "9", "09:30" or "24:00"
+func ParseClockTime(value string) (ClockTime, error) {
+	hourStr, minuteStr, hasMinutes := strings.Cut(strings.TrimSpace(value), ":")
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+
+	minute := 0
+	if hasMinutes {
+		minute, err = strconv.Atoi(minuteStr)
+		if err != nil || len(minuteStr) != 2 || minute < 0 || minute > 59 {
+			return 0, fmt.Errorf("invalid time %q", value)
+		}
+	}
+	if hour == 24 && minute > 0 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+
+	return NewClockTime(hour, minute), nil
+}
+
+// HasTimes reports whether the entry records start and end times
+func (w Workhour) HasTimes() bool {
+	return w.End > w.Start
+}
+
+// TimedHours returns the hours between start and end minus the break
+func (w Workhour) TimedHours() float64 {
+	if !w.HasTimes() {
+		return 0
+	}
+	hours := float64(int(w.End-w.Start)-w.BreakMinutes) / 60
+	return math.Round(hours*10000) / 10000
+}
+
+// TimeRange formats the times as "09:00-12:15", or "" when the entry has none
+func (w Workhour) TimeRange() string {
+	if !w.HasTimes() {
+		return ""
+	}
+	return w.Start.String() + "-" + w.End.String()
+}
+
+// ValidateTimes checks that the times and break of the entry are consistent
+func (w Workhour) ValidateTimes() error {
+	if w.Start == 0 && w.End == 0 {
+		if w.BreakMinutes != 0 {
+			return fmt.Errorf("a break needs start and end times")
+		}
+		return nil
+	}
+	if w.Start < 0 || w.End > NewClockTime(24, 0) {
+		return fmt.Errorf("times must be within the day")
+	}
+	if w.End <= w.Start {
+		return fmt.Errorf("end time must be after start time")
+	}
+	if w.BreakMinutes < 0 || w.BreakMinutes >= int(w.End-w.Start) {
+		return fmt.Errorf("break must be shorter than the time range")
+	}
+	return nil
+}
+
+// Overlaps reports whether two timed entries share any minute. Touching ranges
+// such as 09:00-12:00 and 12:00-13:00 do not overlap.
+func (w Workhour) Overlaps(other Workhour) bool {
+	if !w.HasTimes() || !other.HasTimes() {
+		return false
+	}
+	return w.Start < other.End && other.Start < w.End
+}
+
+// FindOverlap returns the first of workhours overlapping candidate, skipping the
+// candidate itself by ID, or nil when there is none
+func FindOverlap(candidate Workhour, workhours []Workhour) *Workhour {
+	for i, wh := range workhours {
+		if candidate.ID != 0 && wh.ID == candidate.ID {
+			continue
+		}
+		if candidate.Overlaps(wh) {
+			return &workhours[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package domain
+
+import "testing"
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ClockTime
+		wantErr bool
+	}{
+		{input: "9", want: NewClockTime(9, 0)},
+		{input: "09:30", want: NewClockTime(9, 30)},
+		{input: "24:00", want: NewClockTime(24, 0)},
+		{input: "24:30", wantErr: true},
+		{input: "9:5", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseClockTime(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseClockTime(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseClockTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkhour_TimedHours(t *testing.T) {
+	wh := Workhour{Start: NewClockTime(9, 0), End: NewClockTime(17, 30), BreakMinutes: 30}
+	if !wh.HasTimes() {
+		t.Fatal("expected workhour to have times")
+	}
+	if got := wh.TimedHours(); got != 8 {
+		t.Errorf("got %v hours, want 8", got)
+	}
+	if got := wh.TimeRange(); got != "09:00-17:30" {
+		t.Errorf("got range %q", got)
+	}
+
+	if (Workhour{Hours: 3}).HasTimes() {
+		t.Error("expected workhour without times")
+	}
+}
+
+func TestWorkhour_ValidateTimes(t *testing.T) {
+	tests := []struct {
+		name    string
+		wh      Workhour
+		wantErr bool
+	}{
+		{name: "no times", wh: Workhour{Hours: 2}},
+		{name: "valid", wh: Workhour{Start: NewClockTime(8, 0), End: NewClockTime(12, 0), BreakMinutes: 15}},
+		{name: "end before start", wh: Workhour{Start: NewClockTime(12, 0), End: NewClockTime(8, 0)}, wantErr: true},
+		{name: "break too long", wh: Workhour{Start: NewClockTime(8, 0), End: NewClockTime(9, 0), BreakMinutes: 60}, wantErr: true},
+		{name: "break without times", wh: Workhour{BreakMinutes: 30}, wantErr: true},
+		{name: "past midnight", wh: Workhour{Start: NewClockTime(22, 0), End: NewClockTime(24, 30)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.wh.ValidateTimes(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTimes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindOverlap(t *testing.T) {
+	day := []Workhour{
+		{ID: 1, Start: NewClockTime(9, 0), End: NewClockTime(12, 0)},
+		{ID: 2, Hours: 4},
+		{ID: 3, Start: NewClockTime(13, 0), End: NewClockTime(17, 0)},
+	}
+
+	tests := []struct {
+		name      string
+		candidate Workhour
+		wantID    int
+	}{
+		{name: "inside", candidate: Workhour{Start: NewClockTime(10, 0), End: NewClockTime(11, 0)}, wantID: 1},
+		{name: "spanning", candidate: Workhour{Start: NewClockTime(11, 0), End: NewClockTime(14, 0)}, wantID: 1},
+		{name: "adjacent", candidate: Workhour{Start: NewClockTime(12, 0), End: NewClockTime(13, 0)}},
+		{name: "untimed", candidate: Workhour{Hours: 8}},
+		{name: "itself", candidate: Workhour{ID: 3, Start: NewClockTime(13, 30), End: NewClockTime(17, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindOverlap(tt.candidate, day)
+			gotID := 0
+			if got != nil {
+				gotID = got.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("FindOverlap() = %d, want %d", gotID, tt.wantID)
+			}
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package models
 
 import (
+	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
 	"tltui/src/elm-store/calendar"
+	"tltui/src/elm-store/clients"
+	"tltui/src/elm-store/leave"
 	"tltui/src/elm-store/projects"
 	"tltui/src/elm-store/workhour_details"
 	"tltui/src/render"
@@ -17,6 +24,8 @@ const (
 	ModeViewCalendar AppMode = iota
 	ModeViewProjects
 	ModeViewWorkhourDetails
+	ModeViewLeave
+	ModeViewClients
 )
 
 type AppModel struct {
@@ -25,12 +34,50 @@ type AppModel struct {
 	Calendar        calendar.CalendarModel
 	Projects        projects.ProjectsModel
 	WorkhourDetails workhour_details.WorkhourDetailsModel
+	Leave           leave.LeaveModel
+	Clients         clients.ClientsModel
 
-	Notification *common.Notification
+	Notification    *common.Notification
+	ProfileSwitcher *ProfileSwitcher // Open profile switcher, nil when closed
+	SyncScreen      *SyncScreen      // Open sync screen, nil when closed
+
+	dataVersion int64 // Last seen repository.DataVersion, to notice changes of other processes
+}
+
+// dataVersionPollInterval is how often the database is checked for changes of other processes
+const dataVersionPollInterval = 2 * time.Second
+
+// DataVersionPolledMsg carries the database's data version, read on every poll tick
+type DataVersionPolledMsg struct {
+	DB      *sql.DB // Database the version was read from, a replaced one is ignored
+	Version int64
+	Err     error
 }
 
+// NewAppModel builds the app with every view loaded from the open database
+func NewAppModel() AppModel {
+	m := AppModel{
+		Mode:            ModeViewCalendar,
+		Calendar:        calendar.NewCalendarModel(),
+		Projects:        projects.NewProjectsModel(),
+		WorkhourDetails: workhour_details.NewWorkhourDetailsModel(),
+		Leave:           leave.NewLeaveModel(),
+		Clients:         clients.NewClientsModel(),
+	}
+	m.dataVersion, _ = repository.DataVersion()
+	return m
+}
+
+// Init starts watching the database for changes of other processes, and syncs with
+// the other devices at startup when sync is set up
 func (m AppModel) Init() tea.Cmd {
-	return nil
+	if repository.IsReadOnly() {
+		return pollDataVersion()
+	}
+	if dir, _, err := repository.GetSyncConfig(); err != nil || dir == "" {
+		return pollDataVersion()
+	}
+	return tea.Batch(pollDataVersion(), runSync)
 }
 
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -49,8 +96,36 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Notification = nil
 		return m, nil
 
+	case ProfileSwitcherClosedMsg:
+		m.ProfileSwitcher = nil
+		return m, nil
+
+	case ProfileSelectedMsg:
+		return m.switchProfile(msg.Profile)
+
+	case SyncScreenClosedMsg:
+		m.SyncScreen = nil
+		return m, nil
+
+	case SyncFinishedMsg:
+		return m.handleSyncFinished(msg)
+
+	case SyncConflictResolvedMsg:
+		m.refreshViews()
+		return m, nil
+
+	case DataVersionPolledMsg:
+		return m.handleDataVersionPolled(msg)
+
+	case calendar.WorkhoursViewModalCreateRequestedMsg, calendar.WorkhoursViewModalEditRequestedMsg,
+		calendar.WorkhoursViewModalDeleteRequestedMsg, projects.TaskCreateRequestedMsg,
+		projects.TaskEditRequestedMsg, projects.TaskDeleteRequestedMsg:
+		if repository.IsReadOnly() {
+			return m, notifyReadOnly()
+		}
+
 	case tea.WindowSizeMsg:
-		var cmd1, cmd2, cmd3 tea.Cmd
+		var cmd1, cmd2, cmd3, cmd4, cmd5 tea.Cmd
 		var updatedModel tea.Model
 
 		updatedModel, cmd1 = m.Calendar.Update(msg)
@@ -62,20 +137,49 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updatedModel, cmd3 = m.WorkhourDetails.Update(msg)
 		m.WorkhourDetails = updatedModel.(workhour_details.WorkhourDetailsModel)
 
-		return m, tea.Batch(cmd1, cmd2, cmd3)
+		updatedModel, cmd4 = m.Leave.Update(msg)
+		m.Leave = updatedModel.(leave.LeaveModel)
+
+		updatedModel, cmd5 = m.Clients.Update(msg)
+		m.Clients = updatedModel.(clients.ClientsModel)
+
+		return m, tea.Batch(cmd1, cmd2, cmd3, cmd4, cmd5)
 
 	case tea.KeyMsg:
+		if m.ProfileSwitcher != nil {
+			return m, m.ProfileSwitcher.Update(msg)
+		}
+		if m.SyncScreen != nil {
+			return m, m.SyncScreen.Update(msg)
+		}
+
 		isModalOpen := m.Calendar.ActiveModal != nil ||
 			m.Calendar.ShowHelp ||
 			m.Projects.ActiveModal != nil ||
-			m.WorkhourDetails.ActiveModal != nil
+			m.WorkhourDetails.ActiveModal != nil ||
+			m.Leave.ActiveModal != nil ||
+			m.Clients.ActiveModal != nil
+
+		if !isModalOpen && repository.IsReadOnly() && m.isMutatingKey(msg.String()) {
+			return m, notifyReadOnly()
+		}
 
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
 			if !isModalOpen {
 				return m, tea.Quit
 			}
-		case "1", "2", "3":
+		case "P":
+			if !isModalOpen {
+				m.ProfileSwitcher = NewProfileSwitcher()
+				return m, nil
+			}
+		case "S":
+			if !isModalOpen {
+				m.SyncScreen = NewSyncScreen()
+				return m, nil
+			}
+		case "1", "2", "3", "4", "5":
 			if isModalOpen {
 				break
 			}
@@ -87,10 +191,18 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "2":
 				m.Mode = ModeViewProjects
+				m.Projects.Refresh()
 				return m, nil
 			case "3":
 				m.Mode = ModeViewWorkhourDetails
 				return m, nil
+			case "4":
+				m.Mode = ModeViewLeave
+				m.Leave.Refresh()
+				return m, nil
+			case "5":
+				m.Mode = ModeViewClients
+				return m, nil
 			}
 		}
 	}
@@ -119,9 +231,137 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.Mode == ModeViewLeave {
+		var cmd tea.Cmd
+		var updatedModel tea.Model
+		updatedModel, cmd = m.Leave.Update(msg)
+		m.Leave = updatedModel.(leave.LeaveModel)
+		return m, cmd
+	}
+
+	if m.Mode == ModeViewClients {
+		var cmd tea.Cmd
+		var updatedModel tea.Model
+		updatedModel, cmd = m.Clients.Update(msg)
+		m.Clients = updatedModel.(clients.ClientsModel)
+		return m, cmd
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
+// isMutatingKey reports whether a key changes data in the current view, for read-only mode
+func (m AppModel) isMutatingKey(key string) bool {
+	switch key {
+	case "n", "e", "d", "p", "x":
+		return true
+	case "L":
+		return m.Mode == ModeViewCalendar // Locks or unlocks the month
+	case "enter":
+		return m.Mode != ModeViewCalendar // Opens the edit form outside the calendar
+	}
+	return false
+}
+
+func notifyReadOnly() tea.Cmd {
+	return common.NotifyInfo("Read-only mode, changes are disabled")
+}
+
+// switchProfile opens another profile's database and reloads every view from it
+func (m AppModel) switchProfile(profile string) (AppModel, tea.Cmd) {
+	if profile == repository.CurrentProfile() {
+		m.ProfileSwitcher = nil
+		return m, nil
+	}
+
+	if err := repository.SwitchProfile(profile); err != nil {
+		if m.ProfileSwitcher != nil {
+			m.ProfileSwitcher.ErrorMessage = err.Error()
+		}
+		return m, common.NotifyError("Failed to switch profile", err)
+	}
+
+	width, height := m.Calendar.Width, m.Calendar.Height
+	m = NewAppModel()
+	updatedModel, cmd := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	m = updatedModel.(AppModel)
+
+	return m, tea.Batch(cmd, common.NotifySuccess(fmt.Sprintf("Switched to profile %s", profile)))
+}
+
+// handleSyncFinished reloads the views after a sync and reports what it did
+func (m AppModel) handleSyncFinished(msg SyncFinishedMsg) (AppModel, tea.Cmd) {
+	if m.SyncScreen != nil {
+		m.SyncScreen.Syncing = false
+		m.SyncScreen.Reload()
+		if msg.Err != nil {
+			m.SyncScreen.ErrorMessage = msg.Err.Error()
+		}
+	}
+	if msg.Err != nil {
+		return m, common.NotifyError("Sync failed", msg.Err)
+	}
+
+	m.refreshViews()
+	result := msg.Result
+	if result.Conflicts > 0 {
+		return m, common.NotifyInfo(fmt.Sprintf("Synced with %d conflicts, press S to review them", result.Conflicts))
+	}
+	if len(result.BudgetWarnings) > 0 || len(result.RuleViolations) > 0 {
+		var warnings []string
+		if len(result.BudgetWarnings) > 0 {
+			warnings = append(warnings, domain.FormatBudgetWarnings(result.BudgetWarnings))
+		}
+		if len(result.RuleViolations) > 0 {
+			warnings = append(warnings, domain.FormatViolations(result.RuleViolations))
+		}
+		return m, common.NotifyInfo(fmt.Sprintf("Synced, applied %d changes and shared %d. ⚠ %s",
+			result.Applied, result.Exported, strings.Join(warnings, "; ")))
+	}
+	return m, common.NotifySuccess(fmt.Sprintf("Synced, applied %d changes and shared %d", result.Applied, result.Exported))
+}
+
+// handleDataVersionPolled reloads the views when another process changed the database
+func (m AppModel) handleDataVersionPolled(msg DataVersionPolledMsg) (AppModel, tea.Cmd) {
+	// Versions of a database replaced since, e.g. by a profile switch, do not compare
+	if msg.Err != nil || msg.DB != repository.GetDB() || msg.Version == m.dataVersion {
+		return m, pollDataVersion()
+	}
+
+	m.dataVersion = msg.Version
+	m.refreshViews()
+	return m, tea.Batch(
+		pollDataVersion(),
+		common.NotifyInfo("Data changed outside this window, views refreshed"),
+	)
+}
+
+// refreshViews reloads the data the views keep in memory
+func (m *AppModel) refreshViews() {
+	m.Calendar.Refresh()
+	m.Projects.Refresh()
+	m.WorkhourDetails.Refresh()
+	m.Leave.Refresh()
+}
+
+// pollDataVersion reads the data version after the poll interval. The handle of the
+// database is taken here on the update loop, since the tick runs on another goroutine.
+func pollDataVersion() tea.Cmd {
+	conn := repository.GetDB()
+	return tea.Tick(dataVersionPollInterval, func(time.Time) tea.Msg {
+		version, err := repository.DataVersionOf(conn)
+		return DataVersionPolledMsg{DB: conn, Version: version, Err: err}
+	})
+}
+
+// locationLabel names the open profile, or the database file when one was opened directly
+func (m AppModel) locationLabel() string {
+	if profile := repository.CurrentProfile(); profile != "" {
+		return "[P] Profile: " + profile
+	}
+	return "DB: " + repository.DBPath()
+}
+
 func (m AppModel) View() string {
 	var content string
 	var activeTabIndex int
@@ -139,15 +379,27 @@ func (m AppModel) View() string {
 		activeTabIndex = 2
 		content = m.WorkhourDetails.View()
 
+	case ModeViewLeave:
+		activeTabIndex = 3
+		content = m.Leave.View()
+
+	case ModeViewClients:
+		activeTabIndex = 4
+		content = m.Clients.View()
+
 	default:
 		content = ""
 	}
 
-	isModalOpened := m.Calendar.ActiveModal != nil || m.Projects.ActiveModal != nil || m.WorkhourDetails.ActiveModal != nil
+	isModalOpened := m.Calendar.ActiveModal != nil || m.Projects.ActiveModal != nil || m.WorkhourDetails.ActiveModal != nil || m.Leave.ActiveModal != nil || m.Clients.ActiveModal != nil
 
 	mainView := ""
-	if !isModalOpened {
-		mainView = render.RenderPageLayoutWithTabs(activeTabIndex, content)
+	if m.ProfileSwitcher != nil {
+		mainView = m.ProfileSwitcher.View(m.Calendar.Width, m.Calendar.Height)
+	} else if m.SyncScreen != nil {
+		mainView = m.SyncScreen.View(m.Calendar.Width, m.Calendar.Height)
+	} else if !isModalOpened {
+		mainView = render.RenderPageLayoutWithTabs(activeTabIndex, m.locationLabel(), repository.IsReadOnly(), content)
 	} else {
 		mainView = content
 	}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAppModel_ReadOnlyDisablesChanges(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+	repository.SetReadOnly(true)
+	defer repository.SetReadOnly(false)
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	workhour := repository.CreateTestWorkhour(t, date, detail.ID, project.ID, 8)
+
+	m := NewAppModel()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(AppModel)
+	m.Calendar.SelectedDate = date.AddDate(0, 0, 1)
+	m.Calendar.YankedWorkhours = []domain.Workhour{workhour}
+
+	if view := m.View(); !strings.Contains(view, "read-only") {
+		t.Error("expected a read-only badge")
+	}
+
+	for _, key := range []string{"p", "x", "n"} {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if cmd == nil {
+			t.Fatalf("expected %q to be refused", key)
+		}
+		if notification, ok := cmd().(common.ShowNotificationMsg); !ok || !strings.Contains(notification.Message, "Read-only") {
+			t.Errorf("expected a read-only notice for %q, got %+v", key, notification)
+		}
+	}
+	if workhours, _ := repository.GetWorkhoursByDate(date.AddDate(0, 0, 1)); len(workhours) != 0 {
+		t.Errorf("expected nothing to be pasted, got %d workhours", len(workhours))
+	}
+
+	// Browsing still works
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if updated.(AppModel).Calendar.ActiveModal == nil {
+		t.Error("expected the week view to open in read-only mode")
+	}
+}
+
+func TestSyncScreen_ResolveConflict(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	workhour := repository.CreateTestWorkhour(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 8)
+	stored, _ := repository.GetWorkhourByID(workhour.ID)
+
+	shared := t.TempDir()
+	if _, err := repository.InitSync(shared); err != nil {
+		t.Fatalf("failed to set up sync: %v", err)
+	}
+	remote := "0b7e4f7e-9a43-4c55-8f0e-2f1a6d6f3c11"
+	change := fmt.Sprintf(`{"replica":%q,"uuid":%q,"action":"update","old":{"date":"2024-01-15","details_id":1,"project_id":1,"hours":6},"new":{"date":"2024-01-15","details_id":1,"project_id":1,"hours":7},"changed_at":%d}`+"\n",
+		remote, stored.UUID, time.Now().Add(time.Hour).UnixNano())
+	os.WriteFile(filepath.Join(shared, remote+".jsonl"), []byte(change), 0644)
+
+	m := NewAppModel()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(AppModel)
+	updated, _ = m.Update(runSync())
+	m = updated.(AppModel)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m = updated.(AppModel)
+	if view := m.View(); !strings.Contains(view, "1 conflicts") || !strings.Contains(view, "discarded: 2024-01-15 DEV 8h Arnia") {
+		t.Fatalf("expected the conflict on the sync screen, got:\n%s", view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = updated.(AppModel)
+	if wh, _ := repository.GetWorkhourByID(workhour.ID); wh == nil || wh.Hours != 8 {
+		t.Errorf("expected the discarded local value to be restored, got %+v", wh)
+	}
+	if view := m.View(); !strings.Contains(view, "No conflicts") {
+		t.Errorf("expected no conflicts left, got:\n%s", view)
+	}
+}
+
+func TestAppModel_RefreshesOnExternalChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.db")
+	if err := repository.InitDBAt(path); err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer repository.CloseDB()
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia", 100)
+
+	m := NewAppModel()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(AppModel)
+
+	// Changes of this process are not reported as external
+	repository.CreateTestProject(t, 2, "Website", 101)
+	if version, _ := repository.DataVersion(); version != m.dataVersion {
+		t.Fatalf("expected the data version to stay %d for changes of this process, got %d", m.dataVersion, version)
+	}
+
+	// Another process, e.g. a second TUI, adds a project and a workhour type
+	other, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open second connection: %v", err)
+	}
+	defer other.Close()
+	if _, err := other.Exec("INSERT INTO projects (id, name, odoo_id) VALUES (3, 'Support', 102)"); err != nil {
+		t.Fatalf("failed to insert project: %v", err)
+	}
+	if _, err := other.Exec("INSERT INTO workhour_details (id, name, short_name, is_work) VALUES (2, 'Vacation', 'VAC', 0)"); err != nil {
+		t.Fatalf("failed to insert workhour type: %v", err)
+	}
+
+	version, _ := repository.DataVersion()
+	updated, cmd := m.Update(DataVersionPolledMsg{DB: repository.GetDB(), Version: version})
+	m = updated.(AppModel)
+	if len(m.Projects.Projects) != 3 || len(m.WorkhourDetails.WorkhourDetails) != 2 {
+		t.Errorf("expected the views to be refreshed, got %d projects and %d types",
+			len(m.Projects.Projects), len(m.WorkhourDetails.WorkhourDetails))
+	}
+	if m.Projects.NextID != 4 || m.WorkhourDetails.NextID != 3 {
+		t.Errorf("expected the next IDs to follow the new rows, got %d and %d", m.Projects.NextID, m.WorkhourDetails.NextID)
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected a batch with the notification, got %T", cmd())
+	}
+	// The next poll waits for its tick, only the notification answers right away
+	notified := false
+	for _, c := range batch {
+		msgs := make(chan tea.Msg, 1)
+		go func() { msgs <- c() }()
+		select {
+		case msg := <-msgs:
+			if notification, ok := msg.(common.ShowNotificationMsg); ok && notification.Type == common.NotificationInfo {
+				notified = true
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	if !notified {
+		t.Error("expected an info notification about the external change")
+	}
+
+	// A poll of a database replaced since, e.g. by a profile switch, is ignored
+	seen := m.dataVersion
+	updated, _ = m.Update(DataVersionPolledMsg{DB: other, Version: seen + 1})
+	if m = updated.(AppModel); m.dataVersion != seen {
+		t.Errorf("expected the data version of another database to be ignored, got %d", m.dataVersion)
+	}
+}
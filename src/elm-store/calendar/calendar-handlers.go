@@ -2,6 +2,8 @@ package calendar
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 	"tltui/src/common"
 	"tltui/src/domain"
@@ -12,50 +14,93 @@ import (
 
 func (m CalendarModel) handleWorkhourCreated(msg WorkhourCreateSubmittedMsg) (CalendarModel, tea.Cmd) {
 	newWorkhour := domain.Workhour{
-		Date:      msg.Date,
-		DetailsID: msg.DetailsID,
-		ProjectID: msg.ProjectID,
-		Hours:     msg.Hours,
+		Date:         msg.Date,
+		DetailsID:    msg.DetailsID,
+		ProjectID:    msg.ProjectID,
+		TaskID:       msg.TaskID,
+		Hours:        msg.Hours,
+		IssueKey:     msg.IssueKey,
+		Start:        msg.Start,
+		End:          msg.End,
+		BreakMinutes: msg.BreakMinutes,
 	}
-	_, err := repository.CreateWorkhour(newWorkhour)
+	_, budgetWarnings, err := repository.CreateWorkhour(newWorkhour)
 	if err != nil {
 		return m, common.NotifyError("Failed to create workhour", err)
 	}
+	recordWorkhourSelections(msg.DetailsID, msg.ProjectID, msg.TaskID)
+	warningCmd := tea.Batch(
+		m.checkLeaveBalance(msg.DetailsID, msg.Date),
+		notifyBudgetWarnings(budgetWarnings),
+		m.checkRules(msg.Date),
+	)
 
 	// Restore view modal and refresh data
 	if m.ViewModalParent != nil && m.ViewModalParent.modal != nil {
 		m.ViewModalParent.modal.Workhours = m.getWorkhoursForDate(m.ViewModalParent.modal.Date)
+		m.ViewModalParent.modal.LeaveBalances = m.getLeaveBalances(m.ViewModalParent.modal.Date)
+		if m.ViewModalParent.modal.ShowHistory {
+			m.ViewModalParent.modal.loadHistory()
+		}
+		if m.ViewModalParent.modal.ShowSuggestions {
+			m.ViewModalParent.modal.loadSuggestions()
+		}
 		m.ActiveModal = m.ViewModalParent
 		m.ViewModalParent = nil
 	} else {
 		m.ActiveModal = nil
 	}
 
-	return m, nil
+	return m, warningCmd
 }
 
 func (m CalendarModel) handleWorkhourEdited(msg WorkhourEditSubmittedMsg) (CalendarModel, tea.Cmd) {
 	updatedWorkhour := domain.Workhour{
-		Date:      msg.Date,
-		DetailsID: msg.DetailsID,
-		ProjectID: msg.ProjectID,
-		Hours:     msg.Hours,
+		Date:         msg.Date,
+		DetailsID:    msg.DetailsID,
+		ProjectID:    msg.ProjectID,
+		TaskID:       msg.TaskID,
+		Hours:        msg.Hours,
+		IssueKey:     msg.IssueKey,
+		Start:        msg.Start,
+		End:          msg.End,
+		BreakMinutes: msg.BreakMinutes,
 	}
-	err := repository.UpdateWorkhour(msg.WorkhourID, updatedWorkhour)
+
+	// The day the entry leaves is checked as well
+	previousDate := msg.Date
+	if stored, err := repository.GetWorkhourByID(msg.WorkhourID); err == nil && stored != nil {
+		previousDate = stored.Date
+	}
+
+	budgetWarnings, err := repository.UpdateWorkhour(msg.WorkhourID, updatedWorkhour)
 	if err != nil {
 		return m, common.NotifyError("Failed to update workhour", err)
 	}
+	recordWorkhourSelections(msg.DetailsID, msg.ProjectID, msg.TaskID)
+	warningCmd := tea.Batch(
+		m.checkLeaveBalance(msg.DetailsID, msg.Date),
+		notifyBudgetWarnings(budgetWarnings),
+		m.checkRules(msg.Date, previousDate),
+	)
 
 	// Restore view modal and refresh data
 	if m.ViewModalParent != nil && m.ViewModalParent.modal != nil {
 		m.ViewModalParent.modal.Workhours = m.getWorkhoursForDate(m.ViewModalParent.modal.Date)
+		m.ViewModalParent.modal.LeaveBalances = m.getLeaveBalances(m.ViewModalParent.modal.Date)
+		if m.ViewModalParent.modal.ShowHistory {
+			m.ViewModalParent.modal.loadHistory()
+		}
+		if m.ViewModalParent.modal.ShowSuggestions {
+			m.ViewModalParent.modal.loadSuggestions()
+		}
 		m.ActiveModal = m.ViewModalParent
 		m.ViewModalParent = nil
 	} else {
 		m.ActiveModal = nil
 	}
 
-	return m, nil
+	return m, warningCmd
 }
 
 func (m CalendarModel) handleWorkhourDeleted(msg WorkhourDeleteConfirmedMsg) (CalendarModel, tea.Cmd) {
@@ -67,6 +112,13 @@ func (m CalendarModel) handleWorkhourDeleted(msg WorkhourDeleteConfirmedMsg) (Ca
 	// Restore view modal and refresh data
 	if m.ViewModalParent != nil && m.ViewModalParent.modal != nil {
 		m.ViewModalParent.modal.Workhours = m.getWorkhoursForDate(m.ViewModalParent.modal.Date)
+		m.ViewModalParent.modal.LeaveBalances = m.getLeaveBalances(m.ViewModalParent.modal.Date)
+		if m.ViewModalParent.modal.ShowHistory {
+			m.ViewModalParent.modal.loadHistory()
+		}
+		if m.ViewModalParent.modal.ShowSuggestions {
+			m.ViewModalParent.modal.loadSuggestions()
+		}
 		// Adjust selected index if needed
 		if m.ViewModalParent.modal.SelectedWorkhourIndex >= len(m.ViewModalParent.modal.Workhours) && len(m.ViewModalParent.modal.Workhours) > 0 {
 			m.ViewModalParent.modal.SelectedWorkhourIndex = len(m.ViewModalParent.modal.Workhours) - 1
@@ -87,9 +139,13 @@ func (m CalendarModel) handleWorkhourCreateRequest(msg WorkhoursViewModalCreateR
 
 	workhourDetails, _ := repository.GetAllWorkhourDetailsFromDB()
 	projects, _ := repository.GetAllProjectsFromDB()
-	m.ActiveModal = &WorkhourCreateModalWrapper{
-		modal: NewWorkhourCreateModal(msg.Date, workhourDetails, projects),
+	tasks, _ := repository.GetAllTasks()
+	modal := NewWorkhourCreateModal(msg.Date, workhourDetails, projects, tasks)
+	if msg.Suggestion != nil {
+		// Commit subjects are shown for reference; workhours have no description to keep them in
+		modal.Prefill(msg.Suggestion.Workhour, fmt.Sprintf("From git (%s): %s", filepath.Base(msg.Suggestion.Repo.Path), msg.Suggestion.Description))
 	}
+	m.ActiveModal = &WorkhourCreateModalWrapper{modal: modal}
 	return m, nil
 }
 
@@ -101,6 +157,7 @@ func (m CalendarModel) handleWorkhourEditRequest(msg WorkhoursViewModalEditReque
 
 	workhourDetails, _ := repository.GetAllWorkhourDetailsFromDB()
 	projects, _ := repository.GetAllProjectsFromDB()
+	tasks, _ := repository.GetAllTasks()
 	workhours := m.getWorkhoursForDate(msg.Date)
 
 	// Find the specific workhour
@@ -115,13 +172,10 @@ func (m CalendarModel) handleWorkhourEditRequest(msg WorkhoursViewModalEditReque
 	if currentWorkhour != nil {
 		m.ActiveModal = &WorkhourEditModalWrapper{
 			modal: NewWorkhourEditModal(
-				msg.WorkhourID,
-				msg.Date,
-				currentWorkhour.DetailsID,
-				currentWorkhour.ProjectID,
-				currentWorkhour.Hours,
+				*currentWorkhour,
 				workhourDetails,
 				projects,
+				tasks,
 			),
 		}
 	}
@@ -174,24 +228,22 @@ func (m CalendarModel) handlePasteWorkhours() (CalendarModel, tea.Cmd) {
 		return m, nil
 	}
 
-	err := repository.DeleteWorkhoursByDate(m.SelectedDate)
+	// The pasted day replaces the selected one as a whole, so rules see the final state
+	budgetWarnings, err := repository.ReplaceWorkhoursForDate(m.SelectedDate, m.YankedWorkhours)
 	if err != nil {
-		return m, common.NotifyError("Failed to clear existing workhours", err)
+		return m, common.NotifyError("Failed to paste workhours", err)
 	}
 
+	warningCmds := []tea.Cmd{m.checkRules(m.SelectedDate), notifyBudgetWarnings(budgetWarnings)}
+	checked := make(map[int]bool)
 	for _, wh := range m.YankedWorkhours {
-		newWorkhour := domain.Workhour{
-			Date:      m.SelectedDate,
-			DetailsID: wh.DetailsID,
-			ProjectID: wh.ProjectID,
-			Hours:     wh.Hours,
-		}
-		_, err := repository.CreateWorkhour(newWorkhour)
-		if err != nil {
-			return m, common.NotifyError("Failed to paste workhour", err)
+		if checked[wh.DetailsID] {
+			continue
 		}
+		checked[wh.DetailsID] = true
+		warningCmds = append(warningCmds, m.checkLeaveBalance(wh.DetailsID, m.SelectedDate))
 	}
-	return m, nil
+	return m, tea.Batch(warningCmds...)
 }
 
 func (m CalendarModel) handleDeleteWorkhours() (CalendarModel, tea.Cmd) {
@@ -220,14 +272,147 @@ func (m CalendarModel) handleOpenDayView() (CalendarModel, tea.Cmd) {
 		workhours := m.getWorkhoursForDate(m.SelectedDate)
 		workhourDetails, _ := repository.GetAllWorkhourDetailsFromDB()
 		projects, _ := repository.GetAllProjectsFromDB()
+		modal := NewWorkhoursViewModal(
+			m.SelectedDate,
+			workhours,
+			workhourDetails,
+			projects,
+		)
+		modal.Tasks, _ = repository.GetAllTasks()
+		modal.LeaveBalances = m.getLeaveBalances(m.SelectedDate)
 		m.ActiveModal = &WorkhoursViewModalWrapper{
-			modal: NewWorkhoursViewModal(
-				m.SelectedDate,
-				workhours,
-				workhourDetails,
-				projects,
-			),
+			modal: modal,
 		}
 	}
 	return m, nil
 }
+
+func (m CalendarModel) handleOpenYearOverview() (CalendarModel, tea.Cmd) {
+	if m.ActiveModal == nil {
+		workhourDetails, _ := repository.GetAllWorkhourDetailsFromDB()
+		m.ActiveModal = &YearOverviewModalWrapper{
+			modal: NewYearOverviewModal(m.SelectedDate, workhourDetails),
+		}
+	}
+	return m, nil
+}
+
+func (m CalendarModel) handleOpenOvertimeLedger() (CalendarModel, tea.Cmd) {
+	if m.ActiveModal == nil {
+		m.ActiveModal = &OvertimeLedgerModalWrapper{
+			modal: NewOvertimeLedgerModal(m.ViewYear, time.Now()),
+		}
+	}
+	return m, nil
+}
+
+func (m CalendarModel) handleOpenWeekView() (CalendarModel, tea.Cmd) {
+	if m.ActiveModal == nil {
+		m.ActiveModal = &WeekViewModalWrapper{
+			modal: NewWeekViewModal(m.SelectedDate),
+		}
+	}
+	return m, nil
+}
+
+func (m CalendarModel) handleOpenRuleViolations() (CalendarModel, tea.Cmd) {
+	if m.ActiveModal == nil {
+		m.ActiveModal = &RuleViolationsModalWrapper{
+			modal: NewRuleViolationsModal(time.Month(m.ViewMonth), m.ViewYear),
+		}
+	}
+	return m, nil
+}
+
+// handleToggleMonthLock locks the viewed month, or asks for a reason to unlock it
+func (m CalendarModel) handleToggleMonthLock() (CalendarModel, tea.Cmd) {
+	if m.ActiveModal != nil {
+		return m, nil
+	}
+
+	month := time.Month(m.ViewMonth)
+	lock, err := repository.GetMonthLock(m.ViewYear, month)
+	if err != nil {
+		return m, common.NotifyError("Failed to read month lock", err)
+	}
+	if lock != nil {
+		m.ActiveModal = &MonthUnlockModalWrapper{
+			modal: NewMonthUnlockModal(*lock),
+		}
+		return m, nil
+	}
+
+	if err := repository.LockMonth(m.ViewYear, month, repository.CurrentUserName()); err != nil {
+		return m, common.NotifyError("Failed to lock month", err)
+	}
+	return m, common.NotifySuccess(fmt.Sprintf("🔒 Locked %s %d", month, m.ViewYear))
+}
+
+func (m CalendarModel) handleMonthUnlocked(msg MonthUnlockSubmittedMsg) (CalendarModel, tea.Cmd) {
+	err := repository.UnlockMonth(msg.Lock.Year, msg.Lock.Month, repository.CurrentUserName(), msg.Reason)
+	if err != nil {
+		return m, common.NotifyError("Failed to unlock month", err)
+	}
+	m.ActiveModal = nil
+	return m, common.NotifySuccess("🔓 Unlocked " + msg.Lock.Label())
+}
+
+// handleReportGenerated closes the report generator, locking the reported month when asked to
+func (m CalendarModel) handleReportGenerated(msg ReportGeneratedMsg) (CalendarModel, tea.Cmd) {
+	m.ActiveModal = nil
+	copied := ""
+	if msg.Copied {
+		copied = ", summary copied to the clipboard"
+	}
+	emailed := ""
+	if len(msg.EmailedTo) > 0 {
+		emailed = ", emailed to " + strings.Join(msg.EmailedTo, ", ")
+	}
+	if !msg.LockMonth {
+		if len(msg.EmailedTo) > 0 {
+			return m, common.NotifySuccess("✉ Report emailed to " + strings.Join(msg.EmailedTo, ", "))
+		}
+		if msg.Copied {
+			return m, common.NotifySuccess("📋 Summary copied to the clipboard")
+		}
+		return m, nil
+	}
+
+	month := time.Month(msg.Month)
+	if err := repository.LockMonth(msg.Year, month, repository.CurrentUserName()); err != nil {
+		return m, common.NotifyError("Report generated"+copied+emailed+", but locking the month failed", err)
+	}
+	return m, common.NotifySuccess(fmt.Sprintf("🔒 Locked %s %d%s%s", month, msg.Year, copied, emailed))
+}
+
+func (m CalendarModel) handleYearOverviewDaySelected(msg YearOverviewDaySelectedMsg) (CalendarModel, tea.Cmd) {
+	m.SelectedDate = msg.Date
+	m.ViewMonth = int(msg.Date.Month())
+	m.ViewYear = msg.Date.Year()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+// Refresh reloads the data held by the open day or week view, after the workhours
+// were changed elsewhere; the calendar itself reads them when rendering
+func (m *CalendarModel) Refresh() {
+	viewModal := m.ViewModalParent
+	if wrapper, ok := m.ActiveModal.(*WorkhoursViewModalWrapper); ok {
+		viewModal = wrapper
+	}
+	if viewModal != nil && viewModal.modal != nil {
+		modal := viewModal.modal
+		modal.Workhours = m.getWorkhoursForDate(modal.Date)
+		modal.LeaveBalances = m.getLeaveBalances(modal.Date)
+		if modal.ShowHistory {
+			modal.loadHistory()
+		}
+		if modal.SelectedWorkhourIndex >= len(modal.Workhours) {
+			modal.SelectedWorkhourIndex = max(len(modal.Workhours)-1, 0)
+		}
+	}
+
+	if wrapper, ok := m.ActiveModal.(*WeekViewModalWrapper); ok && wrapper.modal != nil {
+		wrapper.modal.loadWorkhours()
+	}
+}
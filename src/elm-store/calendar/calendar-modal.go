@@ -112,3 +112,108 @@ func (w *ReportGeneratorModalWrapper) View(width, height int) string {
 	}
 	return w.modal.View(width, height)
 }
+
+// YearOverviewModalWrapper wraps YearOverviewModal to implement CalendarModal
+type YearOverviewModalWrapper struct {
+	modal *YearOverviewModal
+}
+
+func (w *YearOverviewModalWrapper) Update(msg tea.Msg) (CalendarModal, tea.Cmd) {
+	if w.modal == nil {
+		return nil, nil
+	}
+	updated, cmd := w.modal.Update(msg)
+	w.modal = &updated
+	return w, cmd
+}
+
+func (w *YearOverviewModalWrapper) View(width, height int) string {
+	if w.modal == nil {
+		return ""
+	}
+	return w.modal.View(width, height)
+}
+
+// OvertimeLedgerModalWrapper wraps OvertimeLedgerModal to implement CalendarModal
+type OvertimeLedgerModalWrapper struct {
+	modal *OvertimeLedgerModal
+}
+
+func (w *OvertimeLedgerModalWrapper) Update(msg tea.Msg) (CalendarModal, tea.Cmd) {
+	if w.modal == nil {
+		return nil, nil
+	}
+	updated, cmd := w.modal.Update(msg)
+	w.modal = &updated
+	return w, cmd
+}
+
+func (w *OvertimeLedgerModalWrapper) View(width, height int) string {
+	if w.modal == nil {
+		return ""
+	}
+	return w.modal.View(width, height)
+}
+
+// WeekViewModalWrapper wraps WeekViewModal to implement CalendarModal
+type WeekViewModalWrapper struct {
+	modal *WeekViewModal
+}
+
+func (w *WeekViewModalWrapper) Update(msg tea.Msg) (CalendarModal, tea.Cmd) {
+	if w.modal == nil {
+		return nil, nil
+	}
+	updated, cmd := w.modal.Update(msg)
+	w.modal = &updated
+	return w, cmd
+}
+
+func (w *WeekViewModalWrapper) View(width, height int) string {
+	if w.modal == nil {
+		return ""
+	}
+	return w.modal.View(width, height)
+}
+
+// RuleViolationsModalWrapper wraps RuleViolationsModal to implement CalendarModal
+type RuleViolationsModalWrapper struct {
+	modal *RuleViolationsModal
+}
+
+func (w *RuleViolationsModalWrapper) Update(msg tea.Msg) (CalendarModal, tea.Cmd) {
+	if w.modal == nil {
+		return nil, nil
+	}
+	updated, cmd := w.modal.Update(msg)
+	w.modal = &updated
+	return w, cmd
+}
+
+func (w *RuleViolationsModalWrapper) View(width, height int) string {
+	if w.modal == nil {
+		return ""
+	}
+	return w.modal.View(width, height)
+}
+
+// MonthUnlockModalWrapper wraps MonthUnlockModal to implement CalendarModal
+type MonthUnlockModalWrapper struct {
+	modal *MonthUnlockModal
+}
+
+func (w *MonthUnlockModalWrapper) Update(msg tea.Msg) (CalendarModal, tea.Cmd) {
+	if w.modal == nil {
+		return nil, nil
+	}
+	updated, cmd := w.modal.Update(msg)
+	w.modal = &updated
+	return w, cmd
+}
+
+func (w *MonthUnlockModalWrapper) View(width, height int) string {
+	if w.modal == nil {
+		return ""
+	}
+	return w.modal.View(width, height)
+}
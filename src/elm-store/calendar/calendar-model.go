@@ -6,6 +6,7 @@ import (
 	"time"
 	"tltui/src/common"
 	"tltui/src/domain"
+	"tltui/src/domain/repository"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,7 +20,7 @@ type CalendarModel struct {
 	ViewMonth    int // Month being viewed (1-12)
 	ViewYear     int // Year being viewed
 
-	ActiveModal     CalendarModal               // Currently displayed modal
+	ActiveModal     CalendarModal              // Currently displayed modal
 	ViewModalParent *WorkhoursViewModalWrapper // Saved view modal when CRUD modals are open
 	ShowHelp        bool
 
@@ -50,7 +51,32 @@ func (m CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ActiveModal = nil
 		return m, nil
 
+	case YearOverviewClosedMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case OvertimeLedgerClosedMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case WeekViewClosedMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case RuleViolationsClosedMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case YearOverviewDaySelectedMsg:
+		return m.handleYearOverviewDaySelected(msg)
+
 	case ReportGeneratedMsg:
+		return m.handleReportGenerated(msg)
+
+	case MonthUnlockSubmittedMsg:
+		return m.handleMonthUnlocked(msg)
+
+	case MonthUnlockCanceledMsg:
 		m.ActiveModal = nil
 		return m, nil
 
@@ -184,6 +210,21 @@ func (m CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "g":
 			return m.handleOpenReportGenerator()
 
+		case "Y":
+			return m.handleOpenYearOverview()
+
+		case "o":
+			return m.handleOpenOvertimeLedger()
+
+		case "w":
+			return m.handleOpenWeekView()
+
+		case "v":
+			return m.handleOpenRuleViolations()
+
+		case "L":
+			return m.handleToggleMonthLock()
+
 		case "enter":
 			return m.handleOpenDayView()
 		}
@@ -216,6 +257,9 @@ func (m CalendarModel) View() string {
 
 	monthName := time.Month(m.ViewMonth).String()
 	header := fmt.Sprintf("%s %d", monthName, m.ViewYear)
+	if lock, err := repository.GetMonthLock(m.ViewYear, time.Month(m.ViewMonth)); err == nil && lock != nil {
+		header += fmt.Sprintf(" 🔒 locked by %s on %s", lock.LockedBy, lock.LockedAt.Format("2006-01-02"))
+	}
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("39")).
@@ -356,10 +400,9 @@ func (m CalendarModel) View() string {
 	sb.WriteString(lipgloss.JoinVertical(lipgloss.Left, weekRows...))
 	sb.WriteString("\n")
 
-	helpText := render.RenderHelpText("←/→: day", "↑/↓: week", "</>: month", "?: help")
+	helpText := render.RenderHelpText("←/→: day", "↑/↓: week", "</>: month", "w: week", "Y: year", "?: help")
 	sb.WriteString("\n")
 	sb.WriteString(helpText)
 
 	return sb.String()
 }
-
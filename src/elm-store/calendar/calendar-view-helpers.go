@@ -2,12 +2,16 @@ package calendar
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
+	"tltui/src/common"
 	"tltui/src/domain"
 	"tltui/src/domain/repository"
 	"tltui/src/render"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -38,6 +42,145 @@ func (m CalendarModel) getProjectByID(id int) *domain.Project {
 	return project
 }
 
+// getLeaveBalances retrieves the leave balances of the year a date falls in
+func (m CalendarModel) getLeaveBalances(date time.Time) []domain.LeaveBalance {
+	balances, err := repository.GetLeaveBalancesForYear(date.Year(), date)
+	if err != nil {
+		return []domain.LeaveBalance{}
+	}
+	return balances
+}
+
+// checkLeaveBalance warns when leave logged on a date exceeds its entitlement
+func (m CalendarModel) checkLeaveBalance(detailsID int, date time.Time) tea.Cmd {
+	entitlement, err := repository.GetLeaveEntitlement(date.Year(), detailsID)
+	if err != nil || entitlement == nil {
+		return nil
+	}
+
+	balance, err := repository.GetLeaveBalance(*entitlement, date)
+	if err != nil || balance.RemainingDays >= 0 {
+		return nil
+	}
+
+	name := "leave"
+	if details := m.getWorkhourDetailsByID(detailsID); details != nil {
+		name = details.Name
+	}
+
+	return common.NotifyInfo(fmt.Sprintf(
+		"⚠ %s balance exceeded for %d: %gd over the entitlement",
+		name, date.Year(), math.Round(-balance.RemainingDays*100)/100,
+	))
+}
+
+// notifyBudgetWarnings shows the project budgets a stored change made cross a threshold
+func notifyBudgetWarnings(warnings []domain.BudgetWarning) tea.Cmd {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return common.NotifyInfo("⚠ " + domain.FormatBudgetWarnings(warnings))
+}
+
+// checkRules warns about the rules the stored entries of the dates break. Blocking
+// rules never get here, the repository rejects those changes.
+func (m CalendarModel) checkRules(dates ...time.Time) tea.Cmd {
+	var violations []domain.RuleViolation
+	seen := make(map[string]bool)
+	for _, date := range dates {
+		if seen[repository.DateToString(date)] {
+			continue
+		}
+		seen[repository.DateToString(date)] = true
+		dayViolations, err := repository.GetViolationsForDate(date)
+		if err != nil {
+			return nil
+		}
+		violations = append(violations, dayViolations...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return common.NotifyInfo("⚠ " + domain.FormatViolations(violations))
+}
+
+// previewRuleViolations evaluates the rules for the workhour a form would save, or
+// returns nil while the form is incomplete. workhourID is 0 for new entries.
+func previewRuleViolations(form *common.MixedForm, date time.Time, workhourID int, hoursRounding float64) []domain.RuleViolation {
+	if form.Validate() != nil {
+		return nil
+	}
+
+	entry := parseHoursInput(form.GetField(3).Value(), hoursRounding)
+	entry.ID = workhourID
+	entry.Date = date
+	entry.DetailsID = form.GetSearchSelect(0).GetSelectedID()
+	entry.ProjectID = form.GetSearchSelect(1).GetSelectedID()
+	if entry.HasTimes() {
+		entry.Hours = entry.TimedHours()
+	}
+
+	violations, err := repository.PreviewWorkhourRules(entry)
+	if err != nil {
+		return nil
+	}
+	return violations
+}
+
+// renderRuleViolations lists rule violations in a modal, blocking ones in red
+func renderRuleViolations(violations []domain.RuleViolation) string {
+	if len(violations) == 0 {
+		return ""
+	}
+
+	blockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	var sb strings.Builder
+	for _, v := range violations {
+		if v.Blocking() {
+			sb.WriteString(blockStyle.Render("⛔ " + v.Message))
+		} else {
+			sb.WriteString(warnStyle.Render("⚠ " + v.Message))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// recordWorkhourSelections remembers the type, project and task of a saved workhour
+// so the selects offer them first next time. Ordering is best-effort, so errors are ignored.
+func recordWorkhourSelections(detailsID, projectID, taskID int) {
+	_ = repository.RecordSelection(repository.SelectionKindWorkhourDetails, detailsID)
+	_ = repository.RecordSelection(repository.SelectionKindProject, projectID)
+	if taskID > 0 {
+		_ = repository.RecordSelection(repository.SelectionKindTask, taskID)
+	}
+}
+
+// formatWorkhourTimes formats the times of a workhour as "09:00-12:15 (30m break)",
+// or "" when it has none
+func formatWorkhourTimes(wh domain.Workhour) string {
+	if !wh.HasTimes() {
+		return ""
+	}
+	if wh.BreakMinutes > 0 {
+		return fmt.Sprintf("%s (%s break)", wh.TimeRange(), common.FormatClockDuration(float64(wh.BreakMinutes)/60))
+	}
+	return wh.TimeRange()
+}
+
+// sortWorkhoursByTime orders timed entries by start time, keeping untimed entries after them
+func sortWorkhoursByTime(workhours []domain.Workhour) {
+	sort.SliceStable(workhours, func(i, j int) bool {
+		a, b := workhours[i], workhours[j]
+		if a.HasTimes() != b.HasTimes() {
+			return a.HasTimes()
+		}
+		return a.Start < b.Start
+	})
+}
+
 // renderHelpModal renders the keyboard shortcuts help modal
 func (m CalendarModel) renderHelpModal() string {
 	var sb strings.Builder
@@ -61,7 +204,13 @@ func (m CalendarModel) renderHelpModal() string {
 		{"p", "Paste yanked workhours to selected day"},
 		{"d, x", "Delete all workhours from selected day"},
 		{"g", "Generate report for current month"},
+		{"v", "Rule violations of current month"},
+		{"L", "Lock/unlock current month"},
+		{"w", "Week view with times"},
+		{"Y", "Year overview heatmap"},
+		{"o", "Overtime ledger"},
 		{"enter", "View/edit workhours for selected day"},
+		{"S", "Sync with other devices, review conflicts"},
 		{"?", "Toggle this help"},
 		{"q/esc", "Quit"},
 	}
@@ -1,9 +1,22 @@
 package calendar
 
 import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
 	"tltui/src/domain/repository"
+	generator "tltui/src/elm-store/calendar/report-generator"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -320,3 +333,1101 @@ func TestCalendarModel_ResetToCurrentMonth(t *testing.T) {
 		t.Errorf("expected ViewYear %d, got %d", now.Year(), m.ViewYear)
 	}
 }
+
+func TestCalendarModel_Update_OpenYearOverview(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Test Detail", "TD", true)
+	project := repository.CreateTestProject(t, 1, "Test Project", 100)
+	repository.CreateTestWorkhour(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 8.0)
+	repository.CreateTestWorkhour(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 6.5)
+	repository.CreateTestWorkhour(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 8.0)
+
+	m := NewCalendarModel()
+	m.SelectedDate = time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)
+
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}}
+	updatedModel, _ := m.Update(msg)
+	cm := updatedModel.(CalendarModel)
+
+	wrapper, ok := cm.ActiveModal.(*YearOverviewModalWrapper)
+	if !ok {
+		t.Fatal("expected YearOverviewModalWrapper")
+	}
+
+	if got := wrapper.modal.MonthTotal(time.March); got != 14.5 {
+		t.Errorf("got March total %v, want 14.5", got)
+	}
+	if got := wrapper.modal.MonthTotal(time.January); got != 0 {
+		t.Errorf("got January total %v, want 0 (other year)", got)
+	}
+}
+
+func TestCalendarModel_HandleYearOverviewDaySelected(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	m := NewCalendarModel()
+	m, _ = m.handleOpenYearOverview()
+
+	date := time.Date(2023, 11, 20, 0, 0, 0, 0, time.Local)
+	updatedModel, _ := m.handleYearOverviewDaySelected(YearOverviewDaySelectedMsg{Date: date})
+
+	if updatedModel.ActiveModal != nil {
+		t.Error("expected year overview to be closed")
+	}
+	if updatedModel.ViewMonth != 11 || updatedModel.ViewYear != 2023 {
+		t.Errorf("expected view on 11/2023, got %d/%d", updatedModel.ViewMonth, updatedModel.ViewYear)
+	}
+	if !updatedModel.SelectedDate.Equal(date) {
+		t.Errorf("expected selected date %v, got %v", date, updatedModel.SelectedDate)
+	}
+}
+
+func TestCalendarModel_HandleWorkhourCreated_WarnsOnExceededLeave(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	leaveType := repository.CreateTestWorkhourDetails(t, 3, "Leave", "L", false)
+	project := repository.CreateTestProject(t, 1, "Test Project", 100)
+	repository.CreateTestLeaveEntitlement(t, 2024, leaveType.ID, 1, 0, nil)
+	repository.CreateTestWorkhour(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), leaveType.ID, project.ID, 8.0)
+
+	m := NewCalendarModel()
+
+	msg := WorkhourCreateSubmittedMsg{
+		Date:      time.Date(2024, 1, 16, 0, 0, 0, 0, time.Local),
+		DetailsID: leaveType.ID,
+		ProjectID: project.ID,
+		Hours:     8.0,
+	}
+
+	_, cmd := m.handleWorkhourCreated(msg)
+	if cmd == nil {
+		t.Fatal("expected a warning notification")
+	}
+
+	notification, ok := cmd().(common.ShowNotificationMsg)
+	if !ok {
+		t.Fatal("expected ShowNotificationMsg")
+	}
+	if notification.Type != common.NotificationInfo {
+		t.Errorf("expected info notification, got %v", notification.Type)
+	}
+}
+
+func TestCalendarModel_Update_OpenOvertimeLedger(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	m := NewCalendarModel()
+
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}}
+	updatedModel, _ := m.Update(msg)
+	cm := updatedModel.(CalendarModel)
+
+	wrapper, ok := cm.ActiveModal.(*OvertimeLedgerModalWrapper)
+	if !ok {
+		t.Fatal("expected OvertimeLedgerModalWrapper")
+	}
+	if wrapper.modal.Year != m.ViewYear {
+		t.Errorf("expected ledger for %d, got %d", m.ViewYear, wrapper.modal.Year)
+	}
+
+	updatedModel, _ = cm.Update(OvertimeLedgerClosedMsg{})
+	if updatedModel.(CalendarModel).ActiveModal != nil {
+		t.Error("expected ledger to be closed")
+	}
+}
+
+func TestWorkhourCreateModal_TaskSelectFollowsProject(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	arnia := repository.CreateTestProject(t, 1, "Arnia", 100)
+	other := repository.CreateTestProject(t, 2, "Other", 200)
+	refactor := repository.CreateTestTask(t, arnia.ID, "API refactor", 7)
+	repository.CreateTestTask(t, arnia.ID, "Code review", 0)
+	repository.CreateTestTask(t, other.ID, "Support", 0)
+
+	details, _ := repository.GetAllWorkhourDetailsFromDB()
+	projects, _ := repository.GetAllProjectsFromDB()
+	tasks, _ := repository.GetAllTasks()
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	modal := NewWorkhourCreateModal(date, details, projects, tasks)
+
+	taskSelect := modal.Form.GetSearchSelect(2)
+	if len(taskSelect.Options) != 3 {
+		t.Fatalf("expected no-task option plus 2 Arnia tasks, got %d options", len(taskSelect.Options))
+	}
+
+	// Switching the project rebuilds the task options
+	modal.Form.GetSearchSelect(1).SelectByID(other.ID)
+	modal.Update(nil)
+	if len(taskSelect.Options) != 2 || taskSelect.Options[1].DisplayName != "Support" {
+		t.Errorf("expected Other project tasks, got %+v", taskSelect.Options)
+	}
+
+	modal.Form.GetSearchSelect(1).SelectByID(arnia.ID)
+	modal.Update(nil)
+	taskSelect.SelectByID(refactor.ID)
+	modal.Form.GetField(3).Input.SetValue("6")
+
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected submit command")
+	}
+	msg, ok := cmd().(WorkhourCreateSubmittedMsg)
+	if !ok {
+		t.Fatal("expected WorkhourCreateSubmittedMsg")
+	}
+	if msg.TaskID != refactor.ID || msg.ProjectID != arnia.ID || msg.DetailsID != detail.ID {
+		t.Errorf("got %+v, want task %d on project %d", msg, refactor.ID, arnia.ID)
+	}
+
+	m := NewCalendarModel()
+	m.handleWorkhourCreated(msg)
+	workhours := m.getWorkhoursForDate(date)
+	if len(workhours) != 1 || workhours[0].TaskID != refactor.ID {
+		t.Errorf("expected workhour stored with task %d, got %+v", refactor.ID, workhours)
+	}
+}
+
+func TestReportGeneratorModal_MailReportPerClient(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	client := repository.CreateTestClient(t, "Arnia", "Arnia Software S.R.L.")
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	if err := repository.CreateProject(domain.Project{ID: 1, Name: "API", OdooID: 100, ClientID: client.ID}); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	other := repository.CreateTestProject(t, 2, "Internal", 200)
+
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)
+	repository.CreateTestWorkhour(t, date, detail.ID, 1, 6)
+	repository.CreateTestWorkhour(t, date, detail.ID, other.ID, 2)
+
+	modal := *NewReportGeneratorModal(3, 2024)
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+
+	if !modal.ShowingClientSelect {
+		t.Fatal("expected client selection before the mail report form")
+	}
+
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyDown})
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !modal.ShowingInputForm {
+		t.Fatal("expected mail report form to be open")
+	}
+	if modal.Client == nil || modal.Client.ID != client.ID {
+		t.Fatalf("expected client %d to be selected", client.ID)
+	}
+	if got := modal.ToCompanyInput.Value(); got != "Arnia Software S.R.L." {
+		t.Errorf("expected To Company to be prefilled, got %q", got)
+	}
+	if modal.PreviewStats == nil || modal.PreviewStats.TotalHours != 6 {
+		t.Errorf("expected only the client's 6 hours in the preview, got %+v", modal.PreviewStats)
+	}
+}
+
+func TestCalendarModel_HandleWorkhourEdited_WarnsOnBudgetThreshold(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "API", 100)
+	project.BudgetHours = 10
+	if err := repository.UpdateProject(project); err != nil {
+		t.Fatalf("failed to set budget: %v", err)
+	}
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	workhour := repository.CreateTestWorkhour(t, date, detail.ID, project.ID, 4)
+
+	m := NewCalendarModel()
+
+	_, cmd := m.handleWorkhourEdited(WorkhourEditSubmittedMsg{
+		WorkhourID: workhour.ID,
+		Date:       date,
+		DetailsID:  detail.ID,
+		ProjectID:  project.ID,
+		Hours:      8,
+	})
+	if cmd == nil {
+		t.Fatal("expected a budget warning")
+	}
+
+	notification, ok := cmd().(common.ShowNotificationMsg)
+	if !ok {
+		t.Fatal("expected ShowNotificationMsg")
+	}
+	if !strings.Contains(notification.Message, "80%") {
+		t.Errorf("expected 80%% warning, got %q", notification.Message)
+	}
+
+	// Editing without adding hours does not warn again
+	_, cmd = m.handleWorkhourEdited(WorkhourEditSubmittedMsg{
+		WorkhourID: workhour.ID,
+		Date:       date,
+		DetailsID:  detail.ID,
+		ProjectID:  project.ID,
+		Hours:      8,
+	})
+	if cmd != nil {
+		t.Errorf("expected no warning, got %v", cmd())
+	}
+
+	// Moving the entry to another day of the budget period does not add hours either
+	_, cmd = m.handleWorkhourEdited(WorkhourEditSubmittedMsg{
+		WorkhourID: workhour.ID,
+		Date:       date.AddDate(0, 0, 1),
+		DetailsID:  detail.ID,
+		ProjectID:  project.ID,
+		Hours:      8,
+	})
+	if cmd != nil {
+		t.Errorf("expected no warning for a moved entry, got %v", cmd())
+	}
+}
+
+func TestWorkhourCreateModal_OffersRecentSelectionsFirst(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	meeting := repository.CreateTestWorkhourDetails(t, 2, "Meeting", "MTG", true)
+	repository.CreateTestProject(t, 1, "Arnia", 100)
+	support := repository.CreateTestProject(t, 2, "Support", 200)
+
+	m := NewCalendarModel()
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	m.handleWorkhourCreated(WorkhourCreateSubmittedMsg{Date: date, DetailsID: meeting.ID, ProjectID: support.ID, Hours: 2})
+
+	details, _ := repository.GetAllWorkhourDetailsFromDB()
+	projects, _ := repository.GetAllProjectsFromDB()
+	modal := NewWorkhourCreateModal(date, details, projects, nil)
+
+	if got := modal.Form.GetSearchSelect(0).GetSelectedID(); got != meeting.ID {
+		t.Errorf("expected last used type %d to be preselected, got %d", meeting.ID, got)
+	}
+	if got := modal.Form.GetSearchSelect(1).GetSelectedID(); got != support.ID {
+		t.Errorf("expected last used project %d to be preselected, got %d", support.ID, got)
+	}
+
+	// Typing filters the focused type select
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("dev")})
+	if got := modal.Form.GetSearchSelect(0).GetSelectedID(); got != 1 {
+		t.Errorf("expected Development to match the filter, got %d", got)
+	}
+}
+
+func TestWorkhourCreateModal_ParsesDurations(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestProject(t, 1, "Arnia", 100)
+	if err := repository.SetSetting(repository.SettingHoursRounding, "0.25"); err != nil {
+		t.Fatalf("failed to set rounding: %v", err)
+	}
+
+	details, _ := repository.GetAllWorkhourDetailsFromDB()
+	projects, _ := repository.GetAllProjectsFromDB()
+	modal := NewWorkhourCreateModal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), details, projects, nil)
+	modal.Form.GetField(3).Input.SetValue("1h20m")
+
+	if view := modal.Form.GetField(3).View(); !strings.Contains(view, "= 1.25h") {
+		t.Errorf("expected the interpreted hours under the field, got %q", view)
+	}
+
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected submit command")
+	}
+	msg, ok := cmd().(WorkhourCreateSubmittedMsg)
+	if !ok {
+		t.Fatal("expected WorkhourCreateSubmittedMsg")
+	}
+	if msg.Hours != 1.25 || msg.End != 0 {
+		t.Errorf("got %vh ending %v, want 1.25h without times", msg.Hours, msg.End)
+	}
+
+	// Time ranges keep their exact times and are not rounded
+	modal.Form.GetField(3).Input.SetValue("9:00-12:15 -35m")
+	_, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg = cmd().(WorkhourCreateSubmittedMsg)
+	if msg.Start != domain.NewClockTime(9, 0) || msg.End != domain.NewClockTime(12, 15) || msg.BreakMinutes != 35 {
+		t.Errorf("unexpected times %v-%v, break %d", msg.Start, msg.End, msg.BreakMinutes)
+	}
+	if msg.Hours != 2.6667 {
+		t.Errorf("got %vh, want 2.6667h", msg.Hours)
+	}
+}
+
+func TestCalendarModel_HandleWorkhourCreated_WithTimes(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	m := NewCalendarModel()
+	m.ActiveModal = &WorkhourCreateModalWrapper{}
+	m, _ = m.handleWorkhourCreated(WorkhourCreateSubmittedMsg{
+		Date:         date,
+		DetailsID:    detail.ID,
+		ProjectID:    project.ID,
+		Hours:        1, // Recomputed from the times
+		Start:        domain.NewClockTime(9, 0),
+		End:          domain.NewClockTime(12, 30),
+		BreakMinutes: 30,
+	})
+
+	workhours := m.getWorkhoursForDate(date)
+	if len(workhours) != 1 {
+		t.Fatalf("expected 1 workhour, got %d", len(workhours))
+	}
+	if workhours[0].Hours != 3 || workhours[0].TimeRange() != "09:00-12:30" || workhours[0].BreakMinutes != 30 {
+		t.Errorf("unexpected workhour %+v", workhours[0])
+	}
+
+	// An overlapping entry is rejected and the modal stays open
+	m.ActiveModal = &WorkhourCreateModalWrapper{}
+	m, cmd := m.handleWorkhourCreated(WorkhourCreateSubmittedMsg{
+		Date:      date,
+		DetailsID: detail.ID,
+		ProjectID: project.ID,
+		Start:     domain.NewClockTime(12, 0),
+		End:       domain.NewClockTime(13, 0),
+	})
+	notification, ok := cmd().(common.ShowNotificationMsg)
+	if !ok || notification.Type != common.NotificationError {
+		t.Fatalf("expected an error notification, got %+v", notification)
+	}
+	if !strings.Contains(notification.Message, "overlap") {
+		t.Errorf("expected overlap in the message, got %q", notification.Message)
+	}
+	if m.ActiveModal == nil {
+		t.Error("expected the create modal to stay open")
+	}
+	if len(m.getWorkhoursForDate(date)) != 1 {
+		t.Error("expected the overlapping entry not to be stored")
+	}
+
+	// Entries that only touch are fine
+	m, _ = m.handleWorkhourCreated(WorkhourCreateSubmittedMsg{
+		Date:      date,
+		DetailsID: detail.ID,
+		ProjectID: project.ID,
+		Start:     domain.NewClockTime(12, 30),
+		End:       domain.NewClockTime(13, 0),
+	})
+	if len(m.getWorkhoursForDate(date)) != 2 {
+		t.Error("expected the adjacent entry to be stored")
+	}
+}
+
+func TestCalendarModel_Update_OpenWeekView(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	wednesday := time.Date(2024, 1, 17, 0, 0, 0, 0, time.Local)
+	if _, _, err := repository.CreateWorkhour(domain.Workhour{
+		Date: wednesday, DetailsID: detail.ID, ProjectID: project.ID,
+		Start: domain.NewClockTime(8, 30), End: domain.NewClockTime(12, 0),
+	}); err != nil {
+		t.Fatalf("failed to create workhour: %v", err)
+	}
+
+	m := NewCalendarModel()
+	m.SelectedDate = wednesday
+
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	cm := updatedModel.(CalendarModel)
+
+	wrapper, ok := cm.ActiveModal.(*WeekViewModalWrapper)
+	if !ok {
+		t.Fatal("expected WeekViewModalWrapper")
+	}
+	if !wrapper.modal.WeekStart.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("expected the week to start on Monday, got %v", wrapper.modal.WeekStart)
+	}
+	if view := wrapper.View(120, 60); !strings.Contains(view, "08:30-12:00") {
+		t.Error("expected the entry times in the week view")
+	}
+
+	updatedModel, _ = cm.Update(WeekViewClosedMsg{})
+	if updatedModel.(CalendarModel).ActiveModal != nil {
+		t.Error("expected week view to be closed")
+	}
+}
+
+func TestCalendarModel_BlockingRuleRejectsChanges(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	if err := repository.SetRule(domain.Rule{Kind: domain.RuleMaxDailyHours, Severity: domain.RuleBlock, Limit: 10}); err != nil {
+		t.Fatalf("failed to set rule: %v", err)
+	}
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	repository.CreateTestWorkhour(t, date, detail.ID, project.ID, 8)
+
+	// The create modal shows the violation before saving
+	details, _ := repository.GetAllWorkhourDetailsFromDB()
+	projects, _ := repository.GetAllProjectsFromDB()
+	modal := NewWorkhourCreateModal(date, details, projects, nil)
+	modal.Form.GetField(3).Input.SetValue("4")
+	modal.Update(nil)
+	if len(modal.Violations) != 1 || !modal.Violations[0].Blocking() {
+		t.Fatalf("expected a blocking violation in the modal, got %+v", modal.Violations)
+	}
+	if view := modal.View(100, 60); !strings.Contains(view, "daily maximum") {
+		t.Error("expected the violation in the modal view")
+	}
+
+	m := NewCalendarModel()
+	m.ActiveModal = &WorkhourCreateModalWrapper{modal: modal}
+	m, cmd := m.handleWorkhourCreated(WorkhourCreateSubmittedMsg{
+		Date:      date,
+		DetailsID: detail.ID,
+		ProjectID: project.ID,
+		Hours:     4,
+	})
+	notification, ok := cmd().(common.ShowNotificationMsg)
+	if !ok || notification.Type != common.NotificationError {
+		t.Fatalf("expected an error notification, got %+v", notification)
+	}
+	if m.ActiveModal == nil {
+		t.Error("expected the create modal to stay open")
+	}
+	if len(m.getWorkhoursForDate(date)) != 1 {
+		t.Error("expected the blocked entry not to be stored")
+	}
+
+	// Pasting a day that breaks the rule leaves the target day untouched
+	target := date.AddDate(0, 0, 1)
+	repository.CreateTestWorkhour(t, target, detail.ID, project.ID, 2)
+	m.YankedWorkhours = []domain.Workhour{
+		{Date: date, DetailsID: detail.ID, ProjectID: project.ID, Hours: 6},
+		{Date: date, DetailsID: detail.ID, ProjectID: project.ID, Hours: 6},
+	}
+	m.SelectedDate = target
+	m, cmd = m.handlePasteWorkhours()
+	if notification, ok := cmd().(common.ShowNotificationMsg); !ok || notification.Type != common.NotificationError {
+		t.Fatalf("expected the paste to be rejected, got %+v", notification)
+	}
+	if workhours := m.getWorkhoursForDate(target); len(workhours) != 1 || workhours[0].Hours != 2 {
+		t.Errorf("expected the target day to be unchanged, got %+v", workhours)
+	}
+}
+
+func TestCalendarModel_MovingEntryWithinWeekCountsItOnce(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	if err := repository.SetRule(domain.Rule{Kind: domain.RuleMaxWeeklyHours, Severity: domain.RuleBlock, Limit: 20}); err != nil {
+		t.Fatalf("failed to set rule: %v", err)
+	}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	moved := repository.CreateTestWorkhour(t, monday, detail.ID, project.ID, 8)
+	repository.CreateTestWorkhour(t, monday.AddDate(0, 0, 1), detail.ID, project.ID, 8)
+
+	m := NewCalendarModel()
+	_, cmd := m.handleWorkhourEdited(WorkhourEditSubmittedMsg{
+		WorkhourID: moved.ID,
+		Date:       monday.AddDate(0, 0, 2),
+		DetailsID:  detail.ID,
+		ProjectID:  project.ID,
+		Hours:      8,
+	})
+	if cmd != nil {
+		if notification, ok := cmd().(common.ShowNotificationMsg); ok && notification.Type == common.NotificationError {
+			t.Fatalf("expected the move to keep the week at 16h, got %q", notification.Message)
+		}
+	}
+	if workhours := m.getWorkhoursForDate(monday.AddDate(0, 0, 2)); len(workhours) != 1 {
+		t.Errorf("expected the entry to be moved, got %+v", workhours)
+	}
+}
+
+func TestCalendarModel_MovingEntryChecksTheDayItLeaves(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	leave := repository.CreateTestWorkhourDetails(t, 1, "Vacation", "CO", false)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	moved := repository.CreateTestWorkhour(t, monday, leave.ID, project.ID, 4)
+	repository.CreateTestWorkhour(t, monday, leave.ID, project.ID, 4)
+	repository.CreateTestWorkhour(t, tuesday, leave.ID, project.ID, 4)
+	msg := WorkhourEditSubmittedMsg{WorkhourID: moved.ID, Date: tuesday, DetailsID: leave.ID, ProjectID: project.ID, Hours: 4}
+
+	// Tuesday gets its full day, but Monday is left with half of one
+	m := NewCalendarModel()
+	_, cmd := m.handleWorkhourEdited(msg)
+	if cmd == nil {
+		t.Fatal("expected a warning")
+	}
+	if notification, ok := cmd().(common.ShowNotificationMsg); !ok || !strings.Contains(notification.Message, "2024-01-15: non-work entries total 4h") {
+		t.Errorf("expected a warning about the day the entry left, got %+v", notification)
+	}
+
+	repository.UpdateWorkhour(moved.ID, domain.Workhour{Date: monday, DetailsID: leave.ID, ProjectID: project.ID, Hours: 4})
+	if err := repository.SetRule(domain.Rule{Kind: domain.RuleNonWorkFullDay, Severity: domain.RuleBlock}); err != nil {
+		t.Fatalf("failed to set rule: %v", err)
+	}
+	_, cmd = m.handleWorkhourEdited(msg)
+	if notification, ok := cmd().(common.ShowNotificationMsg); !ok || notification.Type != common.NotificationError {
+		t.Fatalf("expected the move to be rejected, got %+v", notification)
+	}
+	if workhours := m.getWorkhoursForDate(monday); len(workhours) != 2 {
+		t.Errorf("expected the entry to stay on Monday, got %+v", workhours)
+	}
+}
+
+func TestCalendarModel_Update_OpenRuleViolations(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	leave := repository.CreateTestWorkhourDetails(t, 2, "Vacation", "CO", false)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	repository.CreateTestWorkhour(t, date, leave.ID, project.ID, 8)
+	repository.CreateTestWorkhour(t, date, detail.ID, project.ID, 2)
+
+	m := NewCalendarModel()
+	m.ViewMonth = 1
+	m.ViewYear = 2024
+
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	cm := updatedModel.(CalendarModel)
+
+	wrapper, ok := cm.ActiveModal.(*RuleViolationsModalWrapper)
+	if !ok {
+		t.Fatal("expected RuleViolationsModalWrapper")
+	}
+	if len(wrapper.modal.Violations) != 1 || wrapper.modal.Violations[0].Rule.Kind != domain.RuleNoWorkOnLeave {
+		t.Errorf("expected a work on leave violation, got %+v", wrapper.modal.Violations)
+	}
+
+	wrapper.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if wrapper.modal.Month != time.February || len(wrapper.modal.Violations) != 0 {
+		t.Errorf("expected no violations in February, got %+v", wrapper.modal.Violations)
+	}
+
+	updatedModel, _ = cm.Update(RuleViolationsClosedMsg{})
+	if updatedModel.(CalendarModel).ActiveModal != nil {
+		t.Error("expected the violations list to be closed")
+	}
+}
+
+func TestCalendarModel_MonthLock(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	workhour := repository.CreateTestWorkhour(t, date, detail.ID, project.ID, 8)
+
+	m := NewCalendarModel()
+	m.SelectedDate = date
+	m.ViewMonth = 1
+	m.ViewYear = 2024
+
+	// Generating a report with the lock option closes the month
+	updatedModel, _ := m.Update(ReportGeneratedMsg{FilePath: "report.pdf", Month: 1, Year: 2024, LockMonth: true})
+	m = updatedModel.(CalendarModel)
+	if lock, _ := repository.GetMonthLock(2024, time.January); lock == nil {
+		t.Fatal("expected January to be locked")
+	}
+	if view := m.View(); !strings.Contains(view, "🔒") {
+		t.Error("expected a lock indicator in the header")
+	}
+
+	// Changes in the locked month are rejected
+	m.YankedWorkhours = []domain.Workhour{workhour}
+	_, cmd := m.handlePasteWorkhours()
+	if notification, ok := cmd().(common.ShowNotificationMsg); !ok || !strings.Contains(notification.Message, "locked") {
+		t.Errorf("expected a locked month error, got %+v", notification)
+	}
+	if err := repository.DeleteWorkhour(workhour.ID); err == nil {
+		t.Error("expected deleting in a locked month to fail")
+	}
+	workhour.Date = date.AddDate(0, 1, 0)
+	if _, err := repository.UpdateWorkhour(workhour.ID, workhour); err == nil {
+		t.Error("expected moving an entry out of a locked month to fail")
+	}
+
+	// Unlocking asks for a reason and records it
+	updatedModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	m = updatedModel.(CalendarModel)
+	wrapper, ok := m.ActiveModal.(*MonthUnlockModalWrapper)
+	if !ok {
+		t.Fatal("expected MonthUnlockModalWrapper")
+	}
+	if _, cmd := wrapper.modal.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected an empty reason to be rejected")
+	}
+	wrapper.modal.Form.GetField(0).Input.SetValue("Invoice corrected")
+	_, cmd = wrapper.modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updatedModel, _ = m.Update(cmd())
+	m = updatedModel.(CalendarModel)
+
+	if m.ActiveModal != nil {
+		t.Error("expected the unlock modal to close")
+	}
+	if lock, _ := repository.GetMonthLock(2024, time.January); lock != nil {
+		t.Error("expected January to be unlocked")
+	}
+	history, _ := repository.GetMonthLockHistory(2024, time.January)
+	if len(history) != 1 || history[0].UnlockReason != "Invoice corrected" || history[0].UnlockedBy == "" {
+		t.Errorf("expected the unlock to be recorded, got %+v", history)
+	}
+	if err := repository.DeleteWorkhour(workhour.ID); err != nil {
+		t.Errorf("expected changes after unlocking, got %v", err)
+	}
+
+	// L on an open month locks it again
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if lock, _ := repository.GetMonthLock(2024, time.January); lock == nil {
+		t.Error("expected January to be locked again")
+	}
+}
+
+func TestWorkhoursViewModal_History(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+
+	m := NewCalendarModel()
+	m.handleWorkhourCreated(WorkhourCreateSubmittedMsg{Date: date, DetailsID: detail.ID, ProjectID: project.ID, Hours: 4})
+	workhour := m.getWorkhoursForDate(date)[0]
+	m.handleWorkhourEdited(WorkhourEditSubmittedMsg{WorkhourID: workhour.ID, Date: date, DetailsID: detail.ID, ProjectID: project.ID, Hours: 6})
+	m.handleWorkhourDeleted(WorkhourDeleteConfirmedMsg{ID: workhour.ID})
+
+	history, err := repository.GetWorkhourHistory(date, date)
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 changes, got %d", len(history))
+	}
+	if history[0].Action != domain.ChangeCreate || history[0].Old != nil || history[0].New.Hours != 4 {
+		t.Errorf("unexpected create change %+v", history[0])
+	}
+	if history[1].Action != domain.ChangeUpdate || history[1].Old.Hours != 4 || history[1].New.Hours != 6 {
+		t.Errorf("unexpected update change %+v", history[1])
+	}
+	if history[2].Action != domain.ChangeDelete || history[2].Old.Hours != 6 || history[2].New != nil {
+		t.Errorf("unexpected delete change %+v", history[2])
+	}
+	for _, change := range history {
+		if change.Source != domain.ChangeSourceTUI || change.WorkhourID != workhour.ID {
+			t.Errorf("expected a TUI change of workhour %d, got %+v", workhour.ID, change)
+		}
+	}
+
+	modal := NewWorkhoursViewModal(date, nil, []domain.WorkhourDetails{detail}, []domain.Project{project})
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	if !modal.ShowHistory || len(modal.History) != 3 {
+		t.Fatalf("expected the history panel with 3 changes, got %+v", modal.History)
+	}
+	if view := modal.View(120, 40); !strings.Contains(view, "DEV 4h (Arnia) → DEV 6h (Arnia)") {
+		t.Errorf("expected the update in the history panel, got:\n%s", view)
+	}
+}
+
+func TestReportGeneratorModal_History(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	client := repository.CreateTestClient(t, "Arnia", "Arnia Software S.R.L.")
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "API", 100)
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)
+	workhour := repository.CreateTestWorkhour(t, date, detail.ID, project.ID, 6)
+
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(path, []byte("report"), 0644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	params := domain.ReportParams{
+		ClientID:      client.ID,
+		FromCompany:   "Me",
+		ToCompany:     "Arnia Software S.R.L.",
+		InvoiceName:   "INV-7",
+		SelectedItems: map[string]map[string]bool{"API": {"Development": true}},
+	}
+	record, err := generator.RecordReport(domain.ReportKindMailReport, 3, 2024, params, path)
+	if err != nil {
+		t.Fatalf("failed to record report: %v", err)
+	}
+
+	status, _ := generator.CheckReport(record)
+	if status != domain.ReportUpToDate {
+		t.Errorf("expected a fresh report to be up to date, got %s", status)
+	}
+
+	// Entries of other clients' projects don't affect a client's report
+	repository.CreateTestWorkhour(t, date.AddDate(0, 0, 1), detail.ID, project.ID, 2)
+	if status, _ := generator.CheckReport(record); status != domain.ReportUpToDate {
+		t.Errorf("expected a report to ignore other clients' entries, got %s", status)
+	}
+
+	odoo, err := generator.RecordReport(domain.ReportKindOdooCSV, 3, 2024, domain.ReportParams{}, path)
+	if err != nil {
+		t.Fatalf("failed to record report: %v", err)
+	}
+	workhour.Hours = 7
+	if _, err := repository.UpdateWorkhour(workhour.ID, workhour); err != nil {
+		t.Fatalf("failed to update workhour: %v", err)
+	}
+	if status, _ := generator.CheckReport(odoo); status != domain.ReportDataChanged {
+		t.Errorf("expected changed hours to be detected, got %s", status)
+	}
+
+	os.WriteFile(path, []byte("edited"), 0644)
+	if status, _ := generator.CheckReport(record); status != domain.ReportFileChanged {
+		t.Errorf("expected an edited file to be detected, got %s", status)
+	}
+	os.Remove(path)
+	if status, _ := generator.CheckReport(record); status != domain.ReportFileMissing {
+		t.Errorf("expected a missing file to be detected, got %s", status)
+	}
+
+	modal := *NewReportGeneratorModal(5, 2024)
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if !modal.ShowingHistory || len(modal.History) != 2 {
+		t.Fatalf("expected the history with 2 reports, got %+v", modal.History)
+	}
+	if view := modal.View(120, 40); !strings.Contains(view, "March 2024 · INV-7") || !strings.Contains(view, "file missing") {
+		t.Errorf("unexpected history view:\n%s", view)
+	}
+
+	// Regenerating restores the period and the parameters of the selected report
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyDown})
+	modal, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil || !modal.Generating {
+		t.Fatal("expected regenerating to start")
+	}
+	if modal.ViewMonth != 3 || modal.ViewYear != 2024 || ReportType(modal.SelectedReportType) != ReportTypeMailReport {
+		t.Errorf("expected a March 2024 mail report, got %d/%d type %d", modal.ViewMonth, modal.ViewYear, modal.SelectedReportType)
+	}
+	if modal.InvoiceNameInput.Value() != "INV-7" || modal.Client == nil || modal.Client.ID != client.ID || !modal.SelectedItems["API"]["Development"] {
+		t.Errorf("expected the report parameters to be restored, got %+v", modal.reportParams())
+	}
+}
+
+func TestWorkhoursViewModal_GitSuggestions(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+
+	dir := repository.CreateTestGitRepo(t, "me@example.com")
+	repository.CreateTestGitCommit(t, dir, "me@example.com", date.Add(9*time.Hour+40*time.Minute), "Fix login")
+	repository.CreateTestGitCommit(t, dir, "me@example.com", date.Add(11*time.Hour+5*time.Minute), "Add login tests")
+	if _, err := repository.SaveGitRepo(domain.GitRepo{Path: dir, ProjectID: project.ID, DetailsID: detail.ID}); err != nil {
+		t.Fatalf("failed to save repository: %v", err)
+	}
+
+	m := NewCalendarModel()
+	modal := NewWorkhoursViewModal(date, nil, []domain.WorkhourDetails{detail}, []domain.Project{project})
+	m.ActiveModal = &WorkhoursViewModalWrapper{modal: modal}
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if !modal.ShowSuggestions || len(modal.Suggestions) != 1 {
+		t.Fatalf("expected the suggestions panel with 1 suggestion, got %+v (%s)", modal.Suggestions, modal.SuggestionsError)
+	}
+	if view := modal.View(120, 40); !strings.Contains(view, "DEV 2.25h (Arnia) 09:00-11:15") || !strings.Contains(view, "Fix login; Add login tests") {
+		t.Errorf("expected the suggestion in the panel, got:\n%s", view)
+	}
+
+	// Accepting opens the create modal with the suggestion, to be reviewed before saving
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a create request")
+	}
+	request, ok := cmd().(WorkhoursViewModalCreateRequestedMsg)
+	if !ok || request.Suggestion == nil {
+		t.Fatalf("expected a create request with the suggestion, got %+v", request)
+	}
+	m, _ = m.handleWorkhourCreateRequest(request)
+	createWrapper, ok := m.ActiveModal.(*WorkhourCreateModalWrapper)
+	if !ok {
+		t.Fatalf("expected the create modal, got %T", m.ActiveModal)
+	}
+	create := createWrapper.modal
+	if got := create.Form.GetField(3).Value(); got != "09:00-11:15" {
+		t.Errorf("expected the suggested times in the hours field, got %q", got)
+	}
+	if create.Form.GetSearchSelect(0).GetSelectedID() != detail.ID || create.Form.GetSearchSelect(1).GetSelectedID() != project.ID {
+		t.Error("expected the type and project of the repository to be selected")
+	}
+
+	// The times can be edited before saving
+	create.Form.GetField(3).Input.SetValue("09:00-11:30")
+	_, cmd = create.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleWorkhourCreated(cmd().(WorkhourCreateSubmittedMsg))
+
+	workhours := m.getWorkhoursForDate(date)
+	if len(workhours) != 1 || workhours[0].Hours != 2.5 || workhours[0].End != domain.NewClockTime(11, 30) {
+		t.Fatalf("expected the edited entry to be saved, got %+v", workhours)
+	}
+	if wrapper, ok := m.ActiveModal.(*WorkhoursViewModalWrapper); !ok || wrapper.modal != modal {
+		t.Fatalf("expected the day view back, got %T", m.ActiveModal)
+	}
+	if !modal.Suggestions[0].Logged {
+		t.Error("expected the suggestion to be marked as logged")
+	}
+}
+
+func TestWorkhourEditModal_KeepsIssueKey(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	id, _, err := repository.CreateWorkhour(domain.Workhour{Date: date, DetailsID: detail.ID, ProjectID: project.ID, Hours: 2, IssueKey: "API-12"})
+	if err != nil {
+		t.Fatalf("failed to create workhour: %v", err)
+	}
+	workhour, _ := repository.GetWorkhourByID(id)
+
+	modal := NewWorkhourEditModal(*workhour, []domain.WorkhourDetails{detail}, []domain.Project{project}, nil)
+	if got := modal.Form.GetField(4).Value(); got != "API-12" {
+		t.Fatalf("expected the issue key in the form, got %q", got)
+	}
+	modal.Form.GetField(3).Input.SetValue("3")
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	m := NewCalendarModel()
+	m, _ = m.handleWorkhourEdited(cmd().(WorkhourEditSubmittedMsg))
+	workhour, _ = repository.GetWorkhourByID(id)
+	if workhour.Hours != 3 || workhour.IssueKey != "API-12" {
+		t.Errorf("expected 3h on API-12, got %gh on %q", workhour.Hours, workhour.IssueKey)
+	}
+}
+
+func TestReportGeneratorModal_EmailReport(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	server, received := startTestSMTPServer(t)
+	t.Setenv("TLTUI_SMTP_PASSWORD", "secret")
+
+	client := repository.CreateTestClient(t, "Arnia", "Arnia Software S.R.L.")
+	client.ReportRecipients = []string{"Ana <ana@arnia.example>"}
+	if err := repository.UpdateClient(client); err != nil {
+		t.Fatalf("failed to set recipients: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "raport_activitate_arnia_march_2024.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4 report"), 0644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	modal := *NewReportGeneratorModal(3, 2024)
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !modal.EmailAfterGenerate {
+		t.Fatal("expected s to turn on emailing")
+	}
+	modal.SelectedReportType = int(ReportTypeMailReport)
+	modal.Client = &client
+	modal.FromCompanyInput.SetValue("Me S.R.L.")
+	modal.InvoiceNameInput.SetValue("INV-7")
+
+	// Without a mail server the report is kept and the email can only be skipped
+	modal, _ = modal.Update(modal.recordReport(path))
+	if !modal.ShowingEmailPreview || !strings.Contains(modal.ErrorMessage, "tltui mail smtp") {
+		t.Fatalf("expected a preview explaining the missing setup, got %q", modal.ErrorMessage)
+	}
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if msg, ok := cmd().(ReportGeneratedMsg); !ok || msg.FilePath != path || len(msg.EmailedTo) != 0 {
+		t.Errorf("expected skipping to finish without an email, got %+v", msg)
+	}
+
+	repository.SetSetting(repository.SettingSMTPServer, server)
+	repository.SetSetting(repository.SettingSMTPFrom, "Me <me@example.com>")
+	repository.SetSetting(repository.SettingSMTPUsername, "me")
+	repository.SetSetting(repository.SettingEmailSubjectTemplate, "Activity {period} {invoice} for {client}")
+
+	modal.ShowingEmailPreview = false
+	modal, _ = modal.Update(modal.recordReport(path))
+	if !modal.ShowingEmailPreview || modal.ErrorMessage != "" {
+		t.Fatalf("expected the email preview, got error %q", modal.ErrorMessage)
+	}
+	if got := modal.EmailToInput.Value(); got != "Ana <ana@arnia.example>" {
+		t.Errorf("expected the client's recipients, got %q", got)
+	}
+	view := modal.View(120, 50)
+	for _, want := range []string{"Activity March 2024 INV-7 for Arnia", "raport_activitate_arnia_march_2024.pdf", "Best regards,", "Me S.R.L."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the preview:\n%s", want, view)
+		}
+	}
+
+	// A refused recipient keeps the preview open to correct it
+	modal.EmailToInput.SetValue("Ana <ana@arnia.example>, nobody@reject.example")
+	modal, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !modal.Sending || cmd == nil {
+		t.Fatal("expected sending to start")
+	}
+	modal, _ = modal.Update(cmd())
+	if modal.Sending || !modal.ShowingEmailPreview || !strings.Contains(modal.ErrorMessage, "No such user") {
+		t.Fatalf("expected the refusal in the preview, got %q", modal.ErrorMessage)
+	}
+
+	modal.EmailToInput.SetValue("Ana <ana@arnia.example>; billing@arnia.example")
+	modal, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	generated, ok := cmd().(ReportGeneratedMsg)
+	if !ok || len(generated.EmailedTo) != 2 {
+		t.Fatalf("expected the report to be emailed to 2 recipients, got %+v", generated)
+	}
+
+	message := <-received
+	if message.From != "me@example.com" || strings.Join(message.To, ",") != "ana@arnia.example,billing@arnia.example" {
+		t.Errorf("unexpected envelope %s -> %v", message.From, message.To)
+	}
+	if message.Auth != "\x00me\x00secret" {
+		t.Errorf("expected to log in with the configured user, got %q", message.Auth)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(message.Data))
+	if err != nil {
+		t.Fatalf("failed to parse email: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject")); subject != "Activity March 2024 INV-7 for Arnia" {
+		t.Errorf("got subject %q", subject)
+	}
+	_, params, _ := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	if _, err := parts.NextPart(); err != nil {
+		t.Fatalf("expected the text part: %v", err)
+	}
+	attachment, err := parts.NextPart()
+	if err != nil {
+		t.Fatalf("expected the attachment: %v", err)
+	}
+	content, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	if attachment.FileName() != "raport_activitate_arnia_march_2024.pdf" || string(content) != "%PDF-1.4 report" {
+		t.Errorf("got attachment %q with %q", attachment.FileName(), content)
+	}
+
+	// The email is recorded with the report it sent
+	emails, _ := repository.GetSentEmails()
+	if len(emails) != 1 || emails[0].ReportID != modal.EmailRecord.ID || emails[0].Subject != "Activity March 2024 INV-7 for Arnia" {
+		t.Fatalf("expected the sent email to be recorded, got %+v", emails)
+	}
+	modal.ShowingEmailPreview = false
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if view := modal.View(140, 50); !strings.Contains(view, "✉ emailed") || !strings.Contains(view, "billing@arnia.example") {
+		t.Errorf("expected the email in the history:\n%s", view)
+	}
+
+	m := NewCalendarModel()
+	_, cmd = m.Update(generated)
+	if notification, ok := cmd().(common.ShowNotificationMsg); !ok || !strings.Contains(notification.Message, "emailed to") {
+		t.Errorf("expected an emailed notification, got %+v", notification)
+	}
+}
+
+// smtpMessage is an email received by the test SMTP server
+type smtpMessage struct {
+	From string
+	To   []string
+	Auth string // Decoded AUTH PLAIN response
+	Data string
+}
+
+// startTestSMTPServer serves a minimal SMTP dialogue on a local port and passes
+// the emails received to the returned channel. Recipients at reject.example are refused.
+func startTestSMTPServer(t *testing.T) (string, <-chan smtpMessage) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan smtpMessage, 4)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSMTP(conn, messages)
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func serveTestSMTP(conn net.Conn, messages chan<- smtpMessage) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP test")
+
+	var message smtpMessage
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case strings.HasPrefix(command, "AUTH PLAIN "):
+			decoded, _ := base64.StdEncoding.DecodeString(line[len("AUTH PLAIN "):])
+			message.Auth = string(decoded)
+			text.PrintfLine("235 Authenticated")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message.From = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			text.PrintfLine("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			to := strings.Trim(line[len("RCPT TO:"):], "<> ")
+			if strings.HasSuffix(to, "@reject.example") {
+				text.PrintfLine("550 No such user")
+				continue
+			}
+			message.To = append(message.To, to)
+			text.PrintfLine("250 OK")
+		case command == "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			message.Data = string(data)
+			messages <- message
+			message = smtpMessage{Auth: message.Auth}
+			text.PrintfLine("250 OK")
+		case command == "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
@@ -2,9 +2,13 @@ package calendar
 
 import (
 	"fmt"
+	"math"
+	"path/filepath"
 	"strings"
 	"time"
+	"tltui/src/common"
 	"tltui/src/domain"
+	"tltui/src/domain/repository"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -48,14 +52,26 @@ type WorkhoursViewModal struct {
 	Workhours       []domain.Workhour
 	WorkhourDetails []domain.WorkhourDetails
 	Projects        []domain.Project
+	Tasks           []domain.Task
+	LeaveBalances   []domain.LeaveBalance
 
 	SelectedWorkhourIndex int // Index in Workhours array for selection
+
+	ShowHistory  bool                    // Whether the change history panel is shown
+	History      []domain.WorkhourChange // Changes touching Date, loaded when the panel opens
+	HistoryError string
+
+	ShowSuggestions         bool                        // Whether the git suggestions panel is shown; it takes the keys while open
+	Suggestions             []domain.WorkhourSuggestion // Entries proposed from the day's commits, loaded when the panel opens
+	SuggestionsError        string
+	SelectedSuggestionIndex int
 }
 
 type WorkhoursViewModalClosedMsg struct{}
 
 type WorkhoursViewModalCreateRequestedMsg struct {
-	Date time.Time
+	Date       time.Time
+	Suggestion *domain.WorkhourSuggestion // Prefills the new entry when set
 }
 
 type WorkhoursViewModalEditRequestedMsg struct {
@@ -79,6 +95,10 @@ func NewWorkhoursViewModal(date time.Time, workhours []domain.Workhour, workhour
 }
 
 func (m *WorkhoursViewModal) Update(msg tea.Msg) (WorkhoursViewModal, tea.Cmd) {
+	if m.ShowSuggestions {
+		return m.updateSuggestions(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -107,6 +127,17 @@ func (m *WorkhoursViewModal) Update(msg tea.Msg) (WorkhoursViewModal, tea.Cmd) {
 					dispatchWorkhoursViewModalDeleteRequestedMsg(workhourID, m.Date),
 				)
 			}
+		case "H":
+			m.ShowHistory = !m.ShowHistory
+			if m.ShowHistory {
+				m.loadHistory()
+			}
+			return *m, nil
+		case "g":
+			m.ShowSuggestions = true
+			m.SelectedSuggestionIndex = 0
+			m.loadSuggestions()
+			return *m, nil
 		case "up", "k":
 			if len(m.Workhours) > 0 {
 				m.SelectedWorkhourIndex = (m.SelectedWorkhourIndex - 1 + len(m.Workhours)) % len(m.Workhours)
@@ -136,7 +167,13 @@ func (m *WorkhoursViewModal) View(Width, Height int) string {
 	if len(m.Workhours) == 0 {
 		sb.WriteString(emptyStyle.Render("No work hours logged for this day."))
 		sb.WriteString("\n\n")
-		sb.WriteString(render.RenderHelpText("n: new", "ESC/Enter: close"))
+		sb.WriteString(m.renderLeaveBalances())
+		sb.WriteString(m.renderHistory())
+		if m.ShowSuggestions {
+			sb.WriteString(m.renderSuggestions())
+			return render.RenderSimpleModal(Width, Height, sb.String())
+		}
+		sb.WriteString(render.RenderHelpText("n: new", "g: git suggestions", "H: history", "ESC/Enter: close"))
 		return render.RenderSimpleModal(Width, Height, sb.String())
 	}
 
@@ -149,6 +186,13 @@ func (m *WorkhoursViewModal) View(Width, Height int) string {
 			prefix = "▶ "
 		}
 		sb.WriteString(prefix)
+		if times := formatWorkhourTimes(wh); times != "" {
+			timesStyle := labelStyle
+			if i == m.SelectedWorkhourIndex {
+				timesStyle = selectedStyle
+			}
+			sb.WriteString(timesStyle.Render(times + " "))
+		}
 
 		var details *domain.WorkhourDetails
 		for _, wd := range m.WorkhourDetails {
@@ -185,7 +229,17 @@ func (m *WorkhoursViewModal) View(Width, Height int) string {
 			sb.WriteString(entryValueStyle.Render(fmt.Sprintf("%sh", hoursStr)))
 
 			if project != nil {
-				sb.WriteString(entryValueStyle.Render(fmt.Sprintf(" (%s)", project.Name)))
+				projectLabel := project.Name
+				for _, t := range m.Tasks {
+					if t.ID == wh.TaskID {
+						projectLabel = fmt.Sprintf("%s / %s", project.Name, t.Name)
+						break
+					}
+				}
+				sb.WriteString(entryValueStyle.Render(fmt.Sprintf(" (%s)", projectLabel)))
+			}
+			if wh.IssueKey != "" {
+				sb.WriteString(entryValueStyle.Render(" " + wh.IssueKey))
 			}
 
 			if details.IsWork {
@@ -238,16 +292,239 @@ func (m *WorkhoursViewModal) View(Width, Height int) string {
 
 	sb.WriteString("\n")
 
+	if len(m.LeaveBalances) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(m.renderLeaveBalances())
+	}
+
+	sb.WriteString(m.renderHistory())
+
+	if m.ShowSuggestions {
+		sb.WriteString(m.renderSuggestions())
+		return render.RenderSimpleModal(Width, Height, sb.String())
+	}
+
 	sb.WriteString(render.RenderHelpText(
 		"n: new",
 		"e/Enter: edit",
 		"d: delete",
 		"↑/↓: select",
+		"g: git suggestions",
+		"H: history",
 		"ESC: close"))
 
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
+// renderLeaveBalances lists the remaining leave days of the viewed year
+func (m *WorkhoursViewModal) renderLeaveBalances() string {
+	if len(m.LeaveBalances) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	overdrawnStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+
+	sb.WriteString(labelStyle.Render(fmt.Sprintf("Leave balance %d:", m.Date.Year())))
+	sb.WriteString("\n")
+
+	for _, b := range m.LeaveBalances {
+		name := "Unknown"
+		for _, wd := range m.WorkhourDetails {
+			if wd.ID == b.Entitlement.DetailsID {
+				name = fmt.Sprintf("%s %s", wd.ShortName, wd.Name)
+				break
+			}
+		}
+
+		remaining := math.Round(b.RemainingDays*100) / 100
+		line := fmt.Sprintf("  %s: %gd of %gd remaining", name, remaining, b.TotalDays())
+		if b.ExpiredDays > 0 {
+			line += fmt.Sprintf(" (%gd carry-over expired)", math.Round(b.ExpiredDays*100)/100)
+		}
+
+		if remaining < 0 {
+			sb.WriteString(overdrawnStyle.Render(line))
+		} else {
+			sb.WriteString(valueStyle.Render(line))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (m *WorkhoursViewModal) loadHistory() {
+	m.HistoryError = ""
+	history, err := repository.GetWorkhourHistory(m.Date, m.Date)
+	if err != nil {
+		m.HistoryError = err.Error()
+		m.History = nil
+		return
+	}
+	m.History = history
+}
+
+// renderHistory lists the recorded changes of the day, oldest first
+func (m *WorkhoursViewModal) renderHistory() string {
+	if !m.ShowHistory {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("History:"))
+	sb.WriteString("\n")
+
+	if m.HistoryError != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("  ⚠ " + m.HistoryError))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	if len(m.History) == 0 {
+		sb.WriteString(emptyStyle.Render("  No changes recorded for this day."))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	for _, change := range m.History {
+		line := fmt.Sprintf("  %s %-4s %-6s ", change.ChangedAt.Format("2006-01-02 15:04"), change.Source, change.Action)
+		switch change.Action {
+		case domain.ChangeCreate:
+			line += m.describeWorkhour(change.New)
+		case domain.ChangeDelete:
+			line += m.describeWorkhour(change.Old)
+		default:
+			line += m.describeWorkhour(change.Old) + " → " + m.describeWorkhour(change.New)
+		}
+		sb.WriteString(valueStyle.Render(line))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// describeWorkhour is the short form of a workhour used in the history, e.g. "DEV 2h (Acme) 09:00-11:00"
+func (m *WorkhoursViewModal) describeWorkhour(wh *domain.Workhour) string {
+	if wh == nil {
+		return "-"
+	}
+
+	name := "?"
+	for _, wd := range m.WorkhourDetails {
+		if wd.ID == wh.DetailsID {
+			name = wd.ShortName
+			break
+		}
+	}
+
+	desc := fmt.Sprintf("%s %gh", name, math.Round(wh.Hours*100)/100)
+	for _, p := range m.Projects {
+		if p.ID == wh.ProjectID {
+			desc += fmt.Sprintf(" (%s)", p.Name)
+			break
+		}
+	}
+	if wh.HasTimes() {
+		desc += " " + wh.TimeRange()
+	}
+	if !isSameDay(wh.Date, m.Date) {
+		desc += " on " + wh.Date.Format("2006-01-02")
+	}
+	return desc
+}
+
+// updateSuggestions handles the keys while the git suggestions panel is open
+func (m *WorkhoursViewModal) updateSuggestions(msg tea.Msg) (WorkhoursViewModal, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q", "g":
+		m.ShowSuggestions = false
+	case "up", "k":
+		if len(m.Suggestions) > 0 {
+			m.SelectedSuggestionIndex = (m.SelectedSuggestionIndex - 1 + len(m.Suggestions)) % len(m.Suggestions)
+		}
+	case "down", "j":
+		if len(m.Suggestions) > 0 {
+			m.SelectedSuggestionIndex = (m.SelectedSuggestionIndex + 1) % len(m.Suggestions)
+		}
+	case "enter", "a":
+		if m.SelectedSuggestionIndex < len(m.Suggestions) {
+			return *m, dispatchWorkhoursViewModalSuggestionAcceptedMsg(m.Date, m.Suggestions[m.SelectedSuggestionIndex])
+		}
+	case "x":
+		// Dismissed suggestions come back once the suggestions are loaded again
+		if m.SelectedSuggestionIndex < len(m.Suggestions) {
+			m.Suggestions = append(m.Suggestions[:m.SelectedSuggestionIndex:m.SelectedSuggestionIndex], m.Suggestions[m.SelectedSuggestionIndex+1:]...)
+			m.SelectedSuggestionIndex = max(min(m.SelectedSuggestionIndex, len(m.Suggestions)-1), 0)
+		}
+	}
+	return *m, nil
+}
+
+func (m *WorkhoursViewModal) loadSuggestions() {
+	m.SuggestionsError = ""
+	suggestions, err := repository.SuggestWorkhours(m.Date)
+	if err != nil {
+		m.SuggestionsError = err.Error()
+	}
+	m.Suggestions = suggestions
+	if m.SelectedSuggestionIndex >= len(m.Suggestions) {
+		m.SelectedSuggestionIndex = max(len(m.Suggestions)-1, 0)
+	}
+}
+
+// renderSuggestions lists the entries proposed from the day's commits, with their commit subjects
+func (m *WorkhoursViewModal) renderSuggestions() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Suggestions from git commits:"))
+	sb.WriteString("\n")
+
+	if m.SuggestionsError != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("  ⚠ " + m.SuggestionsError))
+		sb.WriteString("\n")
+	}
+	if len(m.Suggestions) == 0 {
+		sb.WriteString(emptyStyle.Render("  No commits of yours on this day in the repositories set up with 'tltui git add'."))
+		sb.WriteString("\n")
+		sb.WriteString(render.RenderHelpText("g/ESC: close"))
+		return sb.String()
+	}
+
+	for i, suggestion := range m.Suggestions {
+		wh := suggestion.Workhour
+		prefix := "  "
+		style := valueStyle
+		if i == m.SelectedSuggestionIndex {
+			prefix = "▶ "
+			style = selectedStyle
+		}
+
+		line := prefix + m.describeWorkhour(&wh)
+		if wh.BreakMinutes > 0 {
+			line += fmt.Sprintf(" (%s break)", common.FormatClockDuration(float64(wh.BreakMinutes)/60))
+		}
+		line += fmt.Sprintf(" from %s, %d commit(s)", filepath.Base(suggestion.Repo.Path), len(suggestion.Commits))
+		if suggestion.Logged {
+			line += " ✓ logged"
+		}
+		sb.WriteString(style.Render(line))
+		sb.WriteString("\n")
+		sb.WriteString(emptyStyle.Render("    " + suggestion.Description))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(render.RenderHelpText("↑/↓: select", "Enter: accept and edit", "x: dismiss", "g/ESC: close"))
+	return sb.String()
+}
+
 func dispatchWorkhoursViewModalClosedMsg() tea.Cmd {
 	return func() tea.Msg {
 		return WorkhoursViewModalClosedMsg{}
@@ -260,6 +537,12 @@ func dispatchWorkhoursViewModalCreateRequestedMsg(date time.Time) tea.Cmd {
 	}
 }
 
+func dispatchWorkhoursViewModalSuggestionAcceptedMsg(date time.Time, suggestion domain.WorkhourSuggestion) tea.Cmd {
+	return func() tea.Msg {
+		return WorkhoursViewModalCreateRequestedMsg{Date: date, Suggestion: &suggestion}
+	}
+}
+
 func dispatchWorkhoursViewModalEditRequestedMsg(workhourID int, date time.Time) tea.Cmd {
 	return func() tea.Msg {
 		return WorkhoursViewModalEditRequestedMsg{
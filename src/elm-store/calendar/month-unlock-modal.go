@@ -0,0 +1,111 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MonthUnlockModal asks why a locked month is reopened before unlocking it
+type MonthUnlockModal struct {
+	Lock    domain.MonthLock
+	History []domain.MonthLock // Earlier locks of the month that were unlocked
+	Form    *common.MixedForm
+}
+
+type MonthUnlockSubmittedMsg struct {
+	Lock   domain.MonthLock
+	Reason string
+}
+
+type MonthUnlockCanceledMsg struct{}
+
+func NewMonthUnlockModal(lock domain.MonthLock) *MonthUnlockModal {
+	var history []domain.MonthLock
+	if locks, err := repository.GetMonthLockHistory(lock.Year, lock.Month); err == nil {
+		for _, l := range locks {
+			if !l.IsActive() {
+				history = append(history, l)
+			}
+		}
+	}
+
+	reasonField := common.NewRequiredFormField("Reason", "e.g. corrected invoice 2025-031", 44).
+		WithCharLimit(200).
+		WithValidator(common.RequiredStringValidator("Reason"))
+
+	return &MonthUnlockModal{
+		Lock:    lock,
+		History: history,
+		Form:    common.NewMixedForm(&reasonField),
+	}
+}
+
+func (m *MonthUnlockModal) Update(msg tea.Msg) (MonthUnlockModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+			return *m, dispatchMonthUnlockSubmittedMsg(m.Lock, strings.TrimSpace(m.Form.GetField(0).Value()))
+
+		case "esc":
+			return *m, dispatchMonthUnlockCanceledMsg()
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *MonthUnlockModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render("Unlock " + m.Lock.Label()))
+	sb.WriteString("\n")
+
+	sb.WriteString(labelStyle.Render("Locked: "))
+	sb.WriteString(valueStyle.Render(fmt.Sprintf("%s by %s", m.Lock.LockedAt.Format("2006-01-02 15:04"), m.Lock.LockedBy)))
+	sb.WriteString("\n\n")
+
+	if len(m.History) > 0 {
+		sb.WriteString(labelStyle.Render("Previous unlocks:"))
+		sb.WriteString("\n")
+		for _, l := range m.History {
+			sb.WriteString(emptyStyle.Render(fmt.Sprintf("  %s by %s: %s",
+				l.UnlockedAt.Format("2006-01-02 15:04"), l.UnlockedBy, l.UnlockReason)))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	sb.WriteString(warningStyle.Render("Reports for this month may already have been sent."))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Enter: unlock", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchMonthUnlockSubmittedMsg(lock domain.MonthLock, reason string) tea.Cmd {
+	return func() tea.Msg {
+		return MonthUnlockSubmittedMsg{Lock: lock, Reason: reason}
+	}
+}
+
+func dispatchMonthUnlockCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return MonthUnlockCanceledMsg{}
+	}
+}
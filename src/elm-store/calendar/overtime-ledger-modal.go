@@ -0,0 +1,134 @@
+package calendar
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type OvertimeLedgerModal struct {
+	Year         int
+	AsOf         time.Time
+	Ledger       domain.OvertimeLedger
+	ErrorMessage string
+}
+
+type OvertimeLedgerClosedMsg struct{}
+
+func NewOvertimeLedgerModal(year int, asOf time.Time) *OvertimeLedgerModal {
+	m := &OvertimeLedgerModal{
+		Year: year,
+		AsOf: asOf,
+	}
+	m.loadLedger()
+	return m
+}
+
+func (m *OvertimeLedgerModal) loadLedger() {
+	m.ErrorMessage = ""
+	ledger, err := repository.GetOvertimeLedger(m.Year, m.AsOf)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		m.Ledger = domain.OvertimeLedger{Year: m.Year}
+		return
+	}
+	m.Ledger = ledger
+}
+
+func (m *OvertimeLedgerModal) Update(msg tea.Msg) (OvertimeLedgerModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "o":
+			return *m, dispatchOvertimeLedgerClosedMsg()
+
+		case "<", "left", "h":
+			m.Year--
+			m.loadLedger()
+		case ">", "right", "l":
+			m.Year++
+			m.loadLedger()
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *OvertimeLedgerModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Overtime Ledger - %d", m.Year)))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("241"))
+	positiveStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	negativeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("%-10s %9s %9s %9s %9s %9s %9s",
+		"Month", "Expected", "Worked", "Overtime", "In lieu", "Balance", "Running")))
+	sb.WriteString("\n")
+
+	if len(m.Ledger.Months) == 0 {
+		sb.WriteString(emptyStyle.Render("No elapsed working days in this year"))
+		sb.WriteString("\n")
+	}
+
+	for _, month := range m.Ledger.Months {
+		sb.WriteString(valueStyle.Render(fmt.Sprintf("%-10s %9s %9s %9s %9s ",
+			month.Month.String(),
+			formatLedgerHours(month.ExpectedHours),
+			formatLedgerHours(month.WorkedHours),
+			formatLedgerHours(month.OvertimeHours),
+			formatLedgerHours(month.TimeOffHours))))
+		sb.WriteString(balanceStyle(month.Balance, positiveStyle, negativeStyle).
+			Render(fmt.Sprintf("%9s", formatSignedHours(month.Balance))))
+		sb.WriteString(" ")
+		sb.WriteString(balanceStyle(month.RunningBalance, positiveStyle, negativeStyle).
+			Render(fmt.Sprintf("%9s", formatSignedHours(month.RunningBalance))))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Year balance: "))
+	sb.WriteString(balanceStyle(m.Ledger.Balance, positiveStyle, negativeStyle).Bold(true).
+		Render(formatSignedHours(m.Ledger.Balance)))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(render.RenderHelpText("</>: year", "ESC: close"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func balanceStyle(balance float64, positive, negative lipgloss.Style) lipgloss.Style {
+	if balance < 0 {
+		return negative
+	}
+	return positive
+}
+
+func formatLedgerHours(hours float64) string {
+	return fmt.Sprintf("%gh", math.Round(hours*100)/100)
+}
+
+func formatSignedHours(hours float64) string {
+	return fmt.Sprintf("%+gh", math.Round(hours*100)/100)
+}
+
+func dispatchOvertimeLedgerClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return OvertimeLedgerClosedMsg{}
+	}
+}
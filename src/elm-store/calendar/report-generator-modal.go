@@ -2,10 +2,13 @@ package calendar
 
 import (
 	"fmt"
+	"math"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
+	"tltui/src/common"
 	"tltui/src/domain"
 	"tltui/src/domain/repository"
 	generator "tltui/src/elm-store/calendar/report-generator"
@@ -21,15 +24,37 @@ type ReportType int
 const (
 	ReportTypeOdooCSV ReportType = iota
 	ReportTypeMailReport
+	ReportTypeXLSX
+	ReportTypeMarkdown
+	ReportTypeHTML
 )
 
+// Kind is the report kind recorded in the report history
+func (t ReportType) Kind() domain.ReportKind {
+	switch t {
+	case ReportTypeMailReport:
+		return domain.ReportKindMailReport
+	case ReportTypeXLSX:
+		return domain.ReportKindXLSX
+	case ReportTypeMarkdown:
+		return domain.ReportKindMarkdown
+	case ReportTypeHTML:
+		return domain.ReportKindHTML
+	}
+	return domain.ReportKindOdooCSV
+}
+
 type ReportGeneratorModal struct {
 	SelectedReportType int
 	ReportTypes        []string
 	Generating         bool
 	ErrorMessage       string
-	ViewMonth          int // Month to generate report for
-	ViewYear           int // Year to generate report for
+	ViewMonth          int                    // Month to generate report for
+	ViewYear           int                    // Year to generate report for
+	RuleViolations     []domain.RuleViolation // Violations of the month, shown before generating
+	LockAfterGenerate  bool                   // Lock the month once the report is generated
+	CopyToClipboard    bool                   // Copy generated summaries to the clipboard
+	EmailAfterGenerate bool                   // Email the generated report, after previewing the email
 
 	ShowingInputForm   bool                       // True when showing From/To company inputs
 	FromCompanyInput   textinput.Model            // "From Company" text input
@@ -40,16 +65,50 @@ type ReportGeneratorModal struct {
 	PreviewStats       *generator.WorkhourStats   // Cached stats for preview display
 	SelectedItems      map[string]map[string]bool // project -> activity -> selected
 	FocusedItemIndex   int                        // Index of focused checkbox item in the flattened list
+
+	ShowingClientSelect bool                     // True while picking the client a mail report is for
+	Clients             []domain.Client          // Clients offered before the mail report form
+	ClientSelect        *common.FormSearchSelect // Client picker, ID 0 = all projects
+	Client              *domain.Client           // Client the mail report is generated for, nil for all projects
+
+	ShowingHistory       bool                     // True while the report history list is shown
+	History              []domain.ReportRecord    // Generated reports, most recent first
+	HistoryStatuses      []domain.ReportStatus    // Status of each History entry when the list was opened
+	HistoryEmails        map[int]domain.SentEmail // Last email of each report, by report ID
+	SelectedHistoryIndex int
+
+	ShowingEmailPreview bool                  // True while the email of a generated report awaits confirmation
+	EmailRecord         domain.ReportRecord   // Generated report the email is for
+	Email               generator.ReportEmail // Email to send, empty when it could not be prepared
+	EmailToInput        textinput.Model       // Recipients, editable before sending
+	SMTPConfig          generator.SMTPConfig
+	Sending             bool
 }
 
 type ReportGeneratorModalClosedMsg struct{}
 type ReportGeneratedMsg struct {
-	FilePath string
+	FilePath  string
+	Month     int
+	Year      int
+	LockMonth bool     // Lock the reported month now that the report is out
+	Copied    bool     // The summary was copied to the clipboard
+	EmailedTo []string // Recipients the report was emailed to
 }
 type ReportGenerationFailedMsg struct {
 	Error error
 }
 
+// ReportEmailPreparedMsg carries the email of a generated report, previewed before it is sent
+type ReportEmailPreparedMsg struct {
+	Record domain.ReportRecord
+	Email  generator.ReportEmail
+	Config generator.SMTPConfig
+	Error  error // The email could not be prepared, e.g. no mail server is set up
+}
+type ReportEmailFailedMsg struct {
+	Error error
+}
+
 func NewReportGeneratorModal(viewMonth, viewYear int) *ReportGeneratorModal {
 	fromCompanyInput := textinput.New()
 	fromCompanyInput.Placeholder = "From Company"
@@ -66,9 +125,13 @@ func NewReportGeneratorModal(viewMonth, viewYear int) *ReportGeneratorModal {
 	invoiceNameInput.CharLimit = 64
 	invoiceNameInput.Width = 40
 
+	// Violations are advisory here; a failed check only hides the summary
+	violations, _ := repository.GetMonthViolations(viewYear, time.Month(viewMonth))
+
 	return &ReportGeneratorModal{
+		RuleViolations:     violations,
 		SelectedReportType: 0,
-		ReportTypes:        []string{"Odoo CSV", "Mail Report"},
+		ReportTypes:        []string{"Odoo CSV", "Mail Report", "XLSX Timesheet", "Wiki Summary (Markdown)", "Email Summary (HTML)"},
 		Generating:         false,
 		ViewMonth:          viewMonth,
 		ViewYear:           viewYear,
@@ -88,6 +151,15 @@ func (m ReportGeneratorModal) Init() tea.Cmd {
 }
 
 func (m ReportGeneratorModal) Update(msg tea.Msg) (ReportGeneratorModal, tea.Cmd) {
+	if msg, ok := msg.(ReportEmailPreparedMsg); ok {
+		m.openEmailPreview(msg)
+		return m, nil
+	}
+
+	if m.ShowingEmailPreview {
+		return m.handleEmailPreview(msg)
+	}
+
 	if m.Generating {
 		return m, nil
 	}
@@ -96,6 +168,14 @@ func (m ReportGeneratorModal) Update(msg tea.Msg) (ReportGeneratorModal, tea.Cmd
 		return m.handleInputForm(msg)
 	}
 
+	if m.ShowingClientSelect {
+		return m.handleClientSelect(msg)
+	}
+
+	if m.ShowingHistory {
+		return m.handleHistory(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -116,13 +196,7 @@ func (m ReportGeneratorModal) Update(msg tea.Msg) (ReportGeneratorModal, tea.Cmd
 
 		case "enter":
 			if m.ReportTypes[m.SelectedReportType] == "Mail Report" {
-				m.ShowingInputForm = true
-				m.FocusedInput = 0
-				m.FocusedItemIndex = -1
-				m.PreviewStats = m.calculatePreviewStats()
-				m.initializeSelectedItems()
-				m.updateInputFocus()
-				return m, nil
+				return m.startMailReport()
 			}
 			m.Generating = true
 			return m, m.generateReport()
@@ -134,12 +208,44 @@ func (m ReportGeneratorModal) Update(msg tea.Msg) (ReportGeneratorModal, tea.Cmd
 
 		case "m", "M":
 			m.SelectedReportType = 1
-			m.ShowingInputForm = true
-			m.FocusedInput = 0
-			m.FocusedItemIndex = -1
-			m.PreviewStats = m.calculatePreviewStats()
-			m.initializeSelectedItems()
-			m.updateInputFocus()
+			return m.startMailReport()
+
+		case "x", "X":
+			m.SelectedReportType = int(ReportTypeXLSX)
+			m.Generating = true
+			return m, m.generateReport()
+
+		case "w", "W":
+			m.SelectedReportType = int(ReportTypeMarkdown)
+			m.Generating = true
+			return m, m.generateReport()
+
+		case "e", "E":
+			m.SelectedReportType = int(ReportTypeHTML)
+			m.Generating = true
+			return m, m.generateReport()
+
+		case "c", "C":
+			m.CopyToClipboard = !m.CopyToClipboard
+			return m, nil
+
+		case "l", "L":
+			if repository.IsReadOnly() {
+				return m, nil
+			}
+			m.LockAfterGenerate = !m.LockAfterGenerate
+			return m, nil
+
+		case "s", "S":
+			// Sent emails are recorded, which read-only mode does not allow
+			if repository.IsReadOnly() {
+				return m, nil
+			}
+			m.EmailAfterGenerate = !m.EmailAfterGenerate
+			return m, nil
+
+		case "h", "H":
+			m.openHistory()
 			return m, nil
 		}
 	}
@@ -147,67 +253,309 @@ func (m ReportGeneratorModal) Update(msg tea.Msg) (ReportGeneratorModal, tea.Cmd
 	return m, nil
 }
 
-func (m *ReportGeneratorModal) initializeSelectedItems() {
-	if m.PreviewStats == nil {
+// openHistory loads the generated reports and checks each against its file and data
+func (m *ReportGeneratorModal) openHistory() {
+	m.ErrorMessage = ""
+	m.ShowingHistory = true
+	m.SelectedHistoryIndex = 0
+
+	records, err := repository.GetReportRecords()
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		m.History = nil
+		m.HistoryStatuses = nil
 		return
 	}
 
-	// Get workhour details to check IsWork property
-	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	m.History = records
+	m.HistoryEmails = make(map[int]domain.SentEmail)
+	emails, err := repository.GetSentEmails()
 	if err != nil {
-		return
+		m.ErrorMessage = err.Error()
+	}
+	for _, email := range emails {
+		if _, seen := m.HistoryEmails[email.ReportID]; !seen {
+			m.HistoryEmails[email.ReportID] = email
+		}
+	}
+	m.HistoryStatuses = make([]domain.ReportStatus, len(records))
+	for i, record := range records {
+		status, err := generator.CheckReport(record)
+		if err != nil {
+			m.ErrorMessage = err.Error()
+		}
+		m.HistoryStatuses[i] = status
+	}
+}
+
+func (m ReportGeneratorModal) handleHistory(msg tea.Msg) (ReportGeneratorModal, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
 	}
 
-	detailsMap := make(map[string]domain.WorkhourDetails)
-	for _, wd := range workhourDetails {
-		detailsMap[wd.Name] = wd
+	switch keyMsg.String() {
+	case "esc", "q", "h", "H":
+		m.ShowingHistory = false
+		m.ErrorMessage = ""
+		return m, nil
+
+	case "up", "k":
+		if m.SelectedHistoryIndex > 0 {
+			m.SelectedHistoryIndex--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.SelectedHistoryIndex < len(m.History)-1 {
+			m.SelectedHistoryIndex++
+		}
+		return m, nil
 	}
 
-	m.SelectedItems = make(map[string]map[string]bool)
+	if len(m.History) == 0 {
+		return m, nil
+	}
+	record := m.History[m.SelectedHistoryIndex]
 
-	for projectName, activities := range m.PreviewStats.ProjectActivityHours {
-		m.SelectedItems[projectName] = make(map[string]bool)
-		for activityName := range activities {
-			// Only preselect if IsWork is true
-			if detail, exists := detailsMap[activityName]; exists && detail.IsWork {
-				m.SelectedItems[projectName][activityName] = true
-			} else {
-				m.SelectedItems[projectName][activityName] = false
+	switch keyMsg.String() {
+	case "enter", "o":
+		m.ErrorMessage = ""
+		if err := generator.OpenFile(record.Path); err != nil {
+			m.ErrorMessage = err.Error()
+		}
+		return m, nil
+
+	case "r":
+		m.ErrorMessage = ""
+		if err := m.applyReportRecord(record); err != nil {
+			m.ErrorMessage = err.Error()
+			return m, nil
+		}
+		m.ShowingHistory = false
+		m.Generating = true
+		return m, m.generateReport()
+	}
+
+	return m, nil
+}
+
+// applyReportRecord restores the period and parameters a report was generated with
+func (m *ReportGeneratorModal) applyReportRecord(record domain.ReportRecord) error {
+	var client *domain.Client
+	if record.Params.ClientID != 0 {
+		c, err := repository.GetClientByID(record.Params.ClientID)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			return fmt.Errorf("the client of this report no longer exists")
+		}
+		client = c
+	}
+
+	m.ViewMonth = int(record.Month)
+	m.ViewYear = record.Year
+	m.Client = client
+
+	switch record.Kind {
+	case domain.ReportKindOdooCSV:
+		m.SelectedReportType = int(ReportTypeOdooCSV)
+	case domain.ReportKindXLSX:
+		m.SelectedReportType = int(ReportTypeXLSX)
+	case domain.ReportKindMarkdown:
+		m.SelectedReportType = int(ReportTypeMarkdown)
+	case domain.ReportKindHTML:
+		m.SelectedReportType = int(ReportTypeHTML)
+	case domain.ReportKindMailReport:
+		m.SelectedReportType = int(ReportTypeMailReport)
+		m.FromCompanyInput.SetValue(record.Params.FromCompany)
+		m.ToCompanyInput.SetValue(record.Params.ToCompany)
+		m.InvoiceNameInput.SetValue(record.Params.InvoiceName)
+		m.SignatureImagePath = record.Params.SignatureImagePath
+		m.SelectedItems = record.Params.SelectedItems
+	default:
+		return fmt.Errorf("reports of kind %q cannot be regenerated", record.Kind)
+	}
+
+	return nil
+}
+
+// openEmailPreview shows the email of a generated report for confirmation
+func (m *ReportGeneratorModal) openEmailPreview(msg ReportEmailPreparedMsg) {
+	m.Generating = false
+	m.ShowingEmailPreview = true
+	m.EmailRecord = msg.Record
+	m.Email = msg.Email
+	m.SMTPConfig = msg.Config
+	m.ErrorMessage = ""
+	if msg.Error != nil {
+		m.Email = generator.ReportEmail{}
+		m.ErrorMessage = fmt.Sprintf("Report saved to %s, but it cannot be emailed: %v", msg.Record.Path, msg.Error)
+	}
+
+	m.EmailToInput = textinput.New()
+	m.EmailToInput.Placeholder = "ana@example.com, billing@example.com"
+	m.EmailToInput.CharLimit = 500
+	m.EmailToInput.Width = 60
+	m.EmailToInput.SetValue(strings.Join(m.Email.To, ", "))
+	m.EmailToInput.Focus()
+}
+
+func (m ReportGeneratorModal) handleEmailPreview(msg tea.Msg) (ReportGeneratorModal, tea.Cmd) {
+	if msg, ok := msg.(ReportEmailFailedMsg); ok {
+		m.Sending = false
+		m.ErrorMessage = msg.Error.Error()
+		return m, nil
+	}
+
+	if m.Sending {
+		return m, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			// The report is kept, only the email is skipped
+			m.ShowingEmailPreview = false
+			generated := m.generatedMsg(m.EmailRecord.Path)
+			return m, func() tea.Msg { return generated }
+
+		case "enter":
+			if m.Email.From == "" {
+				return m, nil
+			}
+			recipients, err := domain.ParseEmailList(m.EmailToInput.Value())
+			if err != nil {
+				m.ErrorMessage = err.Error()
+				return m, nil
 			}
+			if len(recipients) == 0 {
+				m.ErrorMessage = "Add at least one recipient"
+				return m, nil
+			}
+			m.ErrorMessage = ""
+			m.Email.To = recipients
+			m.Sending = true
+			return m, m.sendEmail()
 		}
 	}
+
+	var cmd tea.Cmd
+	m.EmailToInput, cmd = m.EmailToInput.Update(msg)
+	return m, cmd
 }
 
-func (m ReportGeneratorModal) calculatePreviewStats() *generator.WorkhourStats {
-	startDate := time.Date(m.ViewYear, time.Month(m.ViewMonth), 1, 0, 0, 0, 0, time.Local)
-	endDate := time.Date(m.ViewYear, time.Month(m.ViewMonth+1), 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+// sendEmail sends the previewed email and reports the generated report as emailed
+func (m ReportGeneratorModal) sendEmail() tea.Cmd {
+	return func() tea.Msg {
+		sent, err := generator.SendReportEmail(m.SMTPConfig, m.Email, m.EmailRecord.ID)
+		if err != nil {
+			if !sent.SentAt.IsZero() {
+				// Sending again would email the report twice
+				return ReportGenerationFailedMsg{Error: err}
+			}
+			return ReportEmailFailedMsg{Error: err}
+		}
+		generated := m.generatedMsg(m.EmailRecord.Path)
+		generated.EmailedTo = sent.Recipients
+		return generated
+	}
+}
 
-	workhours, err := repository.GetWorkhoursByDateRange(startDate, endDate)
-	if err != nil {
-		return nil
+// startMailReport asks for the client first when clients exist, otherwise opens the form
+func (m ReportGeneratorModal) startMailReport() (ReportGeneratorModal, tea.Cmd) {
+	clients, err := repository.GetAllClients()
+	if err == nil && len(clients) > 0 {
+		m.Clients = clients
+		m.ClientSelect = newReportClientSelect(clients)
+		m.ShowingClientSelect = true
+		return m, nil
 	}
 
-	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
-	if err != nil {
-		return nil
+	m.openInputForm(nil)
+	return m, nil
+}
+
+func (m ReportGeneratorModal) handleClientSelect(msg tea.Msg) (ReportGeneratorModal, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			m.ShowingClientSelect = false
+			var client *domain.Client
+			clientID := m.ClientSelect.GetSelectedID()
+			for i := range m.Clients {
+				if m.Clients[i].ID == clientID {
+					client = &m.Clients[i]
+				}
+			}
+			_ = repository.RecordSelection(repository.SelectionKindClient, clientID) // Best-effort ordering
+			m.openInputForm(client)
+			return m, nil
+		case "esc":
+			m.ShowingClientSelect = false
+			return m, nil
+		}
 	}
 
-	projects, err := repository.GetAllProjectsFromDB()
-	if err != nil {
-		return nil
+	return m, m.ClientSelect.Update(msg)
+}
+
+// newReportClientSelect offers "All projects" and the clients, most recently used first
+func newReportClientSelect(clients []domain.Client) *common.FormSearchSelect {
+	options := []common.SelectOption{{ID: 0, DisplayName: "All projects"}}
+	for _, c := range clients {
+		options = append(options, common.SelectOption{ID: c.ID, DisplayName: c.Name, ExtraInfo: c.LegalName})
 	}
 
-	detailsMap := make(map[int]domain.WorkhourDetails)
-	for _, wd := range workhourDetails {
-		detailsMap[wd.ID] = wd
+	clientSelect := common.NewRequiredFormSearchSelect("Generate report for", options).
+		WithRecent(recentSelections(repository.SelectionKindClient))
+	clientSelect.Focus()
+	return clientSelect
+}
+
+// openInputForm shows the mail report form, prefilled with the client's details when given
+func (m *ReportGeneratorModal) openInputForm(client *domain.Client) {
+	m.Client = client
+	if client != nil {
+		m.ToCompanyInput.SetValue(client.DisplayLegalName())
 	}
 
-	projectsMap := make(map[int]domain.Project)
-	for _, p := range projects {
-		projectsMap[p.ID] = p
+	m.ShowingInputForm = true
+	m.FocusedInput = 0
+	m.FocusedItemIndex = -1
+	m.PreviewStats = m.calculatePreviewStats()
+	m.initializeSelectedItems()
+	m.updateInputFocus()
+}
+
+func (m *ReportGeneratorModal) initializeSelectedItems() {
+	if m.PreviewStats == nil {
+		return
 	}
 
-	stats := generator.CalculateWorkhourStats(workhours, detailsMap, projectsMap)
+	// Get workhour details to check IsWork property
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return
+	}
+
+	m.SelectedItems = generator.DefaultSelectedItems(*m.PreviewStats, workhourDetails)
+}
+
+func (m ReportGeneratorModal) calculatePreviewStats() *generator.WorkhourStats {
+	startDate := time.Date(m.ViewYear, time.Month(m.ViewMonth), 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(m.ViewYear, time.Month(m.ViewMonth+1), 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+
+	clientID := 0
+	if m.Client != nil {
+		clientID = m.Client.ID
+	}
+	stats, err := generator.LoadStats(startDate, endDate, clientID)
+	if err != nil {
+		return nil
+	}
+	stats.Overtime = generator.LoadMonthOvertime(m.ViewMonth, m.ViewYear)
 	return &stats
 }
 
@@ -259,6 +607,7 @@ func (m ReportGeneratorModal) handleInputForm(msg tea.Msg) (ReportGeneratorModal
 			m.FocusedInput = 0
 			m.FocusedItemIndex = -1
 			m.ErrorMessage = ""
+			m.Client = nil
 			return m, nil
 
 		case "tab", "down", "j":
@@ -402,10 +751,22 @@ func (m *ReportGeneratorModal) toggleCheckboxAtIndex(index int) {
 }
 
 func (m ReportGeneratorModal) View(width, height int) string {
+	if m.ShowingEmailPreview {
+		return m.renderEmailPreview(width, height)
+	}
+
 	if m.ShowingInputForm {
 		return m.renderInputForm(width, height)
 	}
 
+	if m.ShowingClientSelect {
+		return m.renderClientSelect(width, height)
+	}
+
+	if m.ShowingHistory {
+		return m.renderHistory(width, height)
+	}
+
 	var sb strings.Builder
 
 	monthName := time.Month(m.ViewMonth).String()
@@ -426,6 +787,11 @@ func (m ReportGeneratorModal) View(width, height int) string {
 		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
 		sb.WriteString("\n\n")
 	} else {
+		if len(m.RuleViolations) > 0 {
+			warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+			sb.WriteString(warnStyle.Render(fmt.Sprintf("⚠ %d rule violation(s) this month, press v in the calendar to review", len(m.RuleViolations))))
+			sb.WriteString("\n\n")
+		}
 		sb.WriteString("Select report type:\n\n")
 
 		highlightStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
@@ -448,14 +814,181 @@ func (m ReportGeneratorModal) View(width, height int) string {
 			}
 		}
 
+		lockBox := "[ ]"
+		if m.LockAfterGenerate {
+			lockBox = "[x]"
+		}
 		sb.WriteString("\n")
-		helpItems := []string{"↑/↓: select", "o/m: quick select", "enter: generate", "esc/q: cancel"}
+		if repository.IsReadOnly() {
+			sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Read-only mode, reports are not locked or recorded"))
+		} else {
+			sb.WriteString(lockBox + " Lock " + monthName + " after generating")
+		}
+		copyBox := "[ ]"
+		if m.CopyToClipboard {
+			copyBox = "[x]"
+		}
+		sb.WriteString("\n" + copyBox + " Copy summaries to the clipboard")
+		if !repository.IsReadOnly() {
+			emailBox := "[ ]"
+			if m.EmailAfterGenerate {
+				emailBox = "[x]"
+			}
+			sb.WriteString("\n" + emailBox + " Email the report after generating")
+		}
+		sb.WriteString("\n\n")
+		helpItems := []string{"↑/↓: select", "o/m/x/w/e: quick select", "l: lock month", "c: copy", "s: email", "h: history", "enter: generate", "esc/q: cancel"}
 		sb.WriteString(render.RenderHelpText(helpItems...))
 	}
 
 	return render.RenderSimpleModal(width, height, sb.String())
 }
 
+func (m ReportGeneratorModal) renderHistory(width, height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		Align(lipgloss.Center)
+
+	sb.WriteString(titleStyle.Render("Report History"))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	if len(m.History) == 0 {
+		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Italic(true).Render("No reports generated yet."))
+		sb.WriteString("\n\n")
+		sb.WriteString(render.RenderHelpText("esc: back"))
+		return render.RenderSimpleModal(width, height, sb.String())
+	}
+
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	missingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	for i, record := range m.History {
+		prefix := "  "
+		lineStyle := lipgloss.NewStyle()
+		if i == m.SelectedHistoryIndex {
+			prefix = "▶ "
+			lineStyle = selectedStyle
+		}
+
+		line := fmt.Sprintf("%s%s  %-11s %s", prefix, record.GeneratedAt.Format("2006-01-02 15:04"), record.Kind.Label(), record.Period())
+		if record.Params.InvoiceName != "" {
+			line += " · " + record.Params.InvoiceName
+		}
+		sb.WriteString(lineStyle.Render(line))
+
+		status := m.HistoryStatuses[i]
+		switch status {
+		case domain.ReportUpToDate:
+			sb.WriteString(okStyle.Render("  ✓ " + status.String()))
+		case domain.ReportFileMissing:
+			sb.WriteString(missingStyle.Render("  ✗ " + status.String()))
+		default:
+			sb.WriteString(warnStyle.Render("  ⚠ " + status.String()))
+		}
+		sb.WriteString("\n")
+		sb.WriteString(pathStyle.Render("    " + record.Path))
+		sb.WriteString("\n")
+		if email, ok := m.HistoryEmails[record.ID]; ok {
+			sent := fmt.Sprintf("    ✉ emailed %s to %s", email.SentAt.Format("2006-01-02 15:04"), strings.Join(email.Recipients, ", "))
+			sb.WriteString(pathStyle.Render(sent))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(render.RenderHelpText("↑/↓: select", "enter/o: open", "r: regenerate", "esc: back"))
+
+	return render.RenderSimpleModal(width, height, sb.String())
+}
+
+func (m ReportGeneratorModal) renderEmailPreview(width, height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		Align(lipgloss.Center)
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Email %s - %s", m.EmailRecord.Kind.Label(), m.EmailRecord.Period())))
+	sb.WriteString("\n\n")
+
+	if m.Sending {
+		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("⏳ Sending email..."))
+		sb.WriteString("\n\n")
+	}
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	if m.Email.From == "" {
+		sb.WriteString(render.RenderHelpText("esc: continue without email"))
+		return render.RenderSimpleModal(width, height, sb.String())
+	}
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("241"))
+	bodyStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1)
+
+	sb.WriteString(labelStyle.Render("From: ") + m.Email.From)
+	sb.WriteString("\n\n")
+	sb.WriteString(labelStyle.Render("To:"))
+	sb.WriteString("\n")
+	sb.WriteString(m.EmailToInput.View())
+	sb.WriteString("\n\n")
+	sb.WriteString(labelStyle.Render("Subject: ") + m.Email.Subject)
+	sb.WriteString("\n")
+
+	attachments := make([]string, len(m.Email.Attachments))
+	for i, path := range m.Email.Attachments {
+		attachments[i] = "📎 " + filepath.Base(path)
+	}
+	sb.WriteString(labelStyle.Render("Attachments: ") + strings.Join(attachments, "  "))
+	sb.WriteString("\n\n")
+	sb.WriteString(bodyStyle.Render(strings.TrimRight(m.Email.Body, "\n")))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(render.RenderHelpText("type: edit recipients", "enter: send", "esc: skip email"))
+
+	return render.RenderSimpleModal(width, height, sb.String())
+}
+
+func (m ReportGeneratorModal) renderClientSelect(width, height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		Align(lipgloss.Center)
+
+	monthName := time.Month(m.ViewMonth).String()
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Mail Report - %s %d", monthName, m.ViewYear)))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.ClientSelect.View())
+	sb.WriteString("\n")
+	sb.WriteString(render.RenderHelpText("type: filter", "↑/↓: select", "enter: continue", "esc: back"))
+
+	return render.RenderSimpleModal(width, height, sb.String())
+}
+
 func (m ReportGeneratorModal) renderInputForm(width, height int) string {
 	monthName := time.Month(m.ViewMonth).String()
 
@@ -481,7 +1014,18 @@ func (m ReportGeneratorModal) renderInputForm(width, height int) string {
 	sb.WriteString(labelStyle.Render("To Company:"))
 	sb.WriteString("\n")
 	sb.WriteString(m.ToCompanyInput.View())
-	sb.WriteString("\n\n")
+	sb.WriteString("\n")
+	if m.Client != nil {
+		clientStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+		details := []string{"Client: " + m.Client.Name}
+		if m.Client.TaxID != "" {
+			details = append(details, m.Client.TaxID)
+		}
+		details = append(details, "lang: "+m.Client.Language)
+		sb.WriteString(clientStyle.Render(strings.Join(details, " · ")))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
 
 	sb.WriteString(labelStyle.Render("Invoice Name:"))
 	sb.WriteString("\n")
@@ -625,6 +1169,15 @@ func (m ReportGeneratorModal) renderStatsPreview() string {
 		}
 	}
 
+	if stats.Overtime != nil {
+		sb.WriteString("\n")
+		sb.WriteString(sectionStyle.Render("Overtime"))
+		sb.WriteString("\n")
+		sb.WriteString(activityStyle.Render(fmt.Sprintf("  Month %+gh", math.Round(stats.Overtime.Balance*100)/100)))
+		sb.WriteString(daysStyle.Render(fmt.Sprintf(" (running %+gh)", math.Round(stats.Overtime.RunningBalance*100)/100)))
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
@@ -636,22 +1189,97 @@ func (m ReportGeneratorModal) generateReport() tea.Cmd {
 			if err != nil {
 				return ReportGenerationFailedMsg{Error: err}
 			}
-			return ReportGeneratedMsg{FilePath: filePath}
+			return m.recordReport(filePath)
 		case int(ReportTypeMailReport):
 			fromCompany := strings.TrimSpace(m.FromCompanyInput.Value())
 			toCompany := strings.TrimSpace(m.ToCompanyInput.Value())
 			invoiceName := strings.TrimSpace(m.InvoiceNameInput.Value())
-			filePath, err := generator.GenerateMailReport(m.ViewMonth, m.ViewYear, fromCompany, toCompany, invoiceName, m.SignatureImagePath, m.SelectedItems)
+			filePath, err := generator.GenerateMailReport(m.ViewMonth, m.ViewYear, fromCompany, toCompany, invoiceName, m.SignatureImagePath, m.SelectedItems, m.Client)
 			if err != nil {
 				return ReportGenerationFailedMsg{Error: err}
 			}
-			return ReportGeneratedMsg{FilePath: filePath}
+			return m.recordReport(filePath)
+		case int(ReportTypeXLSX):
+			filePath, err := generator.GenerateXLSXReport(m.ViewMonth, m.ViewYear)
+			if err != nil {
+				return ReportGenerationFailedMsg{Error: err}
+			}
+			return m.recordReport(filePath)
+		case int(ReportTypeMarkdown), int(ReportTypeHTML):
+			format := generator.SummaryFormatMarkdown
+			if m.SelectedReportType == int(ReportTypeHTML) {
+				format = generator.SummaryFormatHTML
+			}
+			filePath, content, err := generator.GenerateSummaryReport(format, m.ViewMonth, m.ViewYear)
+			if err != nil {
+				return ReportGenerationFailedMsg{Error: err}
+			}
+			if m.CopyToClipboard {
+				// The terminal running the program does the copying, also over SSH
+				if err := common.CopyToClipboard(os.Stdout, content); err != nil {
+					return ReportGenerationFailedMsg{Error: fmt.Errorf("summary saved to %s, but %w", filePath, err)}
+				}
+			}
+			return m.recordReport(filePath)
 		default:
 			return ReportGeneratorModalClosedMsg{}
 		}
 	}
 }
 
+// recordReport adds the generated report to the history with the parameters it was generated with.
+// Reports generated in read-only mode are not recorded.
+func (m ReportGeneratorModal) recordReport(filePath string) tea.Msg {
+	if repository.IsReadOnly() {
+		return m.generatedMsg(filePath)
+	}
+	record, err := generator.RecordReport(ReportType(m.SelectedReportType).Kind(), m.ViewMonth, m.ViewYear, m.reportParams(), filePath)
+	if err != nil {
+		return ReportGenerationFailedMsg{Error: fmt.Errorf("report saved to %s, but adding it to the history failed: %w", filePath, err)}
+	}
+	if m.EmailAfterGenerate {
+		return prepareReportEmail(record)
+	}
+	return m.generatedMsg(filePath)
+}
+
+// prepareReportEmail fills in the email of a recorded report for the preview
+func prepareReportEmail(record domain.ReportRecord) tea.Msg {
+	config, err := generator.LoadSMTPConfig()
+	if err != nil {
+		return ReportEmailPreparedMsg{Record: record, Error: err}
+	}
+	email, err := generator.PrepareReportEmail(record, config.From)
+	return ReportEmailPreparedMsg{Record: record, Email: email, Config: config, Error: err}
+}
+
+func (m ReportGeneratorModal) reportParams() domain.ReportParams {
+	if ReportType(m.SelectedReportType) != ReportTypeMailReport {
+		return domain.ReportParams{}
+	}
+
+	params := domain.ReportParams{
+		FromCompany:        strings.TrimSpace(m.FromCompanyInput.Value()),
+		ToCompany:          strings.TrimSpace(m.ToCompanyInput.Value()),
+		InvoiceName:        strings.TrimSpace(m.InvoiceNameInput.Value()),
+		SignatureImagePath: m.SignatureImagePath,
+		SelectedItems:      m.SelectedItems,
+	}
+	if m.Client != nil {
+		params.ClientID = m.Client.ID
+	}
+	return params
+}
+
+func (m ReportGeneratorModal) generatedMsg(filePath string) ReportGeneratedMsg {
+	return ReportGeneratedMsg{
+		FilePath:  filePath,
+		Month:     m.ViewMonth,
+		Year:      m.ViewYear,
+		LockMonth: m.LockAfterGenerate,
+		Copied:    m.CopyToClipboard && (m.SelectedReportType == int(ReportTypeMarkdown) || m.SelectedReportType == int(ReportTypeHTML)),
+	}
+}
 
 func dispatchReportGeneratorModalClosedMsg() tea.Cmd {
 	return func() tea.Msg {
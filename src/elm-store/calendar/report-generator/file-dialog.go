@@ -55,6 +55,13 @@ func OpenImageFileDialog() tea.Cmd {
 
 // OpenCSVSaveDialog opens a save dialog for CSV files
 func OpenCSVSaveDialog(sourceFile string) (string, error) {
+	return openSaveDialog(sourceFile, "Save Odoo CSV Report", "*.csv")
+}
+
+// openSaveDialog asks where to save a generated file, titled title and offering the
+// files matching pattern, and moves it there. Without a dialog, or when the dialog is
+// cancelled, the file stays where it was generated.
+func openSaveDialog(sourceFile, title, pattern string) (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
@@ -69,15 +76,15 @@ func OpenCSVSaveDialog(sourceFile string) (string, error) {
 	case commandExists("zenity"):
 		cmd = exec.Command("zenity", "--file-selection", "--save", "--confirm-overwrite",
 			"--filename="+defaultPath,
-			"--title=Save Odoo CSV Report")
+			"--title="+title)
 	case commandExists("kdialog"):
-		cmd = exec.Command("kdialog", "--getsavefilename", defaultPath, "*.csv")
+		cmd = exec.Command("kdialog", "--getsavefilename", defaultPath, pattern)
 	case commandExists("osascript"):
 		script := fmt.Sprintf(`
 			set defaultPath to POSIX file "%s"
-			set saveFile to choose file name with prompt "Save Odoo CSV Report" default name "%s" default location (path to home folder)
+			set saveFile to choose file name with prompt "%s" default name "%s" default location (path to home folder)
 			return POSIX path of saveFile
-		`, defaultPath, defaultFileName)
+		`, defaultPath, title, defaultFileName)
 		cmd = exec.Command("osascript", "-e", script)
 	default:
 		return sourceFile, nil
@@ -145,6 +152,29 @@ func OpenPDFSaveDialog(sourceFile string) (string, error) {
 	return targetPath, nil
 }
 
+// OpenFile opens a file with the desktop's default application
+func OpenFile(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	var cmd *exec.Cmd
+	switch {
+	case commandExists("xdg-open"):
+		cmd = exec.Command("xdg-open", path)
+	case commandExists("open"):
+		cmd = exec.Command("open", path)
+	default:
+		return fmt.Errorf("no application found to open %s", path)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
 // commandExists checks if a command is available in PATH
 func commandExists(cmd string) bool {
 	_, err := exec.LookPath(cmd)
@@ -0,0 +1,111 @@
+package report_generator
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"tltui/src/domain"
+)
+
+// GitLabSinkName identifies GitLab time tracking among the timesheet sinks
+const GitLabSinkName = "gitlab"
+
+// GitLabSink adds the hours of entries to the time spent on GitLab issues with /spend
+// notes, for entries whose issue key is a reference such as "group/api#12"
+type GitLabSink struct {
+	URL    string       // Instance, e.g. https://gitlab.com
+	Token  string       // Personal access token with the api scope
+	Client *http.Client // nil for http.DefaultClient
+}
+
+func (s GitLabSink) Name() string { return GitLabSinkName }
+
+func (s GitLabSink) Accepts(entry TimesheetEntry) bool {
+	_, _, ok := domain.ParseGitLabIssue(entry.Workhour.IssueKey)
+	return ok
+}
+
+func (s GitLabSink) Push(entries []TimesheetEntry) ([]string, error) {
+	var remoteIDs []string
+	for _, entry := range entries {
+		remoteID, err := s.pushEntry(entry)
+		if err != nil {
+			return remoteIDs, fmt.Errorf("failed to log the %s entry of %s on %s: %w",
+				entry.Details.ShortName, entry.Workhour.Date.Format("2006-01-02"), entry.Workhour.IssueKey, err)
+		}
+		remoteIDs = append(remoteIDs, remoteID)
+	}
+	return remoteIDs, nil
+}
+
+// pushEntry spends the hours of an entry on its issue. Time spent in GitLab cannot
+// be edited, so a changed entry pushed before spends the difference.
+func (s GitLabSink) pushEntry(entry TimesheetEntry) (string, error) {
+	wh := entry.Workhour
+	minutes := gitLabMinutes(wh.Hours)
+	if export := entry.Export; export != nil {
+		minutes -= gitLabMinutes(export.Hours)
+		if minutes == 0 {
+			return export.RemoteID, nil
+		}
+	}
+	return s.spend(wh.IssueKey, minutes, wh.Date.Format("2006-01-02"))
+}
+
+// Retract takes the time of an export back from its issue. An issue deleted since
+// has no time left to take back.
+func (s GitLabSink) Retract(export domain.WorkhourExport) error {
+	minutes := gitLabMinutes(export.Hours)
+	if minutes == 0 {
+		return nil
+	}
+	_, err := s.spend(export.IssueKey, -minutes, export.Date.Format("2006-01-02"))
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// spend posts a /spend note and returns its ID, or the issue reference when GitLab
+// only applied the command without keeping a note
+func (s GitLabSink) spend(issueKey string, minutes int, date string) (string, error) {
+	project, iid, ok := domain.ParseGitLabIssue(issueKey)
+	if !ok {
+		return "", fmt.Errorf("invalid GitLab issue %q", issueKey)
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues/%d/notes", strings.TrimRight(s.URL, "/"), url.PathEscape(project), iid)
+	body := map[string]string{"body": fmt.Sprintf("/spend %s %s", formatGitLabDuration(minutes), date)}
+	var note struct {
+		ID int `json:"id"`
+	}
+	if err := sendJSON(s.Client, http.MethodPost, endpoint, http.Header{"Private-Token": {s.Token}}, body, &note); err != nil {
+		return "", err
+	}
+	if note.ID == 0 {
+		return issueKey, nil
+	}
+	return strconv.Itoa(note.ID), nil
+}
+
+func gitLabMinutes(hours float64) int {
+	return int(math.Round(hours * 60))
+}
+
+// formatGitLabDuration writes minutes as a GitLab duration, e.g. "1h30m" or "-45m"
+func formatGitLabDuration(minutes int) string {
+	sign := ""
+	if minutes < 0 {
+		sign, minutes = "-", -minutes
+	}
+	switch {
+	case minutes%60 == 0:
+		return fmt.Sprintf("%s%dh", sign, minutes/60)
+	case minutes < 60:
+		return fmt.Sprintf("%s%dm", sign, minutes)
+	}
+	return fmt.Sprintf("%s%dh%dm", sign, minutes/60, minutes%60)
+}
@@ -0,0 +1,62 @@
+package report_generator
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// BuildICS writes the workhours between start and end passing filter as an iCalendar
+// document. Types that are not work, such as leave and public holidays, and time off
+// in lieu are exported as out-of-office events.
+func BuildICS(w io.Writer, start, end time.Time, filter domain.ICSFilter) error {
+	workhours, err := repository.GetWorkhoursByDateRange(start, end)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workhours: %w", err)
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return fmt.Errorf("failed to fetch workhour details: %w", err)
+	}
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	tasks, err := repository.GetAllTasks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+
+	detailsMap := make(map[int]domain.WorkhourDetails)
+	leaveTypes := make(map[int]bool)
+	for _, wd := range workhourDetails {
+		detailsMap[wd.ID] = wd
+		if !wd.IsWork || wd.OvertimeRole == domain.OvertimeRoleTimeOffInLieu {
+			leaveTypes[wd.ID] = true
+		}
+	}
+	projectsMap := make(map[int]domain.Project)
+	for _, p := range projects {
+		projectsMap[p.ID] = p
+	}
+	tasksMap := make(map[int]domain.Task)
+	for _, t := range tasks {
+		tasksMap[t.ID] = t
+	}
+
+	var selected []domain.Workhour
+	for _, wh := range workhours {
+		if filter.Matches(wh) {
+			selected = append(selected, wh)
+		}
+	}
+
+	name := "tltui"
+	if profile := repository.CurrentProfile(); profile != "" && profile != repository.DefaultProfile {
+		name += " (" + profile + ")"
+	}
+	events := domain.WorkhourEvents(selected, detailsMap, projectsMap, tasksMap, leaveTypes, repository.GetDailyTargetHours())
+	return domain.WriteICS(w, name, events, time.Now())
+}
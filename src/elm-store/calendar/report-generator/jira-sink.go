@@ -0,0 +1,123 @@
+package report_generator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"tltui/src/domain"
+)
+
+// JiraSinkName identifies Jira worklogs among the timesheet sinks
+const JiraSinkName = "jira"
+
+// JiraSink logs entries as worklogs of Jira Cloud issues, for entries whose issue key
+// is a Jira key such as "API-12"
+type JiraSink struct {
+	URL    string // Site, e.g. https://example.atlassian.net
+	Email  string // Account the API token belongs to
+	Token  string
+	Client *http.Client // nil for http.DefaultClient
+}
+
+// jiraWorklog is the request and response body of the worklog endpoints
+type jiraWorklog struct {
+	ID               string  `json:"id,omitempty"`
+	Started          string  `json:"started"`
+	TimeSpentSeconds int     `json:"timeSpentSeconds"`
+	Comment          jiraDoc `json:"comment"`
+}
+
+// jiraDoc is a comment in the Atlassian document format, holding one paragraph
+type jiraDoc struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version,omitempty"`
+	Content []jiraDoc `json:"content,omitempty"`
+	Text    string    `json:"text,omitempty"`
+}
+
+func (s JiraSink) Name() string { return JiraSinkName }
+
+func (s JiraSink) Accepts(entry TimesheetEntry) bool {
+	return domain.IsJiraIssueKey(entry.Workhour.IssueKey)
+}
+
+func (s JiraSink) Push(entries []TimesheetEntry) ([]string, error) {
+	var remoteIDs []string
+	for _, entry := range entries {
+		remoteID, err := s.pushEntry(entry)
+		if err != nil {
+			return remoteIDs, fmt.Errorf("failed to log the %s entry of %s on %s: %w",
+				entry.Details.ShortName, entry.Workhour.Date.Format("2006-01-02"), entry.Workhour.IssueKey, err)
+		}
+		remoteIDs = append(remoteIDs, remoteID)
+	}
+	return remoteIDs, nil
+}
+
+// pushEntry updates the worklog of an entry pushed before, or adds one. A worklog
+// deleted in Jira is added again.
+func (s JiraSink) pushEntry(entry TimesheetEntry) (string, error) {
+	wh := entry.Workhour
+	worklog := jiraWorklog{
+		Started:          jiraStarted(wh),
+		TimeSpentSeconds: int(wh.Hours*3600 + 0.5),
+		Comment: jiraDoc{Type: "doc", Version: 1, Content: []jiraDoc{
+			{Type: "paragraph", Content: []jiraDoc{{Type: "text", Text: entry.Description()}}},
+		}},
+	}
+
+	if export := entry.Export; export != nil {
+		err := sendJSON(s.Client, http.MethodPut, s.worklogURL(wh.IssueKey, export.RemoteID), s.header(), worklog, nil)
+		if !isNotFound(err) {
+			return export.RemoteID, err
+		}
+	}
+
+	var created jiraWorklog
+	if err := sendJSON(s.Client, http.MethodPost, s.worklogURL(wh.IssueKey, ""), s.header(), worklog, &created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("no worklog ID in the response")
+	}
+	return created.ID, nil
+}
+
+// Retract deletes the worklog of an export, unless it was deleted in Jira already
+func (s JiraSink) Retract(export domain.WorkhourExport) error {
+	if !domain.IsJiraIssueKey(export.IssueKey) {
+		return nil
+	}
+	err := sendJSON(s.Client, http.MethodDelete, s.worklogURL(export.IssueKey, export.RemoteID), s.header(), nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (s JiraSink) worklogURL(issueKey, worklogID string) string {
+	endpoint := fmt.Sprintf("%s/rest/api/3/issue/%s/worklog", strings.TrimRight(s.URL, "/"), url.PathEscape(issueKey))
+	if worklogID != "" {
+		endpoint += "/" + url.PathEscape(worklogID)
+	}
+	return endpoint
+}
+
+func (s JiraSink) header() http.Header {
+	req := http.Request{Header: http.Header{}}
+	req.SetBasicAuth(s.Email, s.Token)
+	return req.Header
+}
+
+// jiraStarted is the start of a worklog in Jira's format. Entries without times are
+// logged as starting at 09:00.
+func jiraStarted(wh domain.Workhour) string {
+	start := domain.NewClockTime(9, 0)
+	if wh.HasTimes() {
+		start = wh.Start
+	}
+	started := time.Date(wh.Date.Year(), wh.Date.Month(), wh.Date.Day(), 0, int(start), 0, 0, time.Local)
+	return started.Format("2006-01-02T15:04:05.000-0700")
+}
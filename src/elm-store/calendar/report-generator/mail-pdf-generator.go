@@ -2,6 +2,7 @@ package report_generator
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -19,27 +20,133 @@ import (
 	"github.com/johnfercher/maroto/v2/pkg/consts/align"
 	"github.com/johnfercher/maroto/v2/pkg/consts/border"
 	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
 	"github.com/johnfercher/maroto/v2/pkg/props"
 )
 
-// GenerateMailReport generates a PDF activity report for the given month
-func GenerateMailReport(viewMonth, viewYear int, fromCompany, toCompany, invoiceName, signatureImagePath string, selectedItems map[string]map[string]bool) (string, error) {
+// mailReportLabels holds the fixed texts of the PDF in one report language
+type mailReportLabels struct {
+	Title         string
+	FromCompany   string
+	ToCompany     string
+	TaxID         string
+	Address       string
+	InvoiceNumber string
+	HoursTitle    string
+	Headers       []string
+	TimesHeader   string // Inserted after the date when the report has times
+	Overtime      string
+	OvertimeMonth string // Format of the overtime balance, month then running balance
+	SignatureFrom string
+	SignatureTo   string
+	FilePrefix    string
+}
+
+var mailReportLabelsByLanguage = map[string]mailReportLabels{
+	"ro": {
+		Title:         "Raport de activitate",
+		FromCompany:   "Firma prestatoare:",
+		ToCompany:     "Catre:",
+		TaxID:         "CUI:",
+		Address:       "Adresa:",
+		InvoiceNumber: "Referitor la factura numarul:",
+		HoursTitle:    "Raport de ore lucrate",
+		Headers:       []string{"Data", "Proiect", "Descriere", "Ore lucrate"},
+		TimesHeader:   "Interval",
+		Overtime:      "Ore suplimentare:",
+		OvertimeMonth: "%+gh luna aceasta (sold %+gh)",
+		SignatureFrom: "Semnatura Prestator,",
+		SignatureTo:   "Semnatura Beneficiar,",
+		FilePrefix:    "raport_activitate",
+	},
+	"en": {
+		Title:         "Activity report",
+		FromCompany:   "Service provider:",
+		ToCompany:     "To:",
+		TaxID:         "Tax ID:",
+		Address:       "Address:",
+		InvoiceNumber: "Regarding invoice number:",
+		HoursTitle:    "Worked hours report",
+		Headers:       []string{"Date", "Project", "Description", "Hours worked"},
+		TimesHeader:   "Time",
+		Overtime:      "Overtime balance:",
+		OvertimeMonth: "%+gh this month (running %+gh)",
+		SignatureFrom: "Provider signature,",
+		SignatureTo:   "Client signature,",
+		FilePrefix:    "activity_report",
+	},
+}
+
+// labelsForClient picks the report language of the client, Romanian by default
+func labelsForClient(client *domain.Client) mailReportLabels {
+	if client != nil {
+		if labels, ok := mailReportLabelsByLanguage[client.Language]; ok {
+			return labels
+		}
+	}
+	return mailReportLabelsByLanguage["ro"]
+}
+
+// GenerateMailReport generates a PDF activity report for the given month.
+// When a client is given, its details and report language are used.
+func GenerateMailReport(viewMonth, viewYear int, fromCompany, toCompany, invoiceName, signatureImagePath string, selectedItems map[string]map[string]bool, client *domain.Client) (string, error) {
+	filePath := filepath.Join(os.TempDir(), MailReportFileName(viewMonth, viewYear, client))
+
+	err := BuildMailReport(filePath, viewMonth, viewYear, fromCompany, toCompany, invoiceName, signatureImagePath, selectedItems, client)
+	if err != nil {
+		return "", err
+	}
+
+	savePath, err := OpenPDFSaveDialog(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+
+	if savePath != filePath {
+		err = copyFile(filePath, savePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to save report: %w", err)
+		}
+		os.Remove(filePath)
+	}
+
+	return savePath, nil
+}
+
+// MailReportFileName names the PDF report of a month, e.g. "raport_activitate_acme_march_2025.pdf"
+func MailReportFileName(viewMonth, viewYear int, client *domain.Client) string {
+	labels := labelsForClient(client)
+	monthName := strings.ToLower(time.Month(viewMonth).String())
+	if client != nil {
+		return fmt.Sprintf("%s_%s_%s_%d.pdf", labels.FilePrefix, fileNameSlug(client.Name), monthName, viewYear)
+	}
+	return fmt.Sprintf("%s_%s_%d.pdf", labels.FilePrefix, monthName, viewYear)
+}
+
+// BuildMailReport writes the PDF activity report of a month to filePath, covering the
+// selected activities of each project
+func BuildMailReport(filePath string, viewMonth, viewYear int, fromCompany, toCompany, invoiceName, signatureImagePath string, selectedItems map[string]map[string]bool, client *domain.Client) error {
 	startDate := time.Date(viewYear, time.Month(viewMonth), 1, 0, 0, 0, 0, time.Local)
 	endDate := time.Date(viewYear, time.Month(viewMonth+1), 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
 
 	workhours, err := repository.GetWorkhoursByDateRange(startDate, endDate)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch workhours: %w", err)
+		return fmt.Errorf("failed to fetch workhours: %w", err)
 	}
 
 	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch workhour details: %w", err)
+		return fmt.Errorf("failed to fetch workhour details: %w", err)
 	}
 
 	projects, err := repository.GetAllProjectsFromDB()
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch projects: %w", err)
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	tasks, err := repository.GetAllTasks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
 	}
 
 	detailsMap := make(map[int]domain.WorkhourDetails)
@@ -64,36 +171,26 @@ func GenerateMailReport(viewMonth, viewYear int, fromCompany, toCompany, invoice
 		}
 	}
 
-	stats := CalculateWorkhourStats(filteredWorkhours, detailsMap, projectsMap)
+	tasksMap := make(map[int]domain.Task)
+	for _, t := range tasks {
+		tasksMap[t.ID] = t
+	}
 
-	tmpDir := os.TempDir()
-	monthName := time.Month(viewMonth).String()
-	fileName := fmt.Sprintf("raport_activitate_%s_%d.pdf", strings.ToLower(monthName), viewYear)
-	filePath := filepath.Join(tmpDir, fileName)
+	stats := CalculateWorkhourStats(filteredWorkhours, detailsMap, projectsMap, tasksMap)
+	stats.Overtime = LoadMonthOvertime(viewMonth, viewYear)
 
-	err = generatePDFReport(filePath, viewMonth, viewYear, fromCompany, toCompany, invoiceName, signatureImagePath, stats)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate PDF: %w", err)
-	}
+	labels := labelsForClient(client)
 
-	savePath, err := OpenPDFSaveDialog(filePath)
+	err = generatePDFReport(filePath, viewMonth, viewYear, fromCompany, toCompany, invoiceName, signatureImagePath, stats, client, labels)
 	if err != nil {
-		return "", fmt.Errorf("failed to open save dialog: %w", err)
-	}
-
-	if savePath != filePath {
-		err = copyFile(filePath, savePath)
-		if err != nil {
-			return "", fmt.Errorf("failed to save report: %w", err)
-		}
-		os.Remove(filePath)
+		return fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
-	return savePath, nil
+	return nil
 }
 
 // generatePDFReport creates the actual PDF file with formatted content
-func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, toCompany, invoiceName, signatureImagePath string, stats WorkhourStats) error {
+func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, toCompany, invoiceName, signatureImagePath string, stats WorkhourStats, client *domain.Client, labels mailReportLabels) error {
 	cfg := config.NewBuilder().
 		WithPageNumber().
 		Build()
@@ -104,7 +201,7 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 	// Add title and company info as regular rows (only on first page)
 	m.AddRow(5)
 	m.AddRow(10,
-		text.NewCol(12, "Raport de activitate", props.Text{
+		text.NewCol(12, labels.Title, props.Text{
 			Top:   3,
 			Size:  16,
 			Style: fontstyle.Bold,
@@ -113,7 +210,7 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 	)
 	m.AddRow(15)
 	m.AddRow(5,
-		text.NewCol(4, "Firma prestatoare:", props.Text{
+		text.NewCol(4, labels.FromCompany, props.Text{
 			Size:  10,
 			Top:   1,
 			Style: fontstyle.Bold,
@@ -124,7 +221,7 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 		}),
 	)
 	m.AddRow(5,
-		text.NewCol(4, "Catre:", props.Text{
+		text.NewCol(4, labels.ToCompany, props.Text{
 			Size:  10,
 			Top:   1,
 			Style: fontstyle.Bold,
@@ -134,8 +231,26 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 			Top:  1,
 		}),
 	)
+	if client != nil {
+		for _, detail := range [][2]string{{labels.TaxID, client.TaxID}, {labels.Address, client.Address}} {
+			if detail[1] == "" {
+				continue
+			}
+			m.AddRow(5,
+				text.NewCol(4, detail[0], props.Text{
+					Size:  10,
+					Top:   1,
+					Style: fontstyle.Bold,
+				}),
+				text.NewCol(8, detail[1], props.Text{
+					Size: 10,
+					Top:  1,
+				}),
+			)
+		}
+	}
 	m.AddRow(5,
-		text.NewCol(4, "Referitor la factura numarul:", props.Text{
+		text.NewCol(4, labels.InvoiceNumber, props.Text{
 			Size:  10,
 			Top:   1,
 			Style: fontstyle.Bold,
@@ -149,7 +264,7 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 	m.AddRow(30)
 
 	m.AddRow(8,
-		text.NewCol(12, "Raport de ore lucrate", props.Text{
+		text.NewCol(12, labels.HoursTitle, props.Text{
 			Top:   2,
 			Size:  11,
 			Style: fontstyle.Bold,
@@ -157,36 +272,48 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 		}),
 	)
 
-	tableHeaders := []string{"Data", "Proiect", "Descriere", "Ore lucrate"}
-	var tableRows [][]string
-
 	dates := make([]string, 0, len(stats.DailyBreakdown))
-	for date := range stats.DailyBreakdown {
+	hasTimes := false
+	for date, entries := range stats.DailyBreakdown {
 		dates = append(dates, date)
+		for _, entry := range entries {
+			if entry.TimeRange != "" {
+				hasTimes = true
+			}
+		}
 	}
 	sort.Strings(dates)
 
+	// The times column only appears when some entry has start/end times
+	tableHeaders := labels.Headers
+	columnSizes := []int{3, 3, 3, 3}
+	if hasTimes {
+		tableHeaders = []string{labels.Headers[0], labels.TimesHeader, labels.Headers[1], labels.Headers[2], labels.Headers[3]}
+		columnSizes = []int{2, 2, 3, 3, 2}
+	}
+
+	var tableRows [][]string
 	totalHours := 0.0
 	for _, dateStr := range dates {
 		entries := stats.DailyBreakdown[dateStr]
 
 		for _, entry := range entries {
-			tableRows = append(tableRows, []string{
-				dateStr,
-				entry.ProjectName,
-				entry.ActivityName,
-				fmt.Sprintf("%g", entry.Hours),
-			})
+			projectName := entry.ProjectName
+			if entry.TaskName != "" {
+				projectName = fmt.Sprintf("%s / %s", entry.ProjectName, entry.TaskName)
+			}
+			rowData := []string{dateStr, projectName, entry.ActivityName, fmt.Sprintf("%g", entry.Hours)}
+			if hasTimes {
+				rowData = []string{dateStr, entry.TimeRange, projectName, entry.ActivityName, fmt.Sprintf("%g", entry.Hours)}
+			}
+			tableRows = append(tableRows, rowData)
 			totalHours += entry.Hours
 		}
 	}
 
-	tableRows = append(tableRows, []string{
-		"",
-		"",
-		"",
-		fmt.Sprintf("%g", totalHours),
-	})
+	totalRow := make([]string, len(columnSizes))
+	totalRow[len(totalRow)-1] = fmt.Sprintf("%g", totalHours)
+	tableRows = append(tableRows, totalRow)
 
 	darkBlue := &props.Color{Red: 54, Green: 69, Blue: 92}
 	lightBlue := &props.Color{Red: 207, Green: 226, Blue: 243}
@@ -200,39 +327,19 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 		BorderThickness: 0.5,
 	}
 
+	headerCols := make([]core.Col, len(columnSizes))
+	for i, size := range columnSizes {
+		headerCols[i] = col.New(size).Add(text.New(tableHeaders[i], props.Text{
+			Top:   1.5,
+			Size:  9,
+			Style: fontstyle.Bold,
+			Align: align.Center,
+			Color: white,
+		})).WithStyle(headerCellStyle)
+	}
+
 	// Register table header to repeat on every page
-	err := m.RegisterHeader(
-		row.New(7).Add(
-			col.New(3).Add(text.New(tableHeaders[0], props.Text{
-				Top:   1.5,
-				Size:  9,
-				Style: fontstyle.Bold,
-				Align: align.Center,
-				Color: white,
-			})).WithStyle(headerCellStyle),
-			col.New(3).Add(text.New(tableHeaders[1], props.Text{
-				Top:   1.5,
-				Size:  9,
-				Style: fontstyle.Bold,
-				Align: align.Center,
-				Color: white,
-			})).WithStyle(headerCellStyle),
-			col.New(3).Add(text.New(tableHeaders[2], props.Text{
-				Top:   1.5,
-				Size:  9,
-				Style: fontstyle.Bold,
-				Align: align.Center,
-				Color: white,
-			})).WithStyle(headerCellStyle),
-			col.New(3).Add(text.New(tableHeaders[3], props.Text{
-				Top:   1.5,
-				Size:  9,
-				Style: fontstyle.Bold,
-				Align: align.Center,
-				Color: white,
-			})).WithStyle(headerCellStyle),
-		),
-	)
+	err := m.RegisterHeader(row.New(7).Add(headerCols...))
 
 	if err != nil {
 		return fmt.Errorf("failed to register table header: %w", err)
@@ -255,38 +362,40 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 			BorderThickness: 0.5,
 		}
 
-		m.AddRow(6,
-			col.New(3).Add(text.New(rowData[0], props.Text{
-				Top:   1,
-				Size:  8,
-				Align: align.Center,
-			})).WithStyle(cellStyle),
-			col.New(3).Add(text.New(rowData[1], props.Text{
-				Top:   1,
-				Size:  8,
-				Align: align.Center,
-			})).WithStyle(cellStyle),
-			col.New(3).Add(text.New(rowData[2], props.Text{
+		cols := make([]core.Col, len(columnSizes))
+		for j, size := range columnSizes {
+			cols[j] = col.New(size).Add(text.New(rowData[j], props.Text{
 				Top:   1,
 				Size:  8,
 				Align: align.Center,
-			})).WithStyle(cellStyle),
-			col.New(3).Add(text.New(rowData[3], props.Text{
+			})).WithStyle(cellStyle)
+		}
+		m.AddRow(6, cols...)
+	}
+
+	if stats.Overtime != nil {
+		m.AddRow(4)
+		m.AddRow(5,
+			text.NewCol(4, labels.Overtime, props.Text{
+				Size:  10,
 				Top:   1,
-				Size:  8,
-				Align: align.Center,
-			})).WithStyle(cellStyle),
+				Style: fontstyle.Bold,
+			}),
+			text.NewCol(8, overtimeSummary(labels, *stats.Overtime), props.Text{
+				Size: 10,
+				Top:  1,
+			}),
 		)
 	}
 
 	m.AddRow(10,
-		text.NewCol(6, "Semnatura Prestator,", props.Text{
+		text.NewCol(6, labels.SignatureFrom, props.Text{
 			Size:  10,
 			Top:   4,
 			Align: align.Left,
 			Style: fontstyle.Bold,
 		}),
-		text.NewCol(6, "Semnatura Beneficiar,", props.Text{
+		text.NewCol(6, labels.SignatureTo, props.Text{
 			Size:  10,
 			Top:   4,
 			Align: align.Right,
@@ -319,3 +428,22 @@ func generatePDFReport(filePath string, viewMonth, viewYear int, fromCompany, to
 
 	return nil
 }
+
+// overtimeSummary describes the overtime balance of the month and of the year so far
+func overtimeSummary(labels mailReportLabels, overtime domain.OvertimeMonth) string {
+	return fmt.Sprintf(labels.OvertimeMonth, math.Round(overtime.Balance*100)/100, math.Round(overtime.RunningBalance*100)/100)
+}
+
+// fileNameSlug turns a name into a lowercase file name fragment
+func fileNameSlug(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_"):
+			sb.WriteRune('_')
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
@@ -0,0 +1,40 @@
+package report_generator
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/domain/repository"
+)
+
+func TestBuildMailReport_OvertimeBalance(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	detail := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	project := repository.CreateTestProject(t, 1, "API", 100)
+	repository.CreateTestWorkhour(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local), detail.ID, project.ID, 10)
+
+	client := repository.CreateTestClient(t, "Arnia", "Arnia Software S.R.L.")
+	client.Language = "en"
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	selected := map[string]map[string]bool{"API": {"Development": true}}
+	if err := BuildMailReport(path, 3, 2024, "Me", "Arnia", "INV-7", "", selected, &client); err != nil {
+		t.Fatalf("failed to build report: %v", err)
+	}
+
+	// The PDF content streams are not compressed, so the texts can be read back
+	data, _ := os.ReadFile(path)
+	overtime := LoadMonthOvertime(3, 2024)
+	if overtime == nil {
+		t.Fatal("expected an overtime ledger row for March")
+	}
+	want := fmt.Sprintf("(%+gh this month \\(running %+gh\\)) Tj", math.Round(overtime.Balance*100)/100, math.Round(overtime.RunningBalance*100)/100)
+	if !strings.Contains(string(data), "(Overtime balance:) Tj") || !strings.Contains(string(data), want) {
+		t.Errorf("expected the overtime balance %q in the report", want)
+	}
+}
@@ -12,83 +12,102 @@ import (
 
 // GenerateOdooCSVReport generates an Odoo-compatible CSV timesheet export
 func GenerateOdooCSVReport(viewMonth, viewYear int) (string, error) {
+	tmpDir := os.TempDir()
+	monthName := time.Month(viewMonth).String()
+	fileName := fmt.Sprintf("odoo_timesheet_%s_%d.csv", monthName, viewYear)
+	filePath := filepath.Join(tmpDir, fileName)
+
+	if err := BuildOdooCSVReport(filePath, viewMonth, viewYear); err != nil {
+		return "", err
+	}
+
+	return OpenCSVSaveDialog(filePath)
+}
+
+// BuildOdooCSVReport writes the Odoo timesheet export of a month to filePath
+func BuildOdooCSVReport(filePath string, viewMonth, viewYear int) error {
 	startDate := time.Date(viewYear, time.Month(viewMonth), 1, 0, 0, 0, 0, time.Local)
 	endDate := time.Date(viewYear, time.Month(viewMonth+1), 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
 
-	workhours, err := repository.GetWorkhoursByDateRange(startDate, endDate)
+	entries, err := LoadTimesheetEntries(startDate, endDate, OdooCSVSinkName)
 	if err != nil {
-		return "", fmt.Errorf("failed to get workhours: %w", err)
+		return err
 	}
 
-	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
-	if err != nil {
-		return "", fmt.Errorf("failed to get workhour details: %w", err)
-	}
+	_, err = OdooCSVSink{Path: filePath}.Push(entries)
+	return err
+}
 
-	projects, err := repository.GetAllProjectsFromDB()
-	if err != nil {
-		return "", fmt.Errorf("failed to get projects: %w", err)
-	}
+// OdooCSVSinkName identifies the Odoo import file among the timesheet sinks
+const OdooCSVSinkName = "odoo_csv"
 
-	detailsMap := make(map[int]domain.WorkhourDetails)
-	for _, wd := range workhourDetails {
-		detailsMap[wd.ID] = wd
-	}
+// OdooCSVSink writes the entries pushed to it as an Odoo timesheet import file.
+// The file is rewritten on every push, so it is not tracked in the export records.
+type OdooCSVSink struct {
+	Path string
+}
 
-	projectsMap := make(map[int]domain.Project)
-	for _, p := range projects {
-		projectsMap[p.ID] = p
-	}
+func (s OdooCSVSink) Name() string { return OdooCSVSinkName }
 
-	tmpDir := os.TempDir()
-	monthName := time.Month(viewMonth).String()
-	fileName := fmt.Sprintf("odoo_timesheet_%s_%d.csv", monthName, viewYear)
-	filePath := filepath.Join(tmpDir, fileName)
+func (s OdooCSVSink) Accepts(entry TimesheetEntry) bool { return true }
+
+// Retract has nothing to remove, as the file is not tracked in the export records
+func (s OdooCSVSink) Retract(export domain.WorkhourExport) error { return nil }
+
+func (s OdooCSVSink) Push(entries []TimesheetEntry) ([]string, error) {
+	// The task column is only exported when at least one entry is linked to an Odoo task
+	includeTasks := false
+	for _, entry := range entries {
+		if entry.Task.OdooID != 0 {
+			includeTasks = true
+			break
+		}
+	}
 
-	file, err := os.Create(filePath)
+	file, err := os.Create(s.Path)
 	if err != nil {
-		return "", fmt.Errorf("failed to create file: %w", err)
+		return nil, fmt.Errorf("failed to create file: %w", err)
 	}
 
 	writer := csv.NewWriter(file)
 
 	header := []string{"date", "account_id/id", "journal_id/id", "name", "unit_amount"}
+	if includeTasks {
+		header = append(header, "task_id/id")
+	}
 	if err := writer.Write(header); err != nil {
 		file.Close()
-		return "", fmt.Errorf("failed to write header: %w", err)
+		return nil, fmt.Errorf("failed to write header: %w", err)
 	}
 
-	for _, wh := range workhours {
-		details, ok := detailsMap[wh.DetailsID]
-		if !ok {
-			continue
-		}
-
-		project, ok := projectsMap[wh.ProjectID]
-		if !ok {
-			continue
-		}
-
+	for _, entry := range entries {
 		row := []string{
-			repository.DateToString(wh.Date),
-			fmt.Sprintf("__export__.account_analytic_account_%d", project.OdooID),
+			repository.DateToString(entry.Workhour.Date),
+			fmt.Sprintf("__export__.account_analytic_account_%d", entry.Project.OdooID),
 			"hr_timesheet.analytic_journal",
-			details.Name,
-			fmt.Sprintf("%g", wh.Hours),
+			entry.Details.Name,
+			fmt.Sprintf("%g", entry.Workhour.Hours),
+		}
+		if includeTasks {
+			taskRef := ""
+			if entry.Task.OdooID != 0 {
+				taskRef = fmt.Sprintf("__export__.project_task_%d", entry.Task.OdooID)
+			}
+			row = append(row, taskRef)
 		}
 
 		if err := writer.Write(row); err != nil {
 			file.Close()
-			return "", fmt.Errorf("failed to write row: %w", err)
+			return nil, fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
 		file.Close()
-		return "", fmt.Errorf("failed to flush csv: %w", err)
+		return nil, fmt.Errorf("failed to flush csv: %w", err)
 	}
 	file.Close()
 
-	return OpenCSVSaveDialog(filePath)
+	return make([]string, len(entries)), nil
 }
@@ -0,0 +1,110 @@
+package report_generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// RecordReport adds a generated report to the history, hashing its file and the
+// workhours of its period so later changes to either can be detected
+func RecordReport(kind domain.ReportKind, viewMonth, viewYear int, params domain.ReportParams, filePath string) (domain.ReportRecord, error) {
+	record := domain.ReportRecord{
+		Kind:        kind,
+		Year:        viewYear,
+		Month:       time.Month(viewMonth),
+		Params:      params,
+		Path:        filePath,
+		GeneratedAt: time.Now(),
+	}
+
+	contentHash, err := hashFile(filePath)
+	if err != nil {
+		return record, fmt.Errorf("failed to hash report: %w", err)
+	}
+	record.ContentHash = contentHash
+
+	dataHash, err := reportDataHash(record)
+	if err != nil {
+		return record, err
+	}
+	record.DataHash = dataHash
+
+	id, err := repository.CreateReportRecord(record)
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+
+	return record, nil
+}
+
+// CheckReport compares a recorded report with its file and the current workhours
+func CheckReport(record domain.ReportRecord) (domain.ReportStatus, error) {
+	contentHash, err := hashFile(record.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.ReportFileMissing, nil
+	}
+	if err != nil {
+		return domain.ReportUpToDate, fmt.Errorf("failed to hash report: %w", err)
+	}
+
+	dataHash, err := reportDataHash(record)
+	if err != nil {
+		return domain.ReportUpToDate, err
+	}
+	if dataHash != record.DataHash {
+		return domain.ReportDataChanged, nil
+	}
+	if contentHash != record.ContentHash {
+		return domain.ReportFileChanged, nil
+	}
+
+	return domain.ReportUpToDate, nil
+}
+
+// reportDataHash fingerprints the workhours a report of the record's period and client covers
+func reportDataHash(record domain.ReportRecord) (string, error) {
+	startDate := time.Date(record.Year, record.Month, 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	workhours, err := repository.GetWorkhoursByDateRange(startDate, endDate)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch workhours: %w", err)
+	}
+
+	if record.Params.ClientID != 0 {
+		projects, err := repository.GetAllProjectsFromDB()
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch projects: %w", err)
+		}
+		projectsMap := make(map[int]domain.Project)
+		for _, p := range projects {
+			projectsMap[p.ID] = p
+		}
+		workhours = FilterWorkhoursByClient(workhours, projectsMap, record.Params.ClientID)
+	}
+
+	return domain.WorkhoursFingerprint(workhours), nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,236 @@
+package report_generator
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// SMTPConfig is the mail server reports are sent through
+type SMTPConfig struct {
+	Server   string // host:port
+	Username string // Empty when the server needs no login
+	Password string
+	From     string // Sender address, e.g. "Ana Pop <ana@example.com>"
+}
+
+// LoadSMTPConfig reads the mail server settings and the password from TLTUI_SMTP_PASSWORD
+func LoadSMTPConfig() (SMTPConfig, error) {
+	var config SMTPConfig
+	for key, value := range map[string]*string{
+		repository.SettingSMTPServer:   &config.Server,
+		repository.SettingSMTPUsername: &config.Username,
+		repository.SettingSMTPFrom:     &config.From,
+	} {
+		setting, _, err := repository.GetSetting(key)
+		if err != nil {
+			return config, err
+		}
+		*value = setting
+	}
+	config.Password = os.Getenv("TLTUI_SMTP_PASSWORD")
+
+	if config.Server == "" || config.From == "" {
+		return config, fmt.Errorf("email is not set up, run 'tltui mail smtp HOST:PORT FROM [USERNAME]'")
+	}
+	return config, nil
+}
+
+// ReportEmail is a generated report ready to be emailed
+type ReportEmail struct {
+	From        string
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []string // Paths of the attached files
+}
+
+// PrepareReportEmail fills the configured templates with the values of a generated
+// report and addresses it to the recipients of the report's client
+func PrepareReportEmail(record domain.ReportRecord, from string) (ReportEmail, error) {
+	email := ReportEmail{From: from, Attachments: []string{record.Path}}
+
+	clientName := ""
+	if record.Params.ClientID != 0 {
+		client, err := repository.GetClientByID(record.Params.ClientID)
+		if err != nil {
+			return email, err
+		}
+		if client != nil {
+			clientName = client.Name
+			email.To = client.ReportRecipients
+		}
+	}
+
+	subject, body, err := repository.GetEmailTemplates()
+	if err != nil {
+		return email, err
+	}
+	email.Subject = RenderEmailLine(domain.RenderEmailTemplate(subject, record, clientName))
+	email.Body = domain.RenderEmailTemplate(body, record, clientName)
+
+	return email, nil
+}
+
+// RenderEmailLine turns a rendered template into a single header line,
+// collapsing line breaks and repeated spaces left by empty placeholders
+func RenderEmailLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// Message builds the MIME message of the email, with the body as text and
+// the files as attachments
+func (e ReportEmail) Message() ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", formatAddresses([]string{e.From}))
+	fmt.Fprintf(&message, "To: %s\r\n", formatAddresses(e.To))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", e.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Message-ID: %s\r\n", messageID(e.From))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write email body: %w", err)
+	}
+	body := quotedprintable.NewWriter(textPart)
+	if _, err := body.Write([]byte(strings.ReplaceAll(e.Body, "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write email body: %w", err)
+	}
+
+	for _, path := range e.Attachments {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", err)
+		}
+
+		name := filepath.Base(path)
+		contentType := mime.TypeByExtension(filepath.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": name})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write attachment: %w", err)
+		}
+
+		// Base64 lines are kept under the 76 characters mail servers expect
+		encoded := base64.StdEncoding.EncodeToString(data)
+		for len(encoded) > 76 {
+			fmt.Fprintf(part, "%s\r\n", encoded[:76])
+			encoded = encoded[76:]
+		}
+		fmt.Fprintf(part, "%s\r\n", encoded)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish email: %w", err)
+	}
+	message.Write(buf.Bytes())
+	return message.Bytes(), nil
+}
+
+// formatAddresses writes addresses for a header, encoding names that are not ASCII
+func formatAddresses(addresses []string) string {
+	formatted := make([]string, len(addresses))
+	for i, value := range addresses {
+		formatted[i] = value
+		if address, err := mail.ParseAddress(value); err == nil {
+			formatted[i] = address.String()
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// messageID makes a unique Message-ID on the domain of the sender
+func messageID(from string) string {
+	host := "tltui"
+	if address, err := mail.ParseAddress(from); err == nil {
+		if _, domainPart, ok := strings.Cut(address.Address, "@"); ok {
+			host = domainPart
+		}
+	}
+	random := make([]byte, 12)
+	rand.Read(random)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), host)
+}
+
+// SendReportEmail sends the email through the mail server and records it as sent
+// for the report with the given history ID
+func SendReportEmail(config SMTPConfig, email ReportEmail, reportID int) (domain.SentEmail, error) {
+	sent := domain.SentEmail{ReportID: reportID, Subject: email.Subject, Recipients: email.To}
+	if len(email.To) == 0 {
+		return sent, fmt.Errorf("the email has no recipients")
+	}
+
+	from, err := mail.ParseAddress(email.From)
+	if err != nil {
+		return sent, fmt.Errorf("invalid sender %q: %w", email.From, err)
+	}
+	recipients := make([]string, len(email.To))
+	for i, to := range email.To {
+		address, err := mail.ParseAddress(to)
+		if err != nil {
+			return sent, fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		recipients[i] = address.Address
+	}
+
+	message, err := email.Message()
+	if err != nil {
+		return sent, err
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		host, _, err := net.SplitHostPort(config.Server)
+		if err != nil {
+			return sent, fmt.Errorf("invalid mail server %q: %w", config.Server, err)
+		}
+		auth = smtp.PlainAuth("", config.Username, config.Password, host)
+	}
+	if err := smtp.SendMail(config.Server, auth, from.Address, recipients, message); err != nil {
+		return sent, fmt.Errorf("failed to send email: %w", err)
+	}
+
+	for _, path := range email.Attachments {
+		sent.Attachments = append(sent.Attachments, filepath.Base(path))
+	}
+	sent.SentAt = time.Now()
+	id, err := repository.CreateSentEmail(sent)
+	if err != nil {
+		return sent, fmt.Errorf("email sent, but recording it failed: %w", err)
+	}
+	sent.ID = id
+
+	return sent, nil
+}
@@ -1,7 +1,10 @@
 package report_generator
 
 import (
+	"fmt"
+	"time"
 	"tltui/src/domain"
+	"tltui/src/domain/repository"
 )
 
 // WorkhourStats contains aggregated statistics for workhours
@@ -12,14 +15,18 @@ type WorkhourStats struct {
 	ProjectHours         map[string]float64            // project name -> hours
 	ActivityHours        map[string]float64            // activity name -> hours
 	ProjectActivityHours map[string]map[string]float64 // project name -> activity name -> hours
+	ProjectTaskHours     map[string]map[string]float64 // project name -> task name -> hours
 	DailyBreakdown       map[string][]WorkhourEntry    // date -> list of entries
+	Overtime             *domain.OvertimeMonth         // overtime ledger row of the month, nil when unknown
 }
 
 // WorkhourEntry represents a single workhour entry
 type WorkhourEntry struct {
 	ProjectName  string
+	TaskName     string
 	ActivityName string
 	Hours        float64
+	TimeRange    string // "09:00-12:15" when the workhour has times
 }
 
 // CalculateWorkhourStats calculates comprehensive statistics from workhours
@@ -27,11 +34,13 @@ func CalculateWorkhourStats(
 	workhours []domain.Workhour,
 	detailsMap map[int]domain.WorkhourDetails,
 	projectsMap map[int]domain.Project,
+	tasksMap map[int]domain.Task,
 ) WorkhourStats {
 	stats := WorkhourStats{
 		ProjectHours:         make(map[string]float64),
 		ActivityHours:        make(map[string]float64),
 		ProjectActivityHours: make(map[string]map[string]float64),
+		ProjectTaskHours:     make(map[string]map[string]float64),
 		DailyBreakdown:       make(map[string][]WorkhourEntry),
 	}
 
@@ -43,7 +52,7 @@ func CalculateWorkhourStats(
 		dateStr := wh.Date.Format("02-Jan-2006")
 		daysWorked[dateStr] = true
 
-		var projectName, activityName string
+		var projectName, taskName, activityName string
 
 		if project, ok := projectsMap[wh.ProjectID]; ok {
 			projectName = project.Name
@@ -55,6 +64,14 @@ func CalculateWorkhourStats(
 			stats.ActivityHours[activityName] += wh.Hours
 		}
 
+		if task, ok := tasksMap[wh.TaskID]; ok && projectName != "" {
+			taskName = task.Name
+			if stats.ProjectTaskHours[projectName] == nil {
+				stats.ProjectTaskHours[projectName] = make(map[string]float64)
+			}
+			stats.ProjectTaskHours[projectName][taskName] += wh.Hours
+		}
+
 		if projectName != "" && activityName != "" {
 			if stats.ProjectActivityHours[projectName] == nil {
 				stats.ProjectActivityHours[projectName] = make(map[string]float64)
@@ -65,7 +82,9 @@ func CalculateWorkhourStats(
 		entry := WorkhourEntry{
 			Hours:        wh.Hours,
 			ProjectName:  projectName,
+			TaskName:     taskName,
 			ActivityName: activityName,
+			TimeRange:    wh.TimeRange(),
 		}
 		stats.DailyBreakdown[dateStr] = append(stats.DailyBreakdown[dateStr], entry)
 	}
@@ -77,3 +96,88 @@ func CalculateWorkhourStats(
 
 	return stats
 }
+
+// FilterWorkhoursByClient keeps the workhours booked on projects of the given client
+func FilterWorkhoursByClient(workhours []domain.Workhour, projectsMap map[int]domain.Project, clientID int) []domain.Workhour {
+	filtered := make([]domain.Workhour, 0, len(workhours))
+	for _, wh := range workhours {
+		if project, ok := projectsMap[wh.ProjectID]; ok && project.ClientID == clientID {
+			filtered = append(filtered, wh)
+		}
+	}
+	return filtered
+}
+
+// LoadMonthOvertime returns the overtime ledger row of a month, counted up to
+// the end of the month or today, whichever comes first
+func LoadMonthOvertime(viewMonth, viewYear int) *domain.OvertimeMonth {
+	asOf := time.Date(viewYear, time.Month(viewMonth+1), 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+	if now := time.Now(); now.Before(asOf) {
+		asOf = now
+	}
+
+	ledger, err := repository.GetOvertimeLedger(viewYear, asOf)
+	if err != nil {
+		return nil
+	}
+	return ledger.MonthEntry(time.Month(viewMonth))
+}
+
+// LoadStats calculates the statistics of the workhours between start and end,
+// limited to the projects of a client when clientID is not 0
+func LoadStats(start, end time.Time, clientID int) (WorkhourStats, error) {
+	workhours, err := repository.GetWorkhoursByDateRange(start, end)
+	if err != nil {
+		return WorkhourStats{}, fmt.Errorf("failed to get workhours: %w", err)
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return WorkhourStats{}, fmt.Errorf("failed to get workhour details: %w", err)
+	}
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return WorkhourStats{}, fmt.Errorf("failed to get projects: %w", err)
+	}
+	tasks, err := repository.GetAllTasks()
+	if err != nil {
+		return WorkhourStats{}, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	detailsMap := make(map[int]domain.WorkhourDetails)
+	for _, wd := range workhourDetails {
+		detailsMap[wd.ID] = wd
+	}
+	projectsMap := make(map[int]domain.Project)
+	for _, p := range projects {
+		projectsMap[p.ID] = p
+	}
+	tasksMap := make(map[int]domain.Task)
+	for _, t := range tasks {
+		tasksMap[t.ID] = t
+	}
+
+	if clientID != 0 {
+		workhours = FilterWorkhoursByClient(workhours, projectsMap, clientID)
+	}
+
+	return CalculateWorkhourStats(workhours, detailsMap, projectsMap, tasksMap), nil
+}
+
+// DefaultSelectedItems selects the activities of every project in the stats that
+// count as work, the mail report's default selection
+func DefaultSelectedItems(stats WorkhourStats, workhourDetails []domain.WorkhourDetails) map[string]map[string]bool {
+	detailsMap := make(map[string]domain.WorkhourDetails)
+	for _, wd := range workhourDetails {
+		detailsMap[wd.Name] = wd
+	}
+
+	selected := make(map[string]map[string]bool)
+	for projectName, activities := range stats.ProjectActivityHours {
+		selected[projectName] = make(map[string]bool)
+		for activityName := range activities {
+			detail, exists := detailsMap[activityName]
+			selected[projectName][activityName] = exists && detail.IsWork
+		}
+	}
+	return selected
+}
@@ -0,0 +1,298 @@
+package report_generator
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"tltui/src/domain"
+)
+
+// SummaryFormat is the markup of a month summary
+type SummaryFormat string
+
+const (
+	SummaryFormatMarkdown SummaryFormat = "md"
+	SummaryFormatHTML     SummaryFormat = "html"
+)
+
+// GenerateSummaryReport writes the summary of a month to a temp file and offers to
+// save it elsewhere, returning the saved path and the summary itself
+func GenerateSummaryReport(format SummaryFormat, viewMonth, viewYear int) (string, string, error) {
+	content, err := BuildSummary(format, viewMonth, viewYear, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	filePath := filepath.Join(os.TempDir(), SummaryFileName(format, viewMonth, viewYear, nil))
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		return "", "", fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	savedPath, err := OpenSummarySaveDialog(filePath)
+	return savedPath, content, err
+}
+
+// SummaryFileName names the summary of a month, e.g. "summary_march_2025.md"
+func SummaryFileName(format SummaryFormat, viewMonth, viewYear int, client *domain.Client) string {
+	monthName := strings.ToLower(time.Month(viewMonth).String())
+	if client != nil {
+		return fmt.Sprintf("summary_%s_%s_%d.%s", fileNameSlug(client.Name), monthName, viewYear, format)
+	}
+	return fmt.Sprintf("summary_%s_%d.%s", monthName, viewYear, format)
+}
+
+// BuildSummary renders the summary of a month, limited to the projects of client
+// when it is not nil
+func BuildSummary(format SummaryFormat, viewMonth, viewYear int, client *domain.Client) (string, error) {
+	startDate := time.Date(viewYear, time.Month(viewMonth), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	clientID := 0
+	title := fmt.Sprintf("Timesheet summary: %s %d", time.Month(viewMonth), viewYear)
+	if client != nil {
+		clientID = client.ID
+		title = fmt.Sprintf("Timesheet summary for %s: %s %d", client.DisplayLegalName(), time.Month(viewMonth), viewYear)
+	}
+
+	stats, err := LoadStats(startDate, endDate, clientID)
+	if err != nil {
+		return "", err
+	}
+	// The overtime balance covers all projects, so it is left out of client summaries
+	if client == nil {
+		stats.Overtime = LoadMonthOvertime(viewMonth, viewYear)
+	}
+
+	switch format {
+	case SummaryFormatMarkdown:
+		return RenderMarkdownSummary(title, stats), nil
+	case SummaryFormatHTML:
+		return RenderHTMLSummary(title, stats)
+	}
+	return "", fmt.Errorf("unknown summary format %q", format)
+}
+
+// summaryRow is a line of a summary table
+type summaryRow struct {
+	Label string
+	Hours float64
+}
+
+// summaryTable lists hours by name, sorted by name
+type summaryTable struct {
+	Title  string
+	Header string // Heading of the name column
+	Rows   []summaryRow
+	Total  float64
+}
+
+// summaryDay is a day of the daily breakdown
+type summaryDay struct {
+	Date    string
+	Total   float64
+	Entries []WorkhourEntry
+}
+
+// summaryData is the content of a summary, shared by the Markdown and HTML renderings
+type summaryData struct {
+	Title      string
+	Stats      WorkhourStats
+	Projects   summaryTable
+	PerProject []summaryTable
+	Activities summaryTable
+	Days       []summaryDay
+}
+
+func newSummaryData(title string, stats WorkhourStats) summaryData {
+	data := summaryData{
+		Title:      title,
+		Stats:      stats,
+		Projects:   newSummaryTable("Projects", "Project", stats.ProjectHours),
+		Activities: newSummaryTable("Activities", "Activity", stats.ActivityHours),
+	}
+	for _, project := range data.Projects.Rows {
+		if activities := stats.ProjectActivityHours[project.Label]; len(activities) > 0 {
+			data.PerProject = append(data.PerProject, newSummaryTable(project.Label, "Activity", activities))
+		}
+	}
+
+	type datedEntries struct {
+		date    time.Time
+		entries []WorkhourEntry
+	}
+	var days []datedEntries
+	for key, entries := range stats.DailyBreakdown {
+		date, err := time.Parse("02-Jan-2006", key)
+		if err != nil {
+			continue
+		}
+		days = append(days, datedEntries{date, entries})
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].date.Before(days[j].date) })
+
+	for _, day := range days {
+		summary := summaryDay{Date: day.date.Format("Mon 02 Jan"), Entries: day.entries}
+		for _, entry := range day.entries {
+			summary.Total += entry.Hours
+		}
+		data.Days = append(data.Days, summary)
+	}
+	return data
+}
+
+func newSummaryTable(title, header string, hours map[string]float64) summaryTable {
+	table := summaryTable{Title: title, Header: header}
+	for _, key := range sortedKeys(hours) {
+		table.Rows = append(table.Rows, summaryRow{Label: key, Hours: hours[key]})
+		table.Total += hours[key]
+	}
+	return table
+}
+
+// formatSummaryHours formats hours without trailing zeros, e.g. 7.5 or 8
+func formatSummaryHours(hours float64) string {
+	return fmt.Sprintf("%g", roundHours(hours))
+}
+
+func formatSummaryBalance(hours float64) string {
+	return fmt.Sprintf("%+g", roundHours(hours))
+}
+
+// RenderMarkdownSummary renders the stats as Markdown tables: hours per project,
+// per activity of each project, per activity overall and the daily breakdown
+func RenderMarkdownSummary(title string, stats WorkhourStats) string {
+	data := newSummaryData(title, stats)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", escapeMarkdown(data.Title))
+	fmt.Fprintf(&sb, "**Total:** %sh over %d days (%sh per day)\n",
+		formatSummaryHours(stats.TotalHours), stats.TotalDays, formatSummaryHours(stats.AveragePerDay))
+	if stats.Overtime != nil {
+		fmt.Fprintf(&sb, "\n**Overtime:** %sh (running %sh)\n",
+			formatSummaryBalance(stats.Overtime.Balance), formatSummaryBalance(stats.Overtime.RunningBalance))
+	}
+
+	if len(data.Projects.Rows) > 0 {
+		sb.WriteString("\n## Projects\n\n")
+		writeMarkdownTable(&sb, data.Projects)
+		for _, table := range data.PerProject {
+			fmt.Fprintf(&sb, "\n### %s\n\n", escapeMarkdown(table.Title))
+			writeMarkdownTable(&sb, table)
+		}
+	}
+
+	if len(data.Activities.Rows) > 0 {
+		sb.WriteString("\n## Activities\n\n")
+		writeMarkdownTable(&sb, data.Activities)
+	}
+
+	if len(data.Days) > 0 {
+		sb.WriteString("\n## Daily breakdown\n\n")
+		sb.WriteString("| Date | Project | Task | Activity | Time | Hours |\n")
+		sb.WriteString("|---|---|---|---|---|---:|\n")
+		for _, day := range data.Days {
+			for _, entry := range day.Entries {
+				fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n",
+					day.Date, escapeMarkdown(entry.ProjectName), escapeMarkdown(entry.TaskName),
+					escapeMarkdown(entry.ActivityName), entry.TimeRange, formatSummaryHours(entry.Hours))
+			}
+			fmt.Fprintf(&sb, "| **%s** | | | | | **%s** |\n", day.Date, formatSummaryHours(day.Total))
+		}
+	}
+
+	return sb.String()
+}
+
+func writeMarkdownTable(sb *strings.Builder, table summaryTable) {
+	fmt.Fprintf(sb, "| %s | Hours |\n", table.Header)
+	sb.WriteString("|---|---:|\n")
+	for _, row := range table.Rows {
+		fmt.Fprintf(sb, "| %s | %s |\n", escapeMarkdown(row.Label), formatSummaryHours(row.Hours))
+	}
+	fmt.Fprintf(sb, "| **Total** | **%s** |\n", formatSummaryHours(table.Total))
+}
+
+// escapeMarkdown keeps names from breaking table cells or being read as formatting
+func escapeMarkdown(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`",
+		"[", `\[`, "]", `\]`, "<", `\<`, "#", `\#`,
+		"\r\n", " ", "\n", " ",
+	).Replace(value)
+}
+
+// RenderHTMLSummary renders the same tables as RenderMarkdownSummary as a
+// self-contained HTML page. Styles are inline so they survive pasting into mail clients.
+func RenderHTMLSummary(title string, stats WorkhourStats) (string, error) {
+	var sb strings.Builder
+	if err := summaryHTMLTemplate.Execute(&sb, newSummaryData(title, stats)); err != nil {
+		return "", fmt.Errorf("failed to render summary: %w", err)
+	}
+	return sb.String(), nil
+}
+
+var summaryHTMLTemplate = template.Must(template.New("summary").Funcs(template.FuncMap{
+	"hours":   formatSummaryHours,
+	"balance": formatSummaryBalance,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8"/>
+<title>{{.Title}}</title>
+</head>
+<body style="font-family: Helvetica, Arial, sans-serif; font-size: 14px; color: #222222;">
+<h1 style="font-size: 20px;">{{.Title}}</h1>
+<p><strong>Total:</strong> {{hours .Stats.TotalHours}}h over {{.Stats.TotalDays}} days ({{hours .Stats.AveragePerDay}}h per day)</p>
+{{- with .Stats.Overtime}}
+<p><strong>Overtime:</strong> {{balance .Balance}}h (running {{balance .RunningBalance}}h)</p>
+{{- end}}
+{{- if .Projects.Rows}}
+<h2 style="font-size: 16px;">Projects</h2>
+{{template "table" .Projects}}
+{{- range .PerProject}}
+<h3 style="font-size: 14px;">{{.Title}}</h3>
+{{template "table" .}}
+{{- end}}
+{{- end}}
+{{- if .Activities.Rows}}
+<h2 style="font-size: 16px;">Activities</h2>
+{{template "table" .Activities}}
+{{- end}}
+{{- if .Days}}
+<h2 style="font-size: 16px;">Daily breakdown</h2>
+<table style="border-collapse: collapse;">
+<tr><th style="` + summaryHeaderStyle + `">Date</th><th style="` + summaryHeaderStyle + `">Project</th><th style="` + summaryHeaderStyle + `">Task</th><th style="` + summaryHeaderStyle + `">Activity</th><th style="` + summaryHeaderStyle + `">Time</th><th style="` + summaryHeaderStyle + `">Hours</th></tr>
+{{- range .Days}}
+{{- $date := .Date}}
+{{- range .Entries}}
+<tr><td style="` + summaryCellStyle + `">{{$date}}</td><td style="` + summaryCellStyle + `">{{.ProjectName}}</td><td style="` + summaryCellStyle + `">{{.TaskName}}</td><td style="` + summaryCellStyle + `">{{.ActivityName}}</td><td style="` + summaryCellStyle + `">{{.TimeRange}}</td><td style="` + summaryNumberStyle + `">{{hours .Hours}}</td></tr>
+{{- end}}
+<tr><td colspan="5" style="` + summaryTotalStyle + `">{{.Date}}</td><td style="` + summaryTotalStyle + ` text-align: right;">{{hours .Total}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+{{define "table"}}<table style="border-collapse: collapse;">
+<tr><th style="` + summaryHeaderStyle + `">{{.Header}}</th><th style="` + summaryHeaderStyle + `">Hours</th></tr>
+{{- range .Rows}}
+<tr><td style="` + summaryCellStyle + `">{{.Label}}</td><td style="` + summaryNumberStyle + `">{{hours .Hours}}</td></tr>
+{{- end}}
+<tr><td style="` + summaryTotalStyle + `">Total</td><td style="` + summaryTotalStyle + ` text-align: right;">{{hours .Total}}</td></tr>
+</table>{{end}}`))
+
+const (
+	summaryHeaderStyle = "border: 1px solid #bbbbbb; padding: 4px 8px; background: #d9d9d9; text-align: left;"
+	summaryCellStyle   = "border: 1px solid #bbbbbb; padding: 4px 8px;"
+	summaryNumberStyle = "border: 1px solid #bbbbbb; padding: 4px 8px; text-align: right;"
+	summaryTotalStyle  = "border: 1px solid #bbbbbb; padding: 4px 8px; font-weight: bold;"
+)
+
+// OpenSummarySaveDialog opens a save dialog for Markdown or HTML summaries
+func OpenSummarySaveDialog(sourceFile string) (string, error) {
+	return openSaveDialog(sourceFile, "Save Summary", "*"+filepath.Ext(sourceFile))
+}
@@ -0,0 +1,91 @@
+package report_generator
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/domain/repository"
+)
+
+func TestBuildSummary(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	meetings := repository.CreateTestWorkhourDetails(t, 2, "Meetings", "MTG", true)
+	arnia := repository.CreateTestProject(t, 1, "Arnia", 100)
+	website := repository.CreateTestProject(t, 2, "<b>Web|site</b> & Co", 101)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), dev.ID, website.ID, 1.5)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local), dev.ID, arnia.ID, 6)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local), meetings.ID, arnia.ID, 2)
+
+	markdown, err := BuildSummary(SummaryFormatMarkdown, 3, 2025, nil)
+	if err != nil {
+		t.Fatalf("failed to build the Markdown summary: %v", err)
+	}
+	for _, want := range []string{
+		"# Timesheet summary: March 2025\n",
+		"**Total:** 9.5h over 2 days (4.75h per day)\n",
+		"| \\<b>Web\\|site\\</b> & Co | 1.5 |\n| Arnia | 8 |\n| **Total** | **9.5** |\n",
+		"### Arnia\n\n| Activity | Hours |\n|---|---:|\n| Development | 6 |\n| Meetings | 2 |\n| **Total** | **8** |\n",
+		"## Activities\n\n| Activity | Hours |\n|---|---:|\n| Development | 7.5 |\n| Meetings | 2 |\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected %q in the Markdown summary:\n%s", want, markdown)
+		}
+	}
+
+	// Every table row has as many cells as its header, so escaped pipes stay inside cells
+	var columns int
+	for _, line := range strings.Split(markdown, "\n") {
+		if !strings.HasPrefix(line, "|") {
+			columns = 0
+			continue
+		}
+		cells := strings.Count(strings.ReplaceAll(line, `\|`, ""), "|") - 1
+		if columns == 0 {
+			columns = cells
+		} else if cells != columns {
+			t.Errorf("row %q has %d cells, want %d", line, cells, columns)
+		}
+	}
+	if monday, tuesday := strings.Index(markdown, "| Mon 03 Mar |"), strings.Index(markdown, "| Tue 04 Mar |"); monday < 0 || tuesday < monday {
+		t.Errorf("expected the daily breakdown in date order:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "| **Mon 03 Mar** | | | | | **8** |") {
+		t.Errorf("expected a day total row:\n%s", markdown)
+	}
+
+	html, err := BuildSummary(SummaryFormatHTML, 3, 2025, nil)
+	if err != nil {
+		t.Fatalf("failed to build the HTML summary: %v", err)
+	}
+	if strings.Contains(html, "<b>") || strings.Contains(html, "<link") || strings.Contains(html, "<script") {
+		t.Errorf("expected a self-contained page with escaped names:\n%s", html)
+	}
+
+	// The page is well-formed and shows the names as text
+	decoder := xml.NewDecoder(strings.NewReader(html))
+	decoder.Entity = xml.HTMLEntity
+	var texts []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("summary is not well-formed: %v\n%s", err, html)
+		}
+		if text, ok := token.(xml.CharData); ok && strings.TrimSpace(string(text)) != "" {
+			texts = append(texts, strings.TrimSpace(string(text)))
+		}
+	}
+	joined := strings.Join(texts, "\n")
+	for _, want := range []string{"<b>Web|site</b> & Co\n1.5", "Arnia\nActivity\nHours\nDevelopment\n6\nMeetings\n2\nTotal\n8", "Tue 04 Mar\n1.5"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected %q in the HTML summary texts:\n%s", want, joined)
+		}
+	}
+}
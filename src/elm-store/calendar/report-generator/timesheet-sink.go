@@ -0,0 +1,270 @@
+package report_generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// TimesheetSink is a system the logged hours are sent to, such as an Odoo import
+// file or the worklogs of an issue tracker
+type TimesheetSink interface {
+	// Name identifies the sink in the export records, e.g. "jira"
+	Name() string
+	// Accepts reports whether an entry can be sent to the sink, e.g. has an issue key of its tracker
+	Accepts(entry TimesheetEntry) bool
+	// Push sends the entries in order. Entries pushed before carry their previous export,
+	// always on the same issue and day, whose remote entry is updated rather than
+	// duplicated. It returns the remote ID of every entry sent, also when failing part
+	// way, so that those are recorded and not sent twice. Sinks writing files return
+	// empty IDs and receive all entries each time.
+	Push(entries []TimesheetEntry) ([]string, error)
+	// Retract removes the remote entry of an export, for workhours deleted, moved to
+	// another issue or day, or no longer accepted since their push
+	Retract(export domain.WorkhourExport) error
+}
+
+// TimesheetEntry is a workhour with what sinks need to describe it
+type TimesheetEntry struct {
+	Workhour domain.Workhour
+	Details  domain.WorkhourDetails
+	Project  domain.Project
+	Task     domain.Task            // Zero when the workhour has no task
+	Export   *domain.WorkhourExport // Last push to the sink, nil when never pushed
+}
+
+// Description names the work of an entry, e.g. "Development: Login page"
+func (e TimesheetEntry) Description() string {
+	if e.Task.ID != 0 {
+		return fmt.Sprintf("%s: %s", e.Details.Name, e.Task.Name)
+	}
+	return e.Details.Name
+}
+
+// TimesheetPush summarizes a push to a sink
+type TimesheetPush struct {
+	Pending   []TimesheetEntry        // Entries new or changed since their last push
+	Retract   []domain.WorkhourExport // Pushes whose remote entry is to be removed
+	Sent      int
+	Retracted int
+	Unchanged int // Entries pushed before and not changed since
+	Skipped   int // Entries the sink does not accept
+}
+
+// LoadTimesheetEntries returns the workhours between start and end as timesheet
+// entries, with their last push to sink. Entries without a known type or project are left out.
+func LoadTimesheetEntries(start, end time.Time, sink string) ([]TimesheetEntry, error) {
+	workhours, err := repository.GetWorkhoursByDateRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workhours: %w", err)
+	}
+
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workhour details: %w", err)
+	}
+
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	tasks, err := repository.GetAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	exports, err := repository.GetWorkhourExports(sink)
+	if err != nil {
+		return nil, err
+	}
+
+	detailsMap := make(map[int]domain.WorkhourDetails)
+	for _, wd := range workhourDetails {
+		detailsMap[wd.ID] = wd
+	}
+
+	projectsMap := make(map[int]domain.Project)
+	for _, p := range projects {
+		projectsMap[p.ID] = p
+	}
+
+	tasksMap := make(map[int]domain.Task)
+	for _, t := range tasks {
+		tasksMap[t.ID] = t
+	}
+
+	var entries []TimesheetEntry
+	for _, wh := range workhours {
+		details, ok := detailsMap[wh.DetailsID]
+		if !ok {
+			continue
+		}
+
+		project, ok := projectsMap[wh.ProjectID]
+		if !ok {
+			continue
+		}
+
+		entry := TimesheetEntry{Workhour: wh, Details: details, Project: project, Task: tasksMap[wh.TaskID]}
+		if export, ok := exports[wh.ID]; ok {
+			entry.Export = &export
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// PlanTimesheetPush lists the entries between start and end that a push to the sink
+// would send, and the earlier pushes it would retract. Entries moved to another issue
+// or day are retracted there and sent as new.
+func PlanTimesheetPush(sink TimesheetSink, start, end time.Time) (TimesheetPush, error) {
+	var push TimesheetPush
+	entries, err := LoadTimesheetEntries(start, end, sink.Name())
+	if err != nil {
+		return push, err
+	}
+
+	deleted, err := repository.GetDeletedWorkhourExports(sink.Name(), start, end)
+	if err != nil {
+		return push, err
+	}
+	push.Retract = deleted
+
+	for _, entry := range entries {
+		export := entry.Export
+		switch {
+		case !sink.Accepts(entry):
+			if export != nil {
+				push.Retract = append(push.Retract, *export)
+			} else {
+				push.Skipped++
+			}
+		case export != nil && export.Matches(entry.Workhour):
+			push.Unchanged++
+		default:
+			if export != nil && (export.IssueKey != entry.Workhour.IssueKey || !export.Date.Equal(entry.Workhour.Date)) {
+				push.Retract = append(push.Retract, *export)
+				entry.Export = nil
+			}
+			push.Pending = append(push.Pending, entry)
+		}
+	}
+	return push, nil
+}
+
+// PushTimesheet sends the entries between start and end that are new or changed since
+// their last push, and records the remote IDs of the ones sent. Retractions go first and
+// are forgotten one by one as they succeed, so a push failing part way never retracts
+// an entry twice. Pushing again without changes sends nothing.
+func PushTimesheet(sink TimesheetSink, start, end time.Time) (TimesheetPush, error) {
+	push, err := PlanTimesheetPush(sink, start, end)
+	if err != nil {
+		return push, err
+	}
+
+	for _, export := range push.Retract {
+		if err := sink.Retract(export); err != nil {
+			return push, fmt.Errorf("failed to retract the entry of %s on %s: %w", export.Date.Format("2006-01-02"), export.IssueKey, err)
+		}
+		if err := repository.DeleteWorkhourExport(export.WorkhourID, export.Sink); err != nil {
+			return push, err
+		}
+		push.Retracted++
+	}
+	if len(push.Pending) == 0 {
+		return push, nil
+	}
+
+	remoteIDs, pushErr := sink.Push(push.Pending)
+	for i, remoteID := range remoteIDs {
+		push.Sent++
+		if remoteID == "" {
+			continue
+		}
+		wh := push.Pending[i].Workhour
+		err := repository.SaveWorkhourExport(domain.WorkhourExport{
+			WorkhourID: wh.ID,
+			Sink:       sink.Name(),
+			RemoteID:   remoteID,
+			IssueKey:   wh.IssueKey,
+			Date:       wh.Date,
+			Hours:      wh.Hours,
+		})
+		if err != nil {
+			return push, err
+		}
+	}
+	return push, pushErr
+}
+
+// httpStatusError is a response of an issue tracker with an unexpected status
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+func isNotFound(err error) bool {
+	var statusErr *httpStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// sendJSON sends body as JSON and decodes the response into out, when both are set
+func sendJSON(client *http.Client, method, url string, header http.Header, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(message))}
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
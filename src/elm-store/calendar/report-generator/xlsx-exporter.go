@@ -0,0 +1,366 @@
+package report_generator
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+)
+
+// GenerateXLSXReport generates an Excel timesheet of the month and offers to save it
+func GenerateXLSXReport(viewMonth, viewYear int) (string, error) {
+	filePath := filepath.Join(os.TempDir(), XLSXReportFileName(viewMonth, viewYear))
+
+	if err := BuildXLSXReport(filePath, viewMonth, viewYear); err != nil {
+		return "", err
+	}
+
+	return OpenXLSXSaveDialog(filePath)
+}
+
+// XLSXReportFileName names the Excel timesheet of a month, e.g. "timesheet_March_2025.xlsx"
+func XLSXReportFileName(viewMonth, viewYear int) string {
+	return fmt.Sprintf("timesheet_%s_%d.xlsx", time.Month(viewMonth), viewYear)
+}
+
+// BuildXLSXReport writes the Excel timesheet of a month to filePath: a matrix of the
+// hours per day and project/type with total formulas, the raw entries, and a summary
+// of the statistics. Weekends and days with only non-work entries (holidays, leave)
+// are highlighted.
+func BuildXLSXReport(filePath string, viewMonth, viewYear int) error {
+	startDate := time.Date(viewYear, time.Month(viewMonth), 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	workhours, err := repository.GetWorkhoursByDateRange(startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workhours: %w", err)
+	}
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return fmt.Errorf("failed to fetch workhour details: %w", err)
+	}
+	projects, err := repository.GetAllProjectsFromDB()
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	tasks, err := repository.GetAllTasks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+
+	detailsMap := make(map[int]domain.WorkhourDetails)
+	for _, wd := range workhourDetails {
+		detailsMap[wd.ID] = wd
+	}
+	projectsMap := make(map[int]domain.Project)
+	for _, p := range projects {
+		projectsMap[p.ID] = p
+	}
+	tasksMap := make(map[int]domain.Task)
+	for _, t := range tasks {
+		tasksMap[t.ID] = t
+	}
+
+	sort.SliceStable(workhours, func(i, j int) bool {
+		if !workhours[i].Date.Equal(workhours[j].Date) {
+			return workhours[i].Date.Before(workhours[j].Date)
+		}
+		return workhours[i].ID < workhours[j].ID
+	})
+
+	stats := CalculateWorkhourStats(workhours, detailsMap, projectsMap, tasksMap)
+	stats.Overtime = LoadMonthOvertime(viewMonth, viewYear)
+
+	x := xlsxReport{
+		start:       startDate,
+		end:         endDate,
+		workhours:   workhours,
+		detailsMap:  detailsMap,
+		projectsMap: projectsMap,
+		tasksMap:    tasksMap,
+	}
+	return writeXLSX(filePath, []*xlsxSheet{
+		x.matrixSheet(),
+		x.entriesSheet(),
+		x.summarySheet(stats),
+	})
+}
+
+type xlsxReport struct {
+	start, end  time.Time
+	workhours   []domain.Workhour
+	detailsMap  map[int]domain.WorkhourDetails
+	projectsMap map[int]domain.Project
+	tasksMap    map[int]domain.Task
+}
+
+// matrixColumn is a project and workhour type pair of the matrix sheet
+type matrixColumn struct {
+	ProjectID int
+	DetailsID int
+}
+
+func (x xlsxReport) matrixSheet() *xlsxSheet {
+	hours := make(map[string]map[matrixColumn]float64)
+	seen := make(map[matrixColumn]bool)
+	var columns []matrixColumn
+	for _, wh := range x.workhours {
+		column := matrixColumn{ProjectID: wh.ProjectID, DetailsID: wh.DetailsID}
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+		day := wh.Date.Format("2006-01-02")
+		if hours[day] == nil {
+			hours[day] = make(map[matrixColumn]float64)
+		}
+		hours[day][column] += wh.Hours
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		pi, pj := x.projectName(columns[i].ProjectID), x.projectName(columns[j].ProjectID)
+		if pi != pj {
+			return pi < pj
+		}
+		return x.typeLabel(columns[i].DetailsID) < x.typeLabel(columns[j].DetailsID)
+	})
+
+	sheet := &xlsxSheet{Name: "Matrix", FreezeHeader: true, ColWidths: []float64{12, 6}}
+	header := []xlsxCell{{Value: "Date", Style: xlsxStyleHeader}, {Value: "Day", Style: xlsxStyleHeader}}
+	for _, column := range columns {
+		header = append(header, xlsxCell{Value: x.projectName(column.ProjectID) + " / " + x.typeLabel(column.DetailsID), Style: xlsxStyleHeader})
+		sheet.ColWidths = append(sheet.ColWidths, 16)
+	}
+	header = append(header, xlsxCell{Value: "Total", Style: xlsxStyleHeader})
+	sheet.ColWidths = append(sheet.ColWidths, 10)
+	sheet.AddRow(header...)
+
+	firstHours, lastHours := xlsxColumnName(2), xlsxColumnName(len(columns)+1)
+	totalColumn := len(columns) + 2
+	for day := x.start; !day.After(x.end); day = day.AddDate(0, 0, 1) {
+		style := x.dayStyle(day)
+		key := day.Format("2006-01-02")
+		rowNumber := len(sheet.Rows) + 1
+
+		row := []xlsxCell{{Value: key, Style: style}, {Value: day.Format("Mon"), Style: style}}
+		total := 0.0
+		for _, column := range columns {
+			cell := xlsxCell{Style: style}
+			if h := hours[key][column]; h != 0 {
+				cell.Value = roundHours(h)
+				total += h
+			}
+			row = append(row, cell)
+		}
+		row = append(row, xlsxCell{Value: roundHours(total), Formula: rowSum(firstHours, lastHours, rowNumber, len(columns)), Style: style})
+		sheet.AddRow(row...)
+	}
+
+	lastDayRow := len(sheet.Rows)
+	totals := []xlsxCell{{Value: "Total", Style: xlsxStyleTotal}, {Style: xlsxStyleTotal}}
+	grandTotal := 0.0
+	for i, column := range columns {
+		sum := 0.0
+		for _, byColumn := range hours {
+			sum += byColumn[column]
+		}
+		grandTotal += sum
+		name := xlsxColumnName(i + 2)
+		totals = append(totals, xlsxCell{Value: roundHours(sum), Formula: fmt.Sprintf("SUM(%s2:%s%d)", name, name, lastDayRow), Style: xlsxStyleTotal})
+	}
+	name := xlsxColumnName(totalColumn)
+	totals = append(totals, xlsxCell{Value: roundHours(grandTotal), Formula: fmt.Sprintf("SUM(%s2:%s%d)", name, name, lastDayRow), Style: xlsxStyleTotal})
+	sheet.AddRow(totals...)
+
+	return sheet
+}
+
+// rowSum is the formula adding the hour columns of a matrix row, "0" without any
+func rowSum(first, last string, row, columns int) string {
+	if columns == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("SUM(%s%d:%s%d)", first, row, last, row)
+}
+
+func (x xlsxReport) entriesSheet() *xlsxSheet {
+	sheet := &xlsxSheet{Name: "Entries", FreezeHeader: true, ColWidths: []float64{12, 6, 24, 20, 20, 8, 8, 8, 10, 8}}
+	var header []xlsxCell
+	for _, title := range []string{"Date", "Day", "Project", "Task", "Type", "Hours", "Start", "End", "Break (min)", "Work"} {
+		header = append(header, xlsxCell{Value: title, Style: xlsxStyleHeader})
+	}
+	sheet.AddRow(header...)
+
+	total := 0.0
+	for _, wh := range x.workhours {
+		style := x.dayStyle(wh.Date)
+		cell := func(value any) xlsxCell { return xlsxCell{Value: value, Style: style} }
+
+		task := ""
+		if t, ok := x.tasksMap[wh.TaskID]; ok && wh.TaskID != 0 {
+			task = t.Name
+		}
+		details := x.detailsMap[wh.DetailsID]
+		isWork := "No"
+		if details.IsWork {
+			isWork = "Yes"
+		}
+		row := []xlsxCell{
+			cell(wh.Date.Format("2006-01-02")),
+			cell(wh.Date.Format("Mon")),
+			cell(x.projectName(wh.ProjectID)),
+			cell(task),
+			cell(x.typeName(wh.DetailsID)),
+			cell(roundHours(wh.Hours)),
+		}
+		if wh.HasTimes() {
+			row = append(row, cell(wh.Start.String()), cell(wh.End.String()), cell(wh.BreakMinutes))
+		} else {
+			row = append(row, cell(nil), cell(nil), cell(nil))
+		}
+		row = append(row, cell(isWork))
+		sheet.AddRow(row...)
+		total += wh.Hours
+	}
+
+	totals := make([]xlsxCell, 10)
+	for i := range totals {
+		totals[i].Style = xlsxStyleTotal
+	}
+	totals[0].Value = "Total"
+	totals[5] = xlsxCell{Value: roundHours(total), Formula: fmt.Sprintf("SUM(F2:F%d)", max(len(sheet.Rows), 2)), Style: xlsxStyleTotal}
+	sheet.AddRow(totals...)
+
+	return sheet
+}
+
+func (x xlsxReport) summarySheet(stats WorkhourStats) *xlsxSheet {
+	sheet := &xlsxSheet{Name: "Summary", ColWidths: []float64{28, 24, 12}}
+	bold := func(value any) xlsxCell { return xlsxCell{Value: value, Style: xlsxStyleBold} }
+	header := func(titles ...string) {
+		var cells []xlsxCell
+		for _, title := range titles {
+			cells = append(cells, xlsxCell{Value: title, Style: xlsxStyleHeader})
+		}
+		sheet.AddRow(cells...)
+	}
+
+	sheet.AddRow(bold("Timesheet"), bold(fmt.Sprintf("%s %d", x.start.Month(), x.start.Year())))
+	sheet.AddRow()
+	sheet.AddRow(xlsxCell{Value: "Total hours"}, xlsxCell{Value: roundHours(stats.TotalHours)})
+	sheet.AddRow(xlsxCell{Value: "Days with entries"}, xlsxCell{Value: stats.TotalDays})
+	sheet.AddRow(xlsxCell{Value: "Average per day"}, xlsxCell{Value: roundHours(stats.AveragePerDay)})
+
+	sheet.AddRow()
+	header("Project", "Hours")
+	for _, name := range sortedKeys(stats.ProjectHours) {
+		sheet.AddRow(xlsxCell{Value: name}, xlsxCell{Value: roundHours(stats.ProjectHours[name])})
+	}
+
+	sheet.AddRow()
+	header("Activity", "Hours")
+	for _, name := range sortedKeys(stats.ActivityHours) {
+		sheet.AddRow(xlsxCell{Value: name}, xlsxCell{Value: roundHours(stats.ActivityHours[name])})
+	}
+
+	sheet.AddRow()
+	header("Project", "Activity", "Hours")
+	projectNames := make([]string, 0, len(stats.ProjectActivityHours))
+	for name := range stats.ProjectActivityHours {
+		projectNames = append(projectNames, name)
+	}
+	sort.Strings(projectNames)
+	for _, project := range projectNames {
+		activities := stats.ProjectActivityHours[project]
+		for _, activity := range sortedKeys(activities) {
+			sheet.AddRow(xlsxCell{Value: project}, xlsxCell{Value: activity}, xlsxCell{Value: roundHours(activities[activity])})
+		}
+	}
+
+	if o := stats.Overtime; o != nil {
+		sheet.AddRow()
+		header("Overtime", "Hours")
+		sheet.AddRow(xlsxCell{Value: "Expected"}, xlsxCell{Value: roundHours(o.ExpectedHours)})
+		sheet.AddRow(xlsxCell{Value: "Worked"}, xlsxCell{Value: roundHours(o.WorkedHours)})
+		sheet.AddRow(xlsxCell{Value: "Logged as overtime"}, xlsxCell{Value: roundHours(o.OvertimeHours)})
+		sheet.AddRow(xlsxCell{Value: "Time off in lieu"}, xlsxCell{Value: roundHours(o.TimeOffHours)})
+		sheet.AddRow(xlsxCell{Value: "Month balance"}, xlsxCell{Value: roundHours(o.Balance)})
+		sheet.AddRow(xlsxCell{Value: "Running balance"}, xlsxCell{Value: roundHours(o.RunningBalance)})
+	}
+
+	sheet.AddRow()
+	sheet.AddRow(xlsxCell{Value: "Weekend", Style: xlsxStyleWeekend})
+	sheet.AddRow(xlsxCell{Value: "Holiday or leave", Style: xlsxStyleHoliday})
+
+	return sheet
+}
+
+// dayStyle highlights weekends, and days whose entries are all of non-work types
+func (x xlsxReport) dayStyle(day time.Time) xlsxStyle {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return xlsxStyleWeekend
+	}
+
+	hasEntries := false
+	for _, wh := range x.workhours {
+		if !sameDay(wh.Date, day) {
+			continue
+		}
+		if x.detailsMap[wh.DetailsID].IsWork {
+			return xlsxStyleDefault
+		}
+		hasEntries = true
+	}
+	if hasEntries {
+		return xlsxStyleHoliday
+	}
+	return xlsxStyleDefault
+}
+
+func (x xlsxReport) projectName(id int) string {
+	if p, ok := x.projectsMap[id]; ok {
+		return p.Name
+	}
+	return fmt.Sprintf("Project %d", id)
+}
+
+func (x xlsxReport) typeName(id int) string {
+	if d, ok := x.detailsMap[id]; ok {
+		return d.Name
+	}
+	return fmt.Sprintf("Type %d", id)
+}
+
+func (x xlsxReport) typeLabel(id int) string {
+	if d, ok := x.detailsMap[id]; ok && d.ShortName != "" {
+		return d.ShortName
+	}
+	return x.typeName(id)
+}
+
+func sameDay(a, b time.Time) bool {
+	ya, ma, da := a.Date()
+	yb, mb, db := b.Date()
+	return ya == yb && ma == mb && da == db
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// OpenXLSXSaveDialog opens a save dialog for XLSX files
+func OpenXLSXSaveDialog(sourceFile string) (string, error) {
+	return openSaveDialog(sourceFile, "Save XLSX Timesheet", "*.xlsx")
+}
@@ -0,0 +1,79 @@
+package report_generator
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tltui/src/domain/repository"
+)
+
+func TestBuildXLSXReport(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	dev := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	holiday := repository.CreateTestWorkhourDetails(t, 2, "Public Holiday", "HOL", false)
+	arnia := repository.CreateTestProject(t, 1, "Arnia", 100)
+	website := repository.CreateTestProject(t, 2, "Website & Co", 101)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local), dev.ID, arnia.ID, 6)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local), dev.ID, website.ID, 2)
+	repository.CreateTestWorkhour(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), holiday.ID, arnia.ID, 8)
+
+	path := filepath.Join(t.TempDir(), XLSXReportFileName(3, 2025))
+	if err := BuildXLSXReport(path, 3, 2025); err != nil {
+		t.Fatalf("failed to build report: %v", err)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	defer archive.Close()
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, _ := f.Open()
+		data, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("missing part %s", name)
+		}
+		if err := xml.Unmarshal([]byte(parts[name]), new(any)); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", name, err)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Matrix"`) || !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Summary"`) {
+		t.Errorf("unexpected sheets:\n%s", parts["xl/workbook.xml"])
+	}
+
+	// Days are rows A2 (March 1st) to A32, the project/type pairs columns C and D
+	matrix := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="C1" s="1" t="inlineStr"><is><t xml:space="preserve">Arnia / DEV</t></is></c>`,
+		`<t xml:space="preserve">Website &amp; Co / DEV</t>`,
+		`<c r="F4"><f>SUM(C4:E4)</f><v>8</v></c>`,
+		`<c r="C33" s="4"><f>SUM(C2:C32)</f><v>6</v></c>`,
+		`<c r="F33" s="4"><f>SUM(F2:F32)</f><v>16</v></c>`,
+		`<c r="A2" s="2" t="inlineStr"><is><t xml:space="preserve">2025-03-01</t></is></c>`,
+		`<c r="A5" s="3" t="inlineStr"><is><t xml:space="preserve">2025-03-04</t></is></c>`,
+	} {
+		if !strings.Contains(matrix, want) {
+			t.Errorf("expected %s in the matrix sheet", want)
+		}
+	}
+
+	entries := parts["xl/worksheets/sheet2.xml"]
+	if !strings.Contains(entries, `<f>SUM(F2:F4)</f><v>16</v>`) || strings.Count(entries, "<row ") != 5 {
+		t.Errorf("expected 3 entries with a total row, got:\n%s", entries)
+	}
+	if summary := parts["xl/worksheets/sheet3.xml"]; !strings.Contains(summary, "March 2025") || !strings.Contains(summary, "Public Holiday") {
+		t.Errorf("unexpected summary sheet:\n%s", summary)
+	}
+}
@@ -0,0 +1,205 @@
+package report_generator
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// xlsxStyle selects one of the cell formats of xlsxStylesXML
+type xlsxStyle int
+
+const (
+	xlsxStyleDefault xlsxStyle = iota
+	xlsxStyleHeader            // Bold on grey
+	xlsxStyleWeekend           // Blue fill
+	xlsxStyleHoliday           // Orange fill
+	xlsxStyleTotal             // Bold with a top border
+	xlsxStyleBold
+)
+
+// xlsxCell is a string or number, or a formula with its value as computed here,
+// so viewers that do not recalculate still show it
+type xlsxCell struct {
+	Value   any // string, int or float64; nil leaves the cell empty
+	Formula string
+	Style   xlsxStyle
+}
+
+// xlsxSheet is a worksheet, its first row frozen as the header when FreezeHeader is set
+type xlsxSheet struct {
+	Name         string
+	Rows         [][]xlsxCell
+	ColWidths    []float64
+	FreezeHeader bool
+}
+
+// AddRow appends a row, returning its 1-based number for formulas
+func (s *xlsxSheet) AddRow(cells ...xlsxCell) int {
+	s.Rows = append(s.Rows, cells)
+	return len(s.Rows)
+}
+
+// writeXLSX writes the sheets as an XLSX workbook to filePath
+func writeXLSX(filePath string, sheets []*xlsxSheet) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if err := encodeXLSX(file, sheets); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return file.Close()
+}
+
+func encodeXLSX(w io.Writer, sheets []*xlsxSheet) error {
+	archive := zip.NewWriter(w)
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.Name), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	contentTypes.WriteString(`</Types>`)
+	// Formulas are recalculated on opening, the cached values are only a fallback
+	workbook.WriteString(`</sheets><calcPr calcId="0" fullCalcOnLoad="1"/></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`, len(sheets)+1)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStylesXML},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct{ name, content string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		writer, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(writer, part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func (s *xlsxSheet) xml() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if s.FreezeHeader {
+		sb.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	if len(s.ColWidths) > 0 {
+		sb.WriteString(`<cols>`)
+		for i, width := range s.ColWidths {
+			fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, width)
+		}
+		sb.WriteString(`</cols>`)
+	}
+
+	sb.WriteString(`<sheetData>`)
+	for r, row := range s.Rows {
+		fmt.Fprintf(&sb, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxCellRef(c, r+1)
+			style := ""
+			if cell.Style != xlsxStyleDefault {
+				style = fmt.Sprintf(` s="%d"`, cell.Style)
+			}
+
+			switch value := cell.Value.(type) {
+			case string:
+				if cell.Formula != "" {
+					fmt.Fprintf(&sb, `<c r="%s"%s t="str"><f>%s</f><v>%s</v></c>`, ref, style, xmlEscape(cell.Formula), xmlEscape(value))
+				} else {
+					fmt.Fprintf(&sb, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(value))
+				}
+			case int, float64:
+				number := fmt.Sprint(value)
+				if f, ok := value.(float64); ok {
+					number = strconv.FormatFloat(f, 'f', -1, 64)
+				}
+				if cell.Formula != "" {
+					fmt.Fprintf(&sb, `<c r="%s"%s><f>%s</f><v>%s</v></c>`, ref, style, xmlEscape(cell.Formula), number)
+				} else {
+					fmt.Fprintf(&sb, `<c r="%s"%s><v>%s</v></c>`, ref, style, number)
+				}
+			default:
+				if cell.Formula != "" {
+					fmt.Fprintf(&sb, `<c r="%s"%s><f>%s</f></c>`, ref, style, xmlEscape(cell.Formula))
+				} else if style != "" {
+					fmt.Fprintf(&sb, `<c r="%s"%s/>`, ref, style)
+				}
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+// xlsxCellRef names a cell, e.g. column 0 of row 1 is "A1" and column 27 "AB1"
+func xlsxCellRef(col, row int) string {
+	return xlsxColumnName(col) + strconv.Itoa(row)
+}
+
+func xlsxColumnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+func xmlEscape(value string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(value))
+	return sb.String()
+}
+
+// xlsxStylesXML defines the cell formats in the order of the xlsxStyle constants
+const xlsxStylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="5">` +
+	`<fill><patternFill patternType="none"/></fill>` +
+	`<fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/><bgColor indexed="64"/></patternFill></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFDDEBF7"/><bgColor indexed="64"/></patternFill></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFFCE4D6"/><bgColor indexed="64"/></patternFill></fill>` +
+	`</fills>` +
+	`<borders count="2"><border><left/><right/><top/><bottom/><diagonal/></border>` +
+	`<border><left/><right/><top style="thin"><color auto="1"/></top><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="6">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>` +
+	`<xf numFmtId="0" fontId="0" fillId="3" borderId="0" xfId="0" applyFill="1"/>` +
+	`<xf numFmtId="0" fontId="0" fillId="4" borderId="0" xfId="0" applyFill="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="1" xfId="0" applyFont="1" applyBorder="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
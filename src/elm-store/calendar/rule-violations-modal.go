@@ -0,0 +1,98 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RuleViolationsModal lists the rule violations of a month, to review before reporting
+type RuleViolationsModal struct {
+	Month        time.Month
+	Year         int
+	Violations   []domain.RuleViolation
+	ErrorMessage string
+}
+
+type RuleViolationsClosedMsg struct{}
+
+func NewRuleViolationsModal(month time.Month, year int) *RuleViolationsModal {
+	m := &RuleViolationsModal{
+		Month: month,
+		Year:  year,
+	}
+	m.loadViolations()
+	return m
+}
+
+func (m *RuleViolationsModal) loadViolations() {
+	m.ErrorMessage = ""
+	violations, err := repository.GetMonthViolations(m.Year, m.Month)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		m.Violations = nil
+		return
+	}
+	m.Violations = violations
+}
+
+func (m *RuleViolationsModal) Update(msg tea.Msg) (RuleViolationsModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "v":
+			return *m, dispatchRuleViolationsClosedMsg()
+
+		case "<", "left", "h":
+			m.shiftMonth(-1)
+		case ">", "right", "l":
+			m.shiftMonth(1)
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *RuleViolationsModal) shiftMonth(delta int) {
+	first := time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.Local).AddDate(0, delta, 0)
+	m.Month = first.Month()
+	m.Year = first.Year()
+	m.loadViolations()
+}
+
+func (m *RuleViolationsModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Rule Violations - %s %d", m.Month, m.Year)))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	if len(m.Violations) == 0 && m.ErrorMessage == "" {
+		okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+		sb.WriteString(okStyle.Render("✓ No rule violations this month"))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(renderRuleViolations(m.Violations))
+
+	sb.WriteString("\n")
+	sb.WriteString(render.RenderHelpText("</>: month", "ESC: close"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchRuleViolationsClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return RuleViolationsClosedMsg{}
+	}
+}
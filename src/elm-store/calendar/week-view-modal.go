@@ -0,0 +1,162 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type WeekViewModal struct {
+	WeekStart       time.Time // Monday of the displayed week
+	Workhours       []domain.Workhour
+	WorkhourDetails []domain.WorkhourDetails
+	Projects        []domain.Project
+	WeeklyTarget    float64
+	ErrorMessage    string
+}
+
+type WeekViewClosedMsg struct{}
+
+func NewWeekViewModal(date time.Time) *WeekViewModal {
+	workhourDetails, _ := repository.GetAllWorkhourDetailsFromDB()
+	projects, _ := repository.GetAllProjectsFromDB()
+
+	m := &WeekViewModal{
+		WeekStart:       domain.WeekStart(date),
+		WorkhourDetails: workhourDetails,
+		Projects:        projects,
+		WeeklyTarget:    repository.GetWeeklyTargetHours(),
+	}
+	m.loadWorkhours()
+	return m
+}
+
+func (m *WeekViewModal) loadWorkhours() {
+	m.ErrorMessage = ""
+	workhours, err := repository.GetWorkhoursByDateRange(m.WeekStart, m.WeekStart.AddDate(0, 0, 6))
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		m.Workhours = nil
+		return
+	}
+	m.Workhours = workhours
+}
+
+func (m *WeekViewModal) Update(msg tea.Msg) (WeekViewModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "w":
+			return *m, dispatchWeekViewClosedMsg()
+
+		case "<", "left", "h":
+			m.WeekStart = m.WeekStart.AddDate(0, 0, -7)
+			m.loadWorkhours()
+		case ">", "right", "l":
+			m.WeekStart = m.WeekStart.AddDate(0, 0, 7)
+			m.loadWorkhours()
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *WeekViewModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	_, week := m.WeekStart.ISOWeek()
+	weekEnd := m.WeekStart.AddDate(0, 0, 6)
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Week %d", week)))
+	sb.WriteString("\n")
+	sb.WriteString(dateStyle.Render(fmt.Sprintf("%s - %s", m.WeekStart.Format("Jan 2"), weekEnd.Format("Jan 2, 2006"))))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	var weekTotal float64
+	for i := range 7 {
+		day := m.WeekStart.AddDate(0, 0, i)
+		entries := m.workhoursOn(day)
+
+		var dayTotal float64
+		for _, wh := range entries {
+			dayTotal += wh.Hours
+		}
+		weekTotal += dayTotal
+
+		sb.WriteString(labelStyle.Render(fmt.Sprintf("%-10s", day.Format("Mon 02.01"))))
+		if len(entries) == 0 {
+			sb.WriteString(emptyStyle.Render(" -"))
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(valueStyle.Render(fmt.Sprintf(" %s", formatLedgerHours(dayTotal))))
+		sb.WriteString("\n")
+
+		for _, wh := range entries {
+			times := formatWorkhourTimes(wh)
+			if times == "" {
+				times = "·"
+			}
+			sb.WriteString(emptyStyle.Render(fmt.Sprintf("  %-22s", times)))
+			sb.WriteString(valueStyle.Render(fmt.Sprintf(" %6s  %s", formatLedgerHours(wh.Hours), m.entryLabel(wh))))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("Week total: "))
+	sb.WriteString(valueStyle.Bold(true).Render(formatLedgerHours(weekTotal)))
+	if m.WeeklyTarget > 0 {
+		sb.WriteString(labelStyle.Render(fmt.Sprintf(" of %s", formatLedgerHours(m.WeeklyTarget))))
+	}
+	sb.WriteString("\n\n")
+
+	sb.WriteString(render.RenderHelpText("</>: week", "ESC: close"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+// workhoursOn returns the entries of a day, timed entries first in start order
+func (m *WeekViewModal) workhoursOn(day time.Time) []domain.Workhour {
+	var entries []domain.Workhour
+	for _, wh := range m.Workhours {
+		if wh.Date.Year() == day.Year() && wh.Date.YearDay() == day.YearDay() {
+			entries = append(entries, wh)
+		}
+	}
+	sortWorkhoursByTime(entries)
+	return entries
+}
+
+func (m *WeekViewModal) entryLabel(wh domain.Workhour) string {
+	label := "Unknown"
+	for _, d := range m.WorkhourDetails {
+		if d.ID == wh.DetailsID {
+			label = d.ShortName
+			break
+		}
+	}
+	for _, p := range m.Projects {
+		if p.ID == wh.ProjectID {
+			return fmt.Sprintf("%s %s", label, p.Name)
+		}
+	}
+	return label
+}
+
+func dispatchWeekViewClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return WeekViewClosedMsg{}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 	"tltui/src/common"
 	"tltui/src/domain"
+	"tltui/src/domain/repository"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,58 +17,67 @@ import (
 type WorkhourCreateModal struct {
 	Date time.Time
 	Form *common.MixedForm
+
+	Tasks         []domain.Task
+	TaskProjectID int     // Project the task options were built for
+	HoursRounding float64 // Step entered hours are rounded to, 0 for none
+
+	Violations []domain.RuleViolation // Rules the entry would break once saved
+
+	Note string // Where the prefilled values came from, shown under the date
 }
 
 type WorkhourCreateSubmittedMsg struct {
 	Date      time.Time
 	DetailsID int
 	ProjectID int
+	TaskID    int
 	Hours     float64
+	IssueKey  string
+
+	// Set when the hours were entered as a time range
+	Start        domain.ClockTime
+	End          domain.ClockTime
+	BreakMinutes int
 }
 
 type WorkhourCreateCanceledMsg struct{}
 
-func NewWorkhourCreateModal(date time.Time, workhourDetails []domain.WorkhourDetails, projects []domain.Project) *WorkhourCreateModal {
-	// Build activity/details options
-	detailsOptions := make([]common.SelectOption, len(workhourDetails))
-	for i, d := range workhourDetails {
-		workType := "work"
-		if !d.IsWork {
-			workType = "non-work"
-		}
-		detailsOptions[i] = common.SelectOption{
-			ID:          d.ID,
-			DisplayName: fmt.Sprintf("%s %s", d.ShortName, d.Name),
-			ExtraInfo:   workType,
-		}
-	}
-
-	// Build project options
-	projectOptions := make([]common.SelectOption, len(projects))
-	for i, p := range projects {
-		projectOptions[i] = common.SelectOption{
-			ID:          p.ID,
-			DisplayName: p.Name,
-			ExtraInfo:   fmt.Sprintf("Odoo: %d", p.OdooID),
-		}
-	}
-
-	// Create form elements
-	detailsSelect := common.NewRequiredFormSelect("Type", detailsOptions)
-	projectSelect := common.NewRequiredFormSelect("Project", projectOptions)
-	hoursField := common.NewRequiredFormField("Hours", "8.0", 20).
-		WithCharLimit(5).
-		WithValidator(common.PositiveFloatValidator("Hours"))
+func NewWorkhourCreateModal(date time.Time, workhourDetails []domain.WorkhourDetails, projects []domain.Project, tasks []domain.Task) *WorkhourCreateModal {
+	detailsSelect := newDetailsSearchSelect(workhourDetails)
+	projectSelect := newProjectSearchSelect(projects)
+	taskSelect := newTaskSearchSelect(tasks, projectSelect.GetSelectedID())
+	hoursRounding := repository.GetHoursRounding()
+	hoursField := newHoursField("", hoursRounding)
+	issueField := newIssueField("")
 
 	// Create form
-	form := common.NewMixedForm(detailsSelect, projectSelect, &hoursField)
+	form := common.NewMixedForm(detailsSelect, projectSelect, taskSelect, &hoursField, &issueField)
 
 	return &WorkhourCreateModal{
-		Date: date,
-		Form: form,
+		Date:          date,
+		Form:          form,
+		Tasks:         tasks,
+		TaskProjectID: projectSelect.GetSelectedID(),
+		HoursRounding: hoursRounding,
 	}
 }
 
+// Prefill fills the form with a proposed entry, e.g. one suggested from git commits,
+// for the user to review before saving. A zero DetailsID keeps the type selection.
+func (m *WorkhourCreateModal) Prefill(workhour domain.Workhour, note string) {
+	if workhour.DetailsID > 0 {
+		m.Form.GetSearchSelect(0).SelectByID(workhour.DetailsID)
+	}
+	m.Form.GetSearchSelect(1).SelectByID(workhour.ProjectID)
+	refreshTaskSelect(m.Form, m.Tasks, &m.TaskProjectID)
+	m.Form.GetSearchSelect(2).SelectByID(workhour.TaskID)
+	m.Form.GetField(3).Input.SetValue(hoursInputValue(workhour))
+	m.Form.GetField(4).Input.SetValue(workhour.IssueKey)
+	m.Note = note
+	m.Violations = previewRuleViolations(m.Form, m.Date, 0, m.HoursRounding)
+}
+
 func (m *WorkhourCreateModal) Update(msg tea.Msg) (WorkhourCreateModal, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -77,13 +87,20 @@ func (m *WorkhourCreateModal) Update(msg tea.Msg) (WorkhourCreateModal, tea.Cmd)
 				return *m, nil
 			}
 
-			detailsID := m.Form.GetSelect(0).GetSelectedID()
-			projectID := m.Form.GetSelect(1).GetSelectedID()
-			hoursStr := strings.TrimSpace(m.Form.GetField(2).Value())
-			hours, _ := strconv.ParseFloat(hoursStr, 64) // Already validated
+			entry := parseHoursInput(m.Form.GetField(3).Value(), m.HoursRounding) // Already validated
 
 			return *m, tea.Batch(
-				dispatchWorkhourCreateSubmittedMsg(m.Date, detailsID, projectID, hours),
+				dispatchWorkhourCreateSubmittedMsg(WorkhourCreateSubmittedMsg{
+					Date:         m.Date,
+					DetailsID:    m.Form.GetSearchSelect(0).GetSelectedID(),
+					ProjectID:    m.Form.GetSearchSelect(1).GetSelectedID(),
+					TaskID:       max(m.Form.GetSearchSelect(2).GetSelectedID(), 0),
+					Hours:        entry.Hours,
+					IssueKey:     strings.TrimSpace(m.Form.GetField(4).Value()),
+					Start:        entry.Start,
+					End:          entry.End,
+					BreakMinutes: entry.BreakMinutes,
+				}),
 			)
 
 		case "esc":
@@ -94,6 +111,8 @@ func (m *WorkhourCreateModal) Update(msg tea.Msg) (WorkhourCreateModal, tea.Cmd)
 	}
 
 	cmd := m.Form.Update(msg)
+	refreshTaskSelect(m.Form, m.Tasks, &m.TaskProjectID)
+	m.Violations = previewRuleViolations(m.Form, m.Date, 0, m.HoursRounding)
 
 	switch msg.(type) {
 	case common.TryQuitMsg:
@@ -125,21 +144,23 @@ func (m *WorkhourCreateModal) View(Width, Height int) string {
 	sb.WriteString(dateStyle.Render(dateStr))
 	sb.WriteString("\n\n")
 
+	if m.Note != "" {
+		noteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Italic(true)
+		sb.WriteString(noteStyle.Render(m.Note))
+		sb.WriteString("\n\n")
+	}
+
 	sb.WriteString(m.Form.View())
+	sb.WriteString(renderRuleViolations(m.Violations))
 
-	sb.WriteString(render.RenderHelpText("Tab: next", "↑/↓: select", "Enter: save", "ESC: cancel"))
+	sb.WriteString(render.RenderHelpText("Tab: next", "type: filter", "↑/↓: select", "Enter: save", "ESC: cancel"))
 
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
-func dispatchWorkhourCreateSubmittedMsg(date time.Time, detailsID int, projectID int, hours float64) tea.Cmd {
+func dispatchWorkhourCreateSubmittedMsg(msg WorkhourCreateSubmittedMsg) tea.Cmd {
 	return func() tea.Msg {
-		return WorkhourCreateSubmittedMsg{
-			Date:      date,
-			DetailsID: detailsID,
-			ProjectID: projectID,
-			Hours:     hours,
-		}
+		return msg
 	}
 }
 
@@ -148,3 +169,126 @@ func dispatchWorkhourCreateCanceledMsg() tea.Cmd {
 		return WorkhourCreateCanceledMsg{}
 	}
 }
+
+// newHoursField builds the hours input shared by the workhour modals. It accepts any
+// duration understood by common.ParseDuration and shows the interpreted hours live.
+func newHoursField(initialValue string, hoursRounding float64) common.FormField {
+	return common.NewRequiredFormField("Hours", "8, 1h30m, 1:30 or 9-17:30 -30m", 34).
+		WithInitialValue(initialValue).
+		WithCharLimit(32).
+		WithValidator(common.DurationValidator("Hours", hoursRounding)).
+		WithPreview(common.DurationPreview(hoursRounding))
+}
+
+// newIssueField builds the optional issue key input of the workhour modals, used
+// when pushing the hours to Jira or GitLab
+func newIssueField(initialValue string) common.FormField {
+	return common.NewFormField("Issue", "API-12 or group/api#12 (optional)", 34).
+		WithInitialValue(initialValue).
+		WithCharLimit(200)
+}
+
+// parseHoursInput reads the hours field into the hours and, for time ranges, the
+// start/end times and break of a workhour. The value must already be validated.
+func parseHoursInput(value string, hoursRounding float64) domain.Workhour {
+	var entry domain.Workhour
+	entry.Hours, _ = common.ParseDuration(value, hoursRounding)
+	if span, isRange, err := common.ParseTimeSpan(value); isRange && err == nil {
+		entry.Start = domain.ClockTime(span.StartMinutes)
+		entry.End = domain.ClockTime(span.EndMinutes)
+		entry.BreakMinutes = span.BreakMinutes
+	}
+	return entry
+}
+
+// hoursInputValue formats a workhour for the hours field, as a time range when it has times
+func hoursInputValue(workhour domain.Workhour) string {
+	if !workhour.HasTimes() {
+		return strconv.FormatFloat(workhour.Hours, 'f', -1, 64)
+	}
+	return common.TimeSpan{
+		StartMinutes: int(workhour.Start),
+		EndMinutes:   int(workhour.End),
+		BreakMinutes: workhour.BreakMinutes,
+	}.String()
+}
+
+// buildTaskOptions lists the tasks of a project, led by a "no task" option
+func buildTaskOptions(tasks []domain.Task, projectID int) []common.SelectOption {
+	options := []common.SelectOption{{ID: 0, DisplayName: "No task"}}
+	for _, t := range tasks {
+		if t.ProjectID != projectID {
+			continue
+		}
+		option := common.SelectOption{ID: t.ID, DisplayName: t.Name}
+		if t.OdooID != 0 {
+			option.ExtraInfo = fmt.Sprintf("Odoo: %d", t.OdooID)
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// newDetailsSearchSelect builds the workhour type select, most recently used types first
+func newDetailsSearchSelect(workhourDetails []domain.WorkhourDetails) *common.FormSearchSelect {
+	options := make([]common.SelectOption, len(workhourDetails))
+	for i, d := range workhourDetails {
+		workType := "work"
+		if !d.IsWork {
+			workType = "non-work"
+		}
+		options[i] = common.SelectOption{
+			ID:          d.ID,
+			DisplayName: fmt.Sprintf("%s %s", d.ShortName, d.Name),
+			ExtraInfo:   workType,
+		}
+	}
+
+	return common.NewRequiredFormSearchSelect("Type", options).
+		WithRecent(recentSelections(repository.SelectionKindWorkhourDetails))
+}
+
+// newProjectSearchSelect builds the project select, most recently used projects first
+func newProjectSearchSelect(projects []domain.Project) *common.FormSearchSelect {
+	options := make([]common.SelectOption, len(projects))
+	for i, p := range projects {
+		options[i] = common.SelectOption{
+			ID:          p.ID,
+			DisplayName: p.Name,
+			ExtraInfo:   fmt.Sprintf("Odoo: %d", p.OdooID),
+		}
+	}
+
+	return common.NewRequiredFormSearchSelect("Project", options).
+		WithRecent(recentSelections(repository.SelectionKindProject))
+}
+
+// newTaskSearchSelect builds the task select of a project, starting on "No task"
+func newTaskSearchSelect(tasks []domain.Task, projectID int) *common.FormSearchSelect {
+	taskSelect := common.NewFormSearchSelect("Task", buildTaskOptions(tasks, projectID)).
+		WithRecent(recentSelections(repository.SelectionKindTask))
+	taskSelect.SelectByID(0)
+	return taskSelect
+}
+
+// recentSelections returns the most recently used IDs of a kind, or none if they cannot be loaded
+func recentSelections(kind string) []int {
+	ids, err := repository.GetRecentSelections(kind)
+	if err != nil {
+		return nil
+	}
+	return ids
+}
+
+// refreshTaskSelect rebuilds the task options (field 2) whenever the selected project (field 1) changes
+func refreshTaskSelect(form *common.MixedForm, tasks []domain.Task, taskProjectID *int) {
+	projectID := form.GetSearchSelect(1).GetSelectedID()
+	if projectID == *taskProjectID {
+		return
+	}
+	*taskProjectID = projectID
+
+	taskSelect := form.GetSearchSelect(2)
+	taskSelect.SetOptions(buildTaskOptions(tasks, projectID))
+	taskSelect.SelectByID(0)
+}
@@ -1,12 +1,11 @@
 package calendar
 
 import (
-	"fmt"
-	"strconv"
 	"strings"
 	"time"
 	"tltui/src/common"
 	"tltui/src/domain"
+	"tltui/src/domain/repository"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,6 +16,12 @@ type WorkhourEditModal struct {
 	WorkhourID int
 	Date       time.Time
 	Form       *common.MixedForm
+
+	Tasks         []domain.Task
+	TaskProjectID int     // Project the task options were built for
+	HoursRounding float64 // Step entered hours are rounded to, 0 for none
+
+	Violations []domain.RuleViolation // Rules the entry would break once saved
 }
 
 type WorkhourEditSubmittedMsg struct {
@@ -24,68 +29,47 @@ type WorkhourEditSubmittedMsg struct {
 	Date       time.Time
 	DetailsID  int
 	ProjectID  int
+	TaskID     int
 	Hours      float64
+	IssueKey   string
+
+	// Set when the hours were entered as a time range
+	Start        domain.ClockTime
+	End          domain.ClockTime
+	BreakMinutes int
 }
 
 type WorkhourEditCanceledMsg struct{}
 
 func NewWorkhourEditModal(
-	workhourID int,
-	date time.Time,
-	currentDetailsID int,
-	currentProjectID int,
-	currentHours float64,
+	workhour domain.Workhour,
 	workhourDetails []domain.WorkhourDetails,
 	projects []domain.Project,
+	tasks []domain.Task,
 ) *WorkhourEditModal {
-	detailsOptions := make([]common.SelectOption, len(workhourDetails))
-	selectedDetailsIndex := 0
-	for i, d := range workhourDetails {
-		workType := "work"
-		if !d.IsWork {
-			workType = "non-work"
-		}
-		detailsOptions[i] = common.SelectOption{
-			ID:          d.ID,
-			DisplayName: fmt.Sprintf("%s %s", d.ShortName, d.Name),
-			ExtraInfo:   workType,
-		}
-		if d.ID == currentDetailsID {
-			selectedDetailsIndex = i
-		}
-	}
-
-	projectOptions := make([]common.SelectOption, len(projects))
-	selectedProjectIndex := 0
-	for i, p := range projects {
-		projectOptions[i] = common.SelectOption{
-			ID:          p.ID,
-			DisplayName: p.Name,
-			ExtraInfo:   fmt.Sprintf("Odoo: %d", p.OdooID),
-		}
-		if p.ID == currentProjectID {
-			selectedProjectIndex = i
-		}
-	}
+	detailsSelect := newDetailsSearchSelect(workhourDetails)
+	detailsSelect.SelectByID(workhour.DetailsID)
 
-	detailsSelect := common.NewRequiredFormSelect("Type", detailsOptions)
-	detailsSelect.SelectedIndex = selectedDetailsIndex
+	projectSelect := newProjectSearchSelect(projects)
+	projectSelect.SelectByID(workhour.ProjectID)
 
-	projectSelect := common.NewRequiredFormSelect("Project", projectOptions)
-	projectSelect.SelectedIndex = selectedProjectIndex
+	taskSelect := newTaskSearchSelect(tasks, projectSelect.GetSelectedID())
+	taskSelect.SelectByID(workhour.TaskID)
 
-	hoursField := common.NewRequiredFormField("Hours", fmt.Sprintf("%.1f", currentHours), 20).
-		WithCharLimit(5).
-		WithValidator(common.PositiveFloatValidator("Hours"))
-  hoursField.Input.SetValue(fmt.Sprintf("%.1f", currentHours))
+	hoursRounding := repository.GetHoursRounding()
+	hoursField := newHoursField(hoursInputValue(workhour), hoursRounding)
+	issueField := newIssueField(workhour.IssueKey)
 
 	// Create form
-	form := common.NewMixedForm(detailsSelect, projectSelect, &hoursField)
+	form := common.NewMixedForm(detailsSelect, projectSelect, taskSelect, &hoursField, &issueField)
 
 	return &WorkhourEditModal{
-		WorkhourID: workhourID,
-		Date:       date,
-		Form:       form,
+		WorkhourID:    workhour.ID,
+		Date:          workhour.Date,
+		Form:          form,
+		Tasks:         tasks,
+		TaskProjectID: projectSelect.GetSelectedID(),
+		HoursRounding: hoursRounding,
 	}
 }
 
@@ -98,13 +82,21 @@ func (m *WorkhourEditModal) Update(msg tea.Msg) (WorkhourEditModal, tea.Cmd) {
 				return *m, nil
 			}
 
-			detailsID := m.Form.GetSelect(0).GetSelectedID()
-			projectID := m.Form.GetSelect(1).GetSelectedID()
-			hoursStr := strings.TrimSpace(m.Form.GetField(2).Value())
-			hours, _ := strconv.ParseFloat(hoursStr, 64) // Already validated
+			entry := parseHoursInput(m.Form.GetField(3).Value(), m.HoursRounding) // Already validated
 
 			return *m, tea.Batch(
-				dispatchWorkhourEditSubmittedMsg(m.WorkhourID, m.Date, detailsID, projectID, hours),
+				dispatchWorkhourEditSubmittedMsg(WorkhourEditSubmittedMsg{
+					WorkhourID:   m.WorkhourID,
+					Date:         m.Date,
+					DetailsID:    m.Form.GetSearchSelect(0).GetSelectedID(),
+					ProjectID:    m.Form.GetSearchSelect(1).GetSelectedID(),
+					TaskID:       max(m.Form.GetSearchSelect(2).GetSelectedID(), 0),
+					Hours:        entry.Hours,
+					IssueKey:     strings.TrimSpace(m.Form.GetField(4).Value()),
+					Start:        entry.Start,
+					End:          entry.End,
+					BreakMinutes: entry.BreakMinutes,
+				}),
 			)
 
 		case "esc":
@@ -115,6 +107,8 @@ func (m *WorkhourEditModal) Update(msg tea.Msg) (WorkhourEditModal, tea.Cmd) {
 	}
 
 	cmd := m.Form.Update(msg)
+	refreshTaskSelect(m.Form, m.Tasks, &m.TaskProjectID)
+	m.Violations = previewRuleViolations(m.Form, m.Date, m.WorkhourID, m.HoursRounding)
 	return *m, cmd
 }
 
@@ -139,21 +133,16 @@ func (m *WorkhourEditModal) View(Width, Height int) string {
 	sb.WriteString("\n\n")
 
 	sb.WriteString(m.Form.View())
+	sb.WriteString(renderRuleViolations(m.Violations))
 
-	sb.WriteString(render.RenderHelpText("Tab: next", "↑/↓: select", "Enter: save", "ESC: cancel"))
+	sb.WriteString(render.RenderHelpText("Tab: next", "type: filter", "↑/↓: select", "Enter: save", "ESC: cancel"))
 
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
-func dispatchWorkhourEditSubmittedMsg(workhourID int, date time.Time, detailsID int, projectID int, hours float64) tea.Cmd {
+func dispatchWorkhourEditSubmittedMsg(msg WorkhourEditSubmittedMsg) tea.Cmd {
 	return func() tea.Msg {
-		return WorkhourEditSubmittedMsg{
-			WorkhourID: workhourID,
-			Date:       date,
-			DetailsID:  detailsID,
-			ProjectID:  projectID,
-			Hours:      hours,
-		}
+		return msg
 	}
 }
 
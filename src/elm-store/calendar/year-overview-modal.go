@@ -0,0 +1,354 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatmapPalettes holds one shade ramp (light to dark) per workhour details type.
+// Types are assigned a ramp by their position in the details list.
+var heatmapPalettes = [][]string{
+	{"22", "28", "34", "40", "46"},     // green
+	{"94", "130", "166", "202", "208"}, // orange
+	{"24", "25", "26", "27", "33"},     // blue
+	{"53", "89", "125", "161", "197"},  // magenta
+	{"23", "30", "37", "44", "51"},     // cyan
+	{"58", "100", "142", "184", "226"}, // yellow
+}
+
+type YearOverviewModal struct {
+	Year         int
+	SelectedDate time.Time
+
+	WorkhoursByDate map[string][]domain.Workhour
+	WorkhourDetails []domain.WorkhourDetails
+	ErrorMessage    string
+}
+
+type YearOverviewClosedMsg struct{}
+
+type YearOverviewDaySelectedMsg struct {
+	Date time.Time
+}
+
+func NewYearOverviewModal(selectedDate time.Time, workhourDetails []domain.WorkhourDetails) *YearOverviewModal {
+	m := &YearOverviewModal{
+		Year:            selectedDate.Year(),
+		SelectedDate:    selectedDate,
+		WorkhourDetails: workhourDetails,
+	}
+	m.loadYear()
+	return m
+}
+
+// loadYear fetches every workhour of the viewed year with a single range query
+func (m *YearOverviewModal) loadYear() {
+	startDate := time.Date(m.Year, time.January, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(m.Year, time.December, 31, 0, 0, 0, 0, time.Local)
+
+	m.WorkhoursByDate = make(map[string][]domain.Workhour)
+	m.ErrorMessage = ""
+
+	workhours, err := repository.GetWorkhoursByDateRange(startDate, endDate)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		return
+	}
+
+	for _, wh := range workhours {
+		key := repository.DateToString(wh.Date)
+		m.WorkhoursByDate[key] = append(m.WorkhoursByDate[key], wh)
+	}
+}
+
+func (m *YearOverviewModal) Update(msg tea.Msg) (YearOverviewModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "Y":
+			return *m, dispatchYearOverviewClosedMsg()
+
+		case "enter":
+			return *m, dispatchYearOverviewDaySelectedMsg(m.SelectedDate)
+
+		case "left", "h":
+			m.moveSelection(m.SelectedDate.AddDate(0, 0, -1))
+		case "right", "l":
+			m.moveSelection(m.SelectedDate.AddDate(0, 0, 1))
+		case "up", "k":
+			m.moveSelection(m.SelectedDate.AddDate(0, 0, -7))
+		case "down", "j":
+			m.moveSelection(m.SelectedDate.AddDate(0, 0, 7))
+		case "[":
+			m.moveSelection(m.SelectedDate.AddDate(0, -1, 0))
+		case "]":
+			m.moveSelection(m.SelectedDate.AddDate(0, 1, 0))
+
+		case "<":
+			m.moveSelection(m.SelectedDate.AddDate(-1, 0, 0))
+		case ">":
+			m.moveSelection(m.SelectedDate.AddDate(1, 0, 0))
+		}
+	}
+
+	return *m, nil
+}
+
+// moveSelection selects the given date, reloading data when it falls in another year
+func (m *YearOverviewModal) moveSelection(date time.Time) {
+	m.SelectedDate = date
+	if date.Year() != m.Year {
+		m.Year = date.Year()
+		m.loadYear()
+	}
+}
+
+// MonthTotal returns the total logged hours for the given month of the viewed year
+func (m *YearOverviewModal) MonthTotal(month time.Month) float64 {
+	var total float64
+	for dateStr, workhours := range m.WorkhoursByDate {
+		date, err := repository.StringToDate(dateStr)
+		if err != nil || date.Month() != month {
+			continue
+		}
+		for _, wh := range workhours {
+			total += wh.Hours
+		}
+	}
+	return total
+}
+
+// DayTotal returns the total hours and the details ID holding the most hours for a date
+func (m *YearOverviewModal) DayTotal(date time.Time) (float64, int) {
+	hoursByDetails := make(map[int]float64)
+	var total float64
+	for _, wh := range m.WorkhoursByDate[repository.DateToString(date)] {
+		hoursByDetails[wh.DetailsID] += wh.Hours
+		total += wh.Hours
+	}
+
+	dominantID := -1
+	var dominantHours float64
+	for id, hours := range hoursByDetails {
+		if hours > dominantHours || (hours == dominantHours && id < dominantID) {
+			dominantID = id
+			dominantHours = hours
+		}
+	}
+
+	return total, dominantID
+}
+
+func (m *YearOverviewModal) paletteFor(detailsID int) []string {
+	for i, wd := range m.WorkhourDetails {
+		if wd.ID == detailsID {
+			return heatmapPalettes[i%len(heatmapPalettes)]
+		}
+	}
+	return heatmapPalettes[0]
+}
+
+// shadeIndex maps logged hours to a position on a palette ramp
+func shadeIndex(hours float64) int {
+	switch {
+	case hours < 2:
+		return 0
+	case hours < 4:
+		return 1
+	case hours < 8:
+		return 2
+	case hours == 8:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func (m *YearOverviewModal) renderMonth(month time.Month) string {
+	var sb strings.Builder
+
+	monthHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	totalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	weekdayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	emptyDayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	total := m.MonthTotal(month)
+	header := monthHeaderStyle.Render(fmt.Sprintf("%-10s", month.String()))
+	header += totalStyle.Render(fmt.Sprintf("%7sh", formatHours(total)))
+	sb.WriteString(header)
+	sb.WriteString("\n")
+	sb.WriteString(weekdayStyle.Render("Mo Tu We Th Fr Sa Su"))
+	sb.WriteString("\n")
+
+	firstDay := time.Date(m.Year, month, 1, 0, 0, 0, 0, time.Local)
+	offset := (int(firstDay.Weekday()) + 6) % 7
+	daysInMonth := firstDay.AddDate(0, 1, -1).Day()
+
+	lines := 0
+	cells := make([]string, 0, 7)
+	for range offset {
+		cells = append(cells, "  ")
+	}
+
+	for day := 1; day <= daysInMonth; day++ {
+		date := time.Date(m.Year, month, day, 0, 0, 0, 0, time.Local)
+		hours, dominantID := m.DayTotal(date)
+
+		style := emptyDayStyle
+		if hours > 0 {
+			palette := m.paletteFor(dominantID)
+			style = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("255")).
+				Background(lipgloss.Color(palette[shadeIndex(hours)]))
+		}
+		if isSameDay(date, m.SelectedDate) {
+			style = style.Bold(true).Underline(true).
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("57"))
+		}
+
+		cells = append(cells, style.Render(fmt.Sprintf("%2d", day)))
+		if len(cells) == 7 {
+			sb.WriteString(strings.Join(cells, " "))
+			sb.WriteString("\n")
+			cells = cells[:0]
+			lines++
+		}
+	}
+
+	if len(cells) > 0 {
+		sb.WriteString(strings.Join(cells, " "))
+		sb.WriteString("\n")
+		lines++
+	}
+
+	// Pad to six weeks so months in the same row line up
+	for ; lines < 6; lines++ {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (m *YearOverviewModal) renderLegend() string {
+	var parts []string
+	for i, wd := range m.WorkhourDetails {
+		palette := heatmapPalettes[i%len(heatmapPalettes)]
+		swatch := lipgloss.NewStyle().Background(lipgloss.Color(palette[3])).Render("  ")
+		parts = append(parts, swatch+" "+wd.Name)
+	}
+	return strings.Join(parts, "   ")
+}
+
+func (m *YearOverviewModal) renderSelectedDay() string {
+	workhours := m.WorkhoursByDate[repository.DateToString(m.SelectedDate)]
+	line := labelStyle.Render(m.SelectedDate.Format("Monday, January 2, 2006") + ": ")
+	if len(workhours) == 0 {
+		return line + emptyStyle.Render("nothing logged")
+	}
+
+	detailsHours := make(map[string]float64)
+	var names []string
+	for _, wh := range workhours {
+		name := "Unknown"
+		for _, wd := range m.WorkhourDetails {
+			if wd.ID == wh.DetailsID {
+				name = wd.ShortName + " " + wd.Name
+				break
+			}
+		}
+		if _, seen := detailsHours[name]; !seen {
+			names = append(names, name)
+		}
+		detailsHours[name] += wh.Hours
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %sh", name, formatHours(detailsHours[name])))
+	}
+	return line + valueStyle.Render(strings.Join(parts, ", "))
+}
+
+func (m *YearOverviewModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	var yearTotal float64
+	for month := time.January; month <= time.December; month++ {
+		yearTotal += m.MonthTotal(month)
+	}
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Year Overview - %d", m.Year)))
+	sb.WriteString("\n")
+	yearTotalStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("114"))
+	sb.WriteString(labelStyle.Render("Total: "))
+	sb.WriteString(yearTotalStyle.Render(fmt.Sprintf("%sh", formatHours(yearTotal))))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	monthStyle := lipgloss.NewStyle().MarginRight(3)
+	for quarter := range 3 {
+		var months []string
+		for i := range 4 {
+			month := time.Month(quarter*4 + i + 1)
+			months = append(months, monthStyle.Render(m.renderMonth(month)))
+		}
+		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, months...))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(m.renderSelectedDay())
+	sb.WriteString("\n\n")
+	sb.WriteString(m.renderLegend())
+	sb.WriteString("\n")
+
+	sb.WriteString(render.RenderHelpText(
+		"←/→: day",
+		"↑/↓: week",
+		"[/]: month",
+		"</>: year",
+		"enter: open day",
+		"ESC: close"))
+
+	return render.RenderModal(Width, Height, Width, Height, sb.String())
+}
+
+// formatHours renders hours without a decimal part when they are whole
+func formatHours(hours float64) string {
+	if hours == float64(int(hours)) {
+		return fmt.Sprintf("%d", int(hours))
+	}
+	return fmt.Sprintf("%.1f", hours)
+}
+
+func isSameDay(date1, date2 time.Time) bool {
+	y1, m1, d1 := date1.Date()
+	y2, m2, d2 := date2.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+func dispatchYearOverviewClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return YearOverviewClosedMsg{}
+	}
+}
+
+func dispatchYearOverviewDaySelectedMsg(date time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return YearOverviewDaySelectedMsg{Date: date}
+	}
+}
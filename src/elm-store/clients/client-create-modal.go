@@ -0,0 +1,157 @@
+package clients
+
+import (
+	"strings"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reportLanguages are the languages the mail report can be rendered in
+var reportLanguages = []common.SelectOption{
+	{ID: 0, DisplayName: "ro", ExtraInfo: "Romanian"},
+	{ID: 1, DisplayName: "en", ExtraInfo: "English"},
+}
+
+type ClientCreateModal struct {
+	Form *common.MixedForm
+}
+
+type ClientCreatedMsg struct {
+	Client domain.Client
+}
+
+type ClientCreateCanceledMsg struct{}
+
+// newClientForm builds the form shared by the create and edit modals
+func newClientForm(client domain.Client) *common.MixedForm {
+	nameField := common.NewRequiredFormField("Name", "Client Name", 40).
+		WithInitialValue(client.Name).
+		WithValidator(common.ChainValidators(
+			common.MinLengthValidator("Name", 2),
+			common.MaxLengthValidator("Name", 50),
+		))
+
+	legalNameField := common.NewFormField("Legal Name", "Company S.R.L.", 40).
+		WithCharLimit(100).
+		WithInitialValue(client.LegalName).
+		WithHelpText("used as \"To Company\" in reports")
+
+	taxIDField := common.NewFormField("Tax ID", "RO12345678", 40).
+		WithCharLimit(20).
+		WithInitialValue(client.TaxID)
+
+	addressField := common.NewFormField("Address", "Street, City, Country", 60).
+		WithCharLimit(200).
+		WithInitialValue(client.Address)
+
+	languageSelect := common.NewRequiredFormSelect("Default Language", reportLanguages)
+	for i, option := range reportLanguages {
+		if option.DisplayName == client.Language {
+			languageSelect.SelectedIndex = i
+		}
+	}
+
+	billingContactField := common.NewFormField("Billing Contact", "billing@example.com", 40).
+		WithCharLimit(100).
+		WithInitialValue(client.BillingContact)
+
+	recipientsField := common.NewFormField("Report Emails", "ana@example.com, billing@example.com", 60).
+		WithCharLimit(500).
+		WithInitialValue(strings.Join(client.ReportRecipients, ", ")).
+		WithValidator(func(value string) error {
+			if _, err := domain.ParseEmailList(value); err != nil {
+				return &common.ValidationError{Field: "Report Emails", Message: err.Error()}
+			}
+			return nil
+		}).
+		WithHelpText("generated reports are emailed to these addresses")
+
+	return common.NewMixedForm(&nameField, &legalNameField, &taxIDField, &addressField, languageSelect, &billingContactField, &recipientsField)
+}
+
+// parseClientForm reads a validated client form
+func parseClientForm(form *common.MixedForm) domain.Client {
+	language := "ro"
+	if option := form.GetSelect(4).GetSelectedOption(); option != nil {
+		language = option.DisplayName
+	}
+
+	// The list was validated with the form
+	recipients, _ := domain.ParseEmailList(form.GetField(6).Value())
+
+	return domain.Client{
+		Name:             strings.TrimSpace(form.GetField(0).Value()),
+		LegalName:        strings.TrimSpace(form.GetField(1).Value()),
+		TaxID:            strings.TrimSpace(form.GetField(2).Value()),
+		Address:          strings.TrimSpace(form.GetField(3).Value()),
+		Language:         language,
+		BillingContact:   strings.TrimSpace(form.GetField(5).Value()),
+		ReportRecipients: recipients,
+	}
+}
+
+func NewClientCreateModal() *ClientCreateModal {
+	return &ClientCreateModal{
+		Form: newClientForm(domain.Client{Language: "ro"}),
+	}
+}
+
+func (m *ClientCreateModal) Update(msg tea.Msg) (ClientCreateModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+
+			return *m, tea.Batch(
+				dispatchClientCreatedMsg(parseClientForm(m.Form)),
+			)
+
+		case "esc":
+			return *m, tea.Batch(
+				dispatchClientCreateCanceledMsg(),
+			)
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *ClientCreateModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	sb.WriteString(titleStyle.Render("Create New Client"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Tab/Shift+Tab: navigate", "Enter: create", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchClientCreatedMsg(client domain.Client) tea.Cmd {
+	return func() tea.Msg {
+		return ClientCreatedMsg{
+			Client: client,
+		}
+	}
+}
+
+func dispatchClientCreateCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return ClientCreateCanceledMsg{}
+	}
+}
@@ -0,0 +1,94 @@
+package clients
+
+import (
+	"strings"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ClientDeleteModal struct {
+	ClientID   int
+	ClientName string
+}
+
+type ClientDeletedMsg struct {
+	ClientID int
+}
+
+type ClientDeleteCanceledMsg struct{}
+
+func NewClientDeleteModal(clientID int, clientName string) *ClientDeleteModal {
+	return &ClientDeleteModal{
+		ClientID:   clientID,
+		ClientName: clientName,
+	}
+}
+
+func (m *ClientDeleteModal) Update(msg tea.Msg) (ClientDeleteModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y", "enter":
+			return *m, tea.Batch(
+				dispatchClientDeletedMsg(m.ClientID),
+			)
+
+		case "n", "N", "esc":
+			return *m, tea.Batch(
+				dispatchClientDeleteCanceledMsg(),
+			)
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *ClientDeleteModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("196")). // Red for delete
+		MarginBottom(1)
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("241"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")). // Orange warning
+		Bold(true)
+
+	sb.WriteString(titleStyle.Render("⚠ Delete Client"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(warningStyle.Render("Are you sure you want to delete this client?"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Name: "))
+	sb.WriteString(m.ClientName)
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Projects of this client are kept without a client."))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(render.RenderHelpText("Y/Enter: confirm delete", "N/ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchClientDeletedMsg(clientID int) tea.Cmd {
+	return func() tea.Msg {
+		return ClientDeletedMsg{
+			ClientID: clientID,
+		}
+	}
+}
+
+func dispatchClientDeleteCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return ClientDeleteCanceledMsg{}
+	}
+}
@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"strings"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ClientEditModal struct {
+	EditingClientID int
+	Form            *common.MixedForm
+}
+
+type ClientEditedMsg struct {
+	Client domain.Client
+}
+
+type ClientEditCanceledMsg struct{}
+
+func NewClientEditModal(client domain.Client) *ClientEditModal {
+	return &ClientEditModal{
+		EditingClientID: client.ID,
+		Form:            newClientForm(client),
+	}
+}
+
+func (m *ClientEditModal) Update(msg tea.Msg) (ClientEditModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+
+			client := parseClientForm(m.Form)
+			client.ID = m.EditingClientID
+
+			return *m, tea.Batch(
+				dispatchClientEditedMsg(client),
+			)
+
+		case "esc":
+			return *m, tea.Batch(
+				dispatchClientEditCanceledMsg(),
+			)
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *ClientEditModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	sb.WriteString(titleStyle.Render("Edit Client"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Tab/Shift+Tab: navigate", "Enter: save", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchClientEditedMsg(client domain.Client) tea.Cmd {
+	return func() tea.Msg {
+		return ClientEditedMsg{
+			Client: client,
+		}
+	}
+}
+
+func dispatchClientEditCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return ClientEditCanceledMsg{}
+	}
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"tltui/src/common"
+	"tltui/src/domain/repository"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m ClientsModel) handleClientCreated(msg ClientCreatedMsg) (ClientsModel, tea.Cmd) {
+	_, err := repository.CreateClient(msg.Client)
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to create client", err)
+	}
+
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to reload clients", err)
+	}
+	m.Clients = clients
+
+	m.updateTableRows()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+func (m ClientsModel) handleClientEdited(msg ClientEditedMsg) (ClientsModel, tea.Cmd) {
+	err := repository.UpdateClient(msg.Client)
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to update client", err)
+	}
+
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to reload clients", err)
+	}
+	m.Clients = clients
+
+	m.updateTableRows()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+func (m ClientsModel) handleClientDeleted(msg ClientDeletedMsg) (ClientsModel, tea.Cmd) {
+	err := repository.DeleteClient(msg.ClientID)
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to delete client", err)
+	}
+
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to reload clients", err)
+	}
+	m.Clients = clients
+
+	m.updateTableRows()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+func (m *ClientsModel) updateTableRows() {
+	rows := []table.Row{}
+	for _, c := range m.Clients {
+		rows = append(rows, table.Row{
+			c.Name,
+			c.LegalName,
+			c.TaxID,
+			c.Language,
+			c.BillingContact,
+		})
+	}
+	m.TableView.SetRows(rows)
+}
@@ -0,0 +1,51 @@
+package clients
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ClientModal represents any modal in the Clients view
+type ClientModal interface {
+	Update(tea.Msg) (ClientModal, tea.Cmd)
+	View(width, height int) string
+}
+
+// ClientCreateModalWrapper wraps ClientCreateModal to implement ClientModal
+type ClientCreateModalWrapper struct {
+	*ClientCreateModal
+}
+
+func (w ClientCreateModalWrapper) Update(msg tea.Msg) (ClientModal, tea.Cmd) {
+	_, cmd := w.ClientCreateModal.Update(msg)
+	return w, cmd
+}
+
+func (w ClientCreateModalWrapper) View(width, height int) string {
+	return w.ClientCreateModal.View(width, height)
+}
+
+// ClientEditModalWrapper wraps ClientEditModal to implement ClientModal
+type ClientEditModalWrapper struct {
+	*ClientEditModal
+}
+
+func (w ClientEditModalWrapper) Update(msg tea.Msg) (ClientModal, tea.Cmd) {
+	_, cmd := w.ClientEditModal.Update(msg)
+	return w, cmd
+}
+
+func (w ClientEditModalWrapper) View(width, height int) string {
+	return w.ClientEditModal.View(width, height)
+}
+
+// ClientDeleteModalWrapper wraps ClientDeleteModal to implement ClientModal
+type ClientDeleteModalWrapper struct {
+	*ClientDeleteModal
+}
+
+func (w ClientDeleteModalWrapper) Update(msg tea.Msg) (ClientModal, tea.Cmd) {
+	_, cmd := w.ClientDeleteModal.Update(msg)
+	return w, cmd
+}
+
+func (w ClientDeleteModalWrapper) View(width, height int) string {
+	return w.ClientDeleteModal.View(width, height)
+}
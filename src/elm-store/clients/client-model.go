@@ -0,0 +1,149 @@
+package clients
+
+import (
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ClientsModel struct {
+	Width  int
+	Height int
+
+	ActiveModal ClientModal
+
+	TableView common.TableView
+	Clients   []domain.Client
+}
+
+func NewClientsModel() ClientsModel {
+	m := ClientsModel{}
+
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		clients = []domain.Client{}
+	}
+	m.Clients = clients
+
+	columns := []table.Column{
+		{Title: "Name", Width: 20},
+		{Title: "Legal Name", Width: 30},
+		{Title: "Tax ID", Width: 14},
+		{Title: "Lang", Width: 5},
+		{Title: "Billing Contact", Width: 30},
+	}
+
+	m.TableView = common.NewTableView(columns, []table.Row{})
+	m.TableView.Table.SetHeight(100)
+	m.updateTableRows()
+
+	return m
+}
+
+func (m ClientsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ClientsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ClientCreatedMsg:
+		return m.handleClientCreated(msg)
+
+	case ClientCreateCanceledMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case ClientEditedMsg:
+		return m.handleClientEdited(msg)
+
+	case ClientEditCanceledMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case ClientDeletedMsg:
+		return m.handleClientDeleted(msg)
+
+	case ClientDeleteCanceledMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+		verticalMargin := 12
+		m.TableView.SetSize(msg.Width, msg.Height, verticalMargin)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q":
+			// Only close delete modal on 'q' (edit/create modals have text inputs where user might type 'q')
+			if _, isDelete := m.ActiveModal.(ClientDeleteModalWrapper); isDelete {
+				m.ActiveModal = nil
+				return m, nil
+			}
+			if m.ActiveModal != nil {
+				break
+			}
+			return m, tea.Quit
+
+		case "n":
+			if m.ActiveModal == nil {
+				m.ActiveModal = ClientCreateModalWrapper{NewClientCreateModal()}
+				return m, nil
+			}
+
+		case "d":
+			if m.ActiveModal == nil {
+				selectedClient := m.getSelectedClient()
+				if selectedClient != nil {
+					m.ActiveModal = ClientDeleteModalWrapper{NewClientDeleteModal(
+						selectedClient.ID,
+						selectedClient.Name,
+					)}
+					return m, nil
+				}
+			}
+
+		case "enter":
+			if m.ActiveModal == nil {
+				selectedClient := m.getSelectedClient()
+				if selectedClient != nil {
+					m.ActiveModal = ClientEditModalWrapper{NewClientEditModal(*selectedClient)}
+					return m, nil
+				}
+			}
+		}
+	}
+
+	if m.ActiveModal != nil {
+		_, cmd := m.ActiveModal.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.TableView, cmd = m.TableView.Update(msg)
+	return m, cmd
+}
+
+func (m ClientsModel) View() string {
+	helpText := render.RenderHelpText("↑/↓: navigate", "enter: edit", "n: new", "d: delete", "q: quit")
+
+	if m.ActiveModal != nil {
+		return m.ActiveModal.View(m.Width, m.Height)
+	}
+
+	return m.TableView.View() + "\n" + helpText
+}
+
+func (m ClientsModel) getSelectedClient() *domain.Client {
+	cursor := m.TableView.Cursor()
+	if cursor >= 0 && cursor < len(m.Clients) {
+		return &m.Clients[cursor]
+	}
+	return nil
+}
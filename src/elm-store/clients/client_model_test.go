@@ -0,0 +1,143 @@
+package clients
+
+import (
+	"testing"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestClientsModel_HandleClientCreated(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	m := NewClientsModel()
+
+	msg := ClientCreatedMsg{Client: domain.Client{
+		Name:           "Arnia",
+		LegalName:      "Arnia Software S.R.L.",
+		TaxID:          "RO123",
+		Address:        "Bucharest",
+		Language:       "en",
+		BillingContact: "billing@arnia.example",
+	}}
+
+	updatedModel, _ := m.handleClientCreated(msg)
+
+	if updatedModel.ActiveModal != nil {
+		t.Error("expected modal to be closed after creation")
+	}
+	if len(updatedModel.Clients) != 1 {
+		t.Fatalf("got %d clients, want 1", len(updatedModel.Clients))
+	}
+
+	client := updatedModel.Clients[0]
+	if client.LegalName != "Arnia Software S.R.L." || client.Language != "en" || client.BillingContact != "billing@arnia.example" {
+		t.Errorf("got client %+v", client)
+	}
+}
+
+func TestClientsModel_HandleClientEdited(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	client := repository.CreateTestClient(t, "Old Name", "Old S.R.L.")
+
+	m := NewClientsModel()
+
+	client.Name = "New Name"
+	client.TaxID = "RO999"
+	client.ReportRecipients = []string{"ana@example.com", "Billing <billing@example.com>"}
+	updatedModel, _ := m.handleClientEdited(ClientEditedMsg{Client: client})
+
+	if updatedModel.ActiveModal != nil {
+		t.Error("expected modal to be closed after edit")
+	}
+
+	stored, _ := repository.GetClientByID(client.ID)
+	if stored == nil || stored.Name != "New Name" || stored.TaxID != "RO999" {
+		t.Errorf("got client %+v, want updated name and tax ID", stored)
+	}
+	if stored == nil || len(stored.ReportRecipients) != 2 || stored.ReportRecipients[1] != "Billing <billing@example.com>" {
+		t.Errorf("got recipients %q, want both addresses", stored.ReportRecipients)
+	}
+}
+
+func TestClientEditModal_ValidatesReportEmails(t *testing.T) {
+	modal := NewClientEditModal(domain.Client{ID: 1, Name: "Arnia", Language: "en", ReportRecipients: []string{"ana@example.com"}})
+	if got := modal.Form.GetField(6).Value(); got != "ana@example.com" {
+		t.Errorf("expected the recipients to be prefilled, got %q", got)
+	}
+
+	modal.Form.GetField(6).Input.SetValue("ana@example.com, not an address")
+	if _, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected an invalid address to block saving")
+	}
+
+	modal.Form.GetField(6).Input.SetValue("ana@example.com; dan@example.com")
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the client to be saved")
+	}
+	edited, ok := cmd().(ClientEditedMsg)
+	if !ok || len(edited.Client.ReportRecipients) != 2 {
+		t.Errorf("expected 2 recipients, got %+v", edited.Client.ReportRecipients)
+	}
+}
+
+func TestClientsModel_HandleClientDeleted(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	client := repository.CreateTestClient(t, "Arnia", "")
+	project := domain.Project{ID: 1, Name: "API", OdooID: 100, ClientID: client.ID}
+	if err := repository.CreateProject(project); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	m := NewClientsModel()
+
+	updatedModel, _ := m.handleClientDeleted(ClientDeletedMsg{ClientID: client.ID})
+
+	if len(updatedModel.Clients) != 0 {
+		t.Errorf("expected 0 clients after delete, got %d", len(updatedModel.Clients))
+	}
+
+	// Projects outlive their client
+	stored, _ := repository.GetProjectByID(project.ID)
+	if stored == nil {
+		t.Fatal("expected project to be kept")
+	}
+	if stored.ClientID != 0 {
+		t.Errorf("expected client to be cleared, got %d", stored.ClientID)
+	}
+}
+
+func TestClientsModel_Update_OpenModals(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestClient(t, "Arnia", "Arnia Software S.R.L.")
+
+	m := NewClientsModel()
+
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if _, ok := updatedModel.(ClientsModel).ActiveModal.(ClientCreateModalWrapper); !ok {
+		t.Error("expected ClientCreateModalWrapper")
+	}
+
+	updatedModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	wrapper, ok := updatedModel.(ClientsModel).ActiveModal.(ClientEditModalWrapper)
+	if !ok {
+		t.Fatal("expected ClientEditModalWrapper")
+	}
+	if got := wrapper.Form.GetField(1).Value(); got != "Arnia Software S.R.L." {
+		t.Errorf("expected legal name to be prefilled, got %q", got)
+	}
+
+	updatedModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if _, ok := updatedModel.(ClientsModel).ActiveModal.(ClientDeleteModalWrapper); !ok {
+		t.Error("expected ClientDeleteModalWrapper")
+	}
+}
@@ -0,0 +1,178 @@
+package leave
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type LeaveEntitlementCreateModal struct {
+	Form *common.MixedForm
+}
+
+type LeaveEntitlementCreatedMsg struct {
+	Year            int
+	DetailsID       int
+	AllowanceDays   float64
+	CarryOverDays   float64
+	CarryOverExpiry *time.Time
+}
+
+type LeaveEntitlementCreateCanceledMsg struct{}
+
+// leaveFormValues holds the parsed content of an entitlement form
+type leaveFormValues struct {
+	Year            int
+	DetailsID       int
+	AllowanceDays   float64
+	CarryOverDays   float64
+	CarryOverExpiry *time.Time
+}
+
+// newLeaveEntitlementForm builds the form shared by the create and edit modals.
+// Only non-work types are offered since entitlements track leave.
+func newLeaveEntitlementForm(entitlement domain.LeaveEntitlement, workhourDetails []domain.WorkhourDetails) *common.MixedForm {
+	yearField := common.NewRequiredFormField("Year", "2025", 20).
+		WithCharLimit(4).
+		WithValidator(common.YearValidator("Year")).
+		WithInitialValue(strconv.Itoa(entitlement.Year))
+
+	var typeOptions []common.SelectOption
+	selectedIndex := 0
+	for _, d := range workhourDetails {
+		if d.IsWork {
+			continue
+		}
+		if d.ID == entitlement.DetailsID {
+			selectedIndex = len(typeOptions)
+		}
+		typeOptions = append(typeOptions, common.SelectOption{
+			ID:          d.ID,
+			DisplayName: fmt.Sprintf("%s %s", d.ShortName, d.Name),
+		})
+	}
+	typeSelect := common.NewRequiredFormSelect("Leave Type", typeOptions)
+	if len(typeOptions) > 0 {
+		typeSelect.SelectedIndex = selectedIndex
+	}
+
+	allowanceField := common.NewRequiredFormField("Annual Allowance (days)", "21", 20).
+		WithCharLimit(6).
+		WithValidator(common.NonNegativeFloatValidator("Annual Allowance")).
+		WithInitialValue(fmt.Sprintf("%g", entitlement.AllowanceDays))
+
+	carryOverField := common.NewRequiredFormField("Carry-over (days)", "0", 20).
+		WithCharLimit(6).
+		WithValidator(common.NonNegativeFloatValidator("Carry-over")).
+		WithInitialValue(fmt.Sprintf("%g", entitlement.CarryOverDays))
+
+	expiryValue := ""
+	if entitlement.CarryOverExpiry != nil {
+		expiryValue = entitlement.CarryOverExpiry.Format("2006-01-02")
+	}
+	expiryField := common.NewFormField("Carry-over Expires", "YYYY-MM-DD", 20).
+		WithCharLimit(10).
+		WithHelpText("Optional - unused carry-over lapses after this date").
+		WithValidator(common.OptionalValidator(common.DateValidator("Carry-over Expires"))).
+		WithInitialValue(expiryValue)
+
+	return common.NewMixedForm(&yearField, typeSelect, &allowanceField, &carryOverField, &expiryField)
+}
+
+// parseLeaveEntitlementForm reads an already validated entitlement form
+func parseLeaveEntitlementForm(form *common.MixedForm) leaveFormValues {
+	year, _ := strconv.Atoi(strings.TrimSpace(form.GetField(0).Value()))
+	allowance, _ := strconv.ParseFloat(strings.TrimSpace(form.GetField(2).Value()), 64)
+	carryOver, _ := strconv.ParseFloat(strings.TrimSpace(form.GetField(3).Value()), 64)
+
+	var expiry *time.Time
+	if expiryStr := strings.TrimSpace(form.GetField(4).Value()); expiryStr != "" {
+		if date, err := time.Parse("2006-01-02", expiryStr); err == nil {
+			expiry = &date
+		}
+	}
+
+	return leaveFormValues{
+		Year:            year,
+		DetailsID:       form.GetSelect(1).GetSelectedID(),
+		AllowanceDays:   allowance,
+		CarryOverDays:   carryOver,
+		CarryOverExpiry: expiry,
+	}
+}
+
+func NewLeaveEntitlementCreateModal(year int, workhourDetails []domain.WorkhourDetails) *LeaveEntitlementCreateModal {
+	form := newLeaveEntitlementForm(domain.LeaveEntitlement{Year: year}, workhourDetails)
+
+	return &LeaveEntitlementCreateModal{
+		Form: form,
+	}
+}
+
+func (m *LeaveEntitlementCreateModal) Update(msg tea.Msg) (LeaveEntitlementCreateModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+
+			values := parseLeaveEntitlementForm(m.Form)
+			return *m, tea.Batch(
+				dispatchCreatedMsg(values),
+			)
+
+		case "esc":
+			return *m, tea.Batch(
+				dispatchCreateCanceledMsg(),
+			)
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *LeaveEntitlementCreateModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	sb.WriteString(titleStyle.Render("Create Leave Entitlement"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Tab/Shift+Tab: navigate", "Enter: create", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchCreatedMsg(values leaveFormValues) tea.Cmd {
+	return func() tea.Msg {
+		return LeaveEntitlementCreatedMsg{
+			Year:            values.Year,
+			DetailsID:       values.DetailsID,
+			AllowanceDays:   values.AllowanceDays,
+			CarryOverDays:   values.CarryOverDays,
+			CarryOverExpiry: values.CarryOverExpiry,
+		}
+	}
+}
+
+func dispatchCreateCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return LeaveEntitlementCreateCanceledMsg{}
+	}
+}
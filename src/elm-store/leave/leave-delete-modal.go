@@ -0,0 +1,98 @@
+package leave
+
+import (
+	"fmt"
+	"strings"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type LeaveEntitlementDeleteModal struct {
+	EntitlementID int
+	Year          int
+	TypeName      string
+}
+
+type LeaveEntitlementDeletedMsg struct {
+	EntitlementID int
+}
+
+type LeaveEntitlementDeleteCanceledMsg struct{}
+
+func NewLeaveEntitlementDeleteModal(entitlementID, year int, typeName string) *LeaveEntitlementDeleteModal {
+	return &LeaveEntitlementDeleteModal{
+		EntitlementID: entitlementID,
+		Year:          year,
+		TypeName:      typeName,
+	}
+}
+
+func (m *LeaveEntitlementDeleteModal) Update(msg tea.Msg) (LeaveEntitlementDeleteModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y", "enter":
+			return *m, tea.Batch(
+				dispatchDeletedMsg(m.EntitlementID),
+			)
+
+		case "n", "N", "esc":
+			return *m, tea.Batch(
+				dispatchDeleteCanceledMsg(),
+			)
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *LeaveEntitlementDeleteModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("196")). // Red for delete
+		MarginBottom(1)
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("241"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")). // Orange warning
+		Bold(true)
+
+	sb.WriteString(titleStyle.Render("⚠ Delete Leave Entitlement"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(warningStyle.Render("Are you sure you want to delete this entitlement?"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Year: "))
+	sb.WriteString(fmt.Sprintf("%d", m.Year))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Type: "))
+	sb.WriteString(m.TypeName)
+	sb.WriteString("\n\n")
+
+	sb.WriteString(render.RenderHelpText("Y/Enter: confirm delete", "N/ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchDeletedMsg(entitlementID int) tea.Cmd {
+	return func() tea.Msg {
+		return LeaveEntitlementDeletedMsg{
+			EntitlementID: entitlementID,
+		}
+	}
+}
+
+func dispatchDeleteCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return LeaveEntitlementDeleteCanceledMsg{}
+	}
+}
@@ -0,0 +1,99 @@
+package leave
+
+import (
+	"strings"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type LeaveEntitlementEditModal struct {
+	EditingEntitlementID int
+	Form                 *common.MixedForm
+}
+
+type LeaveEntitlementEditedMsg struct {
+	EntitlementID   int
+	Year            int
+	DetailsID       int
+	AllowanceDays   float64
+	CarryOverDays   float64
+	CarryOverExpiry *time.Time
+}
+
+type LeaveEntitlementEditCanceledMsg struct{}
+
+func NewLeaveEntitlementEditModal(entitlement domain.LeaveEntitlement, workhourDetails []domain.WorkhourDetails) *LeaveEntitlementEditModal {
+	form := newLeaveEntitlementForm(entitlement, workhourDetails)
+
+	return &LeaveEntitlementEditModal{
+		EditingEntitlementID: entitlement.ID,
+		Form:                 form,
+	}
+}
+
+func (m *LeaveEntitlementEditModal) Update(msg tea.Msg) (LeaveEntitlementEditModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+
+			values := parseLeaveEntitlementForm(m.Form)
+			return *m, tea.Batch(
+				dispatchEditedMsg(m.EditingEntitlementID, values),
+			)
+
+		case "esc":
+			return *m, tea.Batch(
+				dispatchEditCanceledMsg(),
+			)
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *LeaveEntitlementEditModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	sb.WriteString(titleStyle.Render("Edit Leave Entitlement"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Tab/Shift+Tab: navigate", "Enter: save", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchEditedMsg(entitlementID int, values leaveFormValues) tea.Cmd {
+	return func() tea.Msg {
+		return LeaveEntitlementEditedMsg{
+			EntitlementID:   entitlementID,
+			Year:            values.Year,
+			DetailsID:       values.DetailsID,
+			AllowanceDays:   values.AllowanceDays,
+			CarryOverDays:   values.CarryOverDays,
+			CarryOverExpiry: values.CarryOverExpiry,
+		}
+	}
+}
+
+func dispatchEditCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return LeaveEntitlementEditCanceledMsg{}
+	}
+}
@@ -0,0 +1,91 @@
+package leave
+
+import (
+	"fmt"
+	"math"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m LeaveModel) handleEntitlementCreated(msg LeaveEntitlementCreatedMsg) (LeaveModel, tea.Cmd) {
+	newEntitlement := domain.LeaveEntitlement{
+		Year:            msg.Year,
+		DetailsID:       msg.DetailsID,
+		AllowanceDays:   msg.AllowanceDays,
+		CarryOverDays:   msg.CarryOverDays,
+		CarryOverExpiry: msg.CarryOverExpiry,
+	}
+
+	_, err := repository.CreateLeaveEntitlement(newEntitlement)
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to create leave entitlement", err)
+	}
+
+	m.reload()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+func (m LeaveModel) handleEntitlementEdited(msg LeaveEntitlementEditedMsg) (LeaveModel, tea.Cmd) {
+	updatedEntitlement := domain.LeaveEntitlement{
+		ID:              msg.EntitlementID,
+		Year:            msg.Year,
+		DetailsID:       msg.DetailsID,
+		AllowanceDays:   msg.AllowanceDays,
+		CarryOverDays:   msg.CarryOverDays,
+		CarryOverExpiry: msg.CarryOverExpiry,
+	}
+
+	err := repository.UpdateLeaveEntitlement(updatedEntitlement)
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to update leave entitlement", err)
+	}
+
+	m.reload()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+func (m LeaveModel) handleEntitlementDeleted(msg LeaveEntitlementDeletedMsg) (LeaveModel, tea.Cmd) {
+	err := repository.DeleteLeaveEntitlement(msg.EntitlementID)
+	if err != nil {
+		m.ActiveModal = nil
+		return m, common.NotifyError("Failed to delete leave entitlement", err)
+	}
+
+	m.reload()
+	m.ActiveModal = nil
+	return m, nil
+}
+
+func (m *LeaveModel) updateTableRows() {
+	rows := []table.Row{}
+	for _, b := range m.Balances {
+		expires := "-"
+		if b.Entitlement.CarryOverExpiry != nil {
+			expires = b.Entitlement.CarryOverExpiry.Format("2006-01-02")
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", b.Entitlement.Year),
+			m.detailsName(b.Entitlement.DetailsID),
+			formatDays(b.Entitlement.AllowanceDays),
+			formatDays(b.Entitlement.CarryOverDays),
+			expires,
+			formatDays(b.UsedDays),
+			formatDays(b.ExpiredDays),
+			formatDays(b.RemainingDays),
+		})
+	}
+	m.TableView.SetRows(rows)
+}
+
+// formatDays renders a day count rounded to two decimals, e.g. "12.5d"
+func formatDays(days float64) string {
+	return fmt.Sprintf("%gd", math.Round(days*100)/100)
+}
@@ -0,0 +1,51 @@
+package leave
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// LeaveModal represents any modal in the Leave view
+type LeaveModal interface {
+	Update(tea.Msg) (LeaveModal, tea.Cmd)
+	View(width, height int) string
+}
+
+// LeaveEntitlementCreateModalWrapper wraps LeaveEntitlementCreateModal to implement LeaveModal
+type LeaveEntitlementCreateModalWrapper struct {
+	*LeaveEntitlementCreateModal
+}
+
+func (w LeaveEntitlementCreateModalWrapper) Update(msg tea.Msg) (LeaveModal, tea.Cmd) {
+	_, cmd := w.LeaveEntitlementCreateModal.Update(msg)
+	return w, cmd
+}
+
+func (w LeaveEntitlementCreateModalWrapper) View(width, height int) string {
+	return w.LeaveEntitlementCreateModal.View(width, height)
+}
+
+// LeaveEntitlementEditModalWrapper wraps LeaveEntitlementEditModal to implement LeaveModal
+type LeaveEntitlementEditModalWrapper struct {
+	*LeaveEntitlementEditModal
+}
+
+func (w LeaveEntitlementEditModalWrapper) Update(msg tea.Msg) (LeaveModal, tea.Cmd) {
+	_, cmd := w.LeaveEntitlementEditModal.Update(msg)
+	return w, cmd
+}
+
+func (w LeaveEntitlementEditModalWrapper) View(width, height int) string {
+	return w.LeaveEntitlementEditModal.View(width, height)
+}
+
+// LeaveEntitlementDeleteModalWrapper wraps LeaveEntitlementDeleteModal to implement LeaveModal
+type LeaveEntitlementDeleteModalWrapper struct {
+	*LeaveEntitlementDeleteModal
+}
+
+func (w LeaveEntitlementDeleteModalWrapper) Update(msg tea.Msg) (LeaveModal, tea.Cmd) {
+	_, cmd := w.LeaveEntitlementDeleteModal.Update(msg)
+	return w, cmd
+}
+
+func (w LeaveEntitlementDeleteModalWrapper) View(width, height int) string {
+	return w.LeaveEntitlementDeleteModal.View(width, height)
+}
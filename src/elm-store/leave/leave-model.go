@@ -0,0 +1,207 @@
+package leave
+
+import (
+	"fmt"
+	"time"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type LeaveModel struct {
+	Width  int
+	Height int
+
+	ActiveModal LeaveModal
+
+	TableView       common.TableView
+	Balances        []domain.LeaveBalance
+	WorkhourDetails []domain.WorkhourDetails
+}
+
+func NewLeaveModel() LeaveModel {
+	m := LeaveModel{}
+
+	columns := []table.Column{
+		{Title: "Year", Width: 6},
+		{Title: "Type", Width: 22},
+		{Title: "Allowance", Width: 10},
+		{Title: "Carry-over", Width: 10},
+		{Title: "Expires", Width: 12},
+		{Title: "Used", Width: 8},
+		{Title: "Expired", Width: 8},
+		{Title: "Remaining", Width: 10},
+	}
+
+	m.TableView = common.NewTableView(columns, []table.Row{})
+	m.TableView.Table.SetHeight(100)
+
+	m.reload()
+
+	return m
+}
+
+func (m LeaveModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m LeaveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LeaveEntitlementCreatedMsg:
+		return m.handleEntitlementCreated(msg)
+
+	case LeaveEntitlementCreateCanceledMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case LeaveEntitlementEditedMsg:
+		return m.handleEntitlementEdited(msg)
+
+	case LeaveEntitlementEditCanceledMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case LeaveEntitlementDeletedMsg:
+		return m.handleEntitlementDeleted(msg)
+
+	case LeaveEntitlementDeleteCanceledMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+		verticalMargin := 14
+		m.TableView.SetSize(msg.Width, msg.Height, verticalMargin)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q":
+			// Only close delete modal on 'q' (edit/create modals have text inputs where user might type 'q')
+			if _, isDelete := m.ActiveModal.(LeaveEntitlementDeleteModalWrapper); isDelete {
+				m.ActiveModal = nil
+				return m, nil
+			}
+			if m.ActiveModal != nil {
+				break
+			}
+			return m, tea.Quit
+
+		case "n":
+			if m.ActiveModal == nil {
+				m.reload()
+				m.ActiveModal = LeaveEntitlementCreateModalWrapper{NewLeaveEntitlementCreateModal(
+					time.Now().Year(),
+					m.WorkhourDetails,
+				)}
+				return m, nil
+			}
+
+		case "d":
+			if m.ActiveModal == nil {
+				selected := m.getSelectedBalance()
+				if selected != nil {
+					m.ActiveModal = LeaveEntitlementDeleteModalWrapper{NewLeaveEntitlementDeleteModal(
+						selected.Entitlement.ID,
+						selected.Entitlement.Year,
+						m.detailsName(selected.Entitlement.DetailsID),
+					)}
+					return m, nil
+				}
+			}
+
+		case "enter":
+			if m.ActiveModal == nil {
+				selected := m.getSelectedBalance()
+				if selected != nil {
+					m.reload()
+					m.ActiveModal = LeaveEntitlementEditModalWrapper{NewLeaveEntitlementEditModal(
+						selected.Entitlement,
+						m.WorkhourDetails,
+					)}
+					return m, nil
+				}
+			}
+		}
+	}
+
+	if m.ActiveModal != nil {
+		_, cmd := m.ActiveModal.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.TableView, cmd = m.TableView.Update(msg)
+	return m, cmd
+}
+
+func (m LeaveModel) View() string {
+	helpText := render.RenderHelpText("↑/↓: navigate", "enter: edit", "n: new", "d: delete", "q: quit")
+
+	if m.ActiveModal != nil {
+		return m.ActiveModal.View(m.Width, m.Height)
+	}
+
+	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Italic(true)
+	info := infoStyle.Render(fmt.Sprintf(
+		"Days are counted against a %gh daily target, balances as of %s",
+		repository.GetDailyTargetHours(),
+		time.Now().Format("2006-01-02"),
+	))
+
+	return info + "\n" + m.TableView.View() + "\n" + helpText
+}
+
+// reload refreshes entitlements, balances and the available leave types
+func (m *LeaveModel) reload() {
+	details, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		details = []domain.WorkhourDetails{}
+	}
+	m.WorkhourDetails = details
+
+	entitlements, err := repository.GetAllLeaveEntitlements()
+	if err != nil {
+		entitlements = []domain.LeaveEntitlement{}
+	}
+
+	now := time.Now()
+	m.Balances = make([]domain.LeaveBalance, 0, len(entitlements))
+	for _, e := range entitlements {
+		balance, err := repository.GetLeaveBalance(e, now)
+		if err != nil {
+			balance = domain.LeaveBalance{Entitlement: e}
+		}
+		m.Balances = append(m.Balances, balance)
+	}
+
+	m.updateTableRows()
+}
+
+func (m LeaveModel) detailsName(detailsID int) string {
+	for _, d := range m.WorkhourDetails {
+		if d.ID == detailsID {
+			return fmt.Sprintf("%s %s", d.ShortName, d.Name)
+		}
+	}
+	return "Unknown"
+}
+
+func (m LeaveModel) getSelectedBalance() *domain.LeaveBalance {
+	cursor := m.TableView.Cursor()
+	if cursor >= 0 && cursor < len(m.Balances) {
+		return &m.Balances[cursor]
+	}
+	return nil
+}
+
+// Refresh reloads balances, since workhours logged in the calendar change them
+func (m *LeaveModel) Refresh() {
+	m.reload()
+}
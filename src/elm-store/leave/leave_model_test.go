@@ -0,0 +1,131 @@
+package leave
+
+import (
+	"testing"
+	"time"
+	"tltui/src/domain/repository"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLeaveModel_HandleEntitlementCreated(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	leaveType := repository.CreateTestWorkhourDetails(t, 3, "Leave", "L", false)
+
+	m := NewLeaveModel()
+
+	msg := LeaveEntitlementCreatedMsg{
+		Year:          2025,
+		DetailsID:     leaveType.ID,
+		AllowanceDays: 21,
+		CarryOverDays: 2,
+	}
+
+	updatedModel, _ := m.handleEntitlementCreated(msg)
+
+	if updatedModel.ActiveModal != nil {
+		t.Error("expected modal to be closed after creation")
+	}
+
+	if len(updatedModel.Balances) != 1 {
+		t.Fatalf("got %d balances, want 1", len(updatedModel.Balances))
+	}
+
+	if got := updatedModel.Balances[0].RemainingDays; got != 23 {
+		t.Errorf("got remaining %v, want 23", got)
+	}
+}
+
+func TestLeaveModel_BalanceCountsLoggedLeave(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	leaveType := repository.CreateTestWorkhourDetails(t, 3, "Leave", "L", false)
+	project := repository.CreateTestProject(t, 1, "Test Project", 100)
+	repository.CreateTestLeaveEntitlement(t, 2025, leaveType.ID, 21, 0, nil)
+	repository.CreateTestWorkhour(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local), leaveType.ID, project.ID, 8)
+	repository.CreateTestWorkhour(t, time.Date(2025, 7, 2, 0, 0, 0, 0, time.Local), leaveType.ID, project.ID, 4)
+
+	m := NewLeaveModel()
+
+	if len(m.Balances) != 1 {
+		t.Fatalf("got %d balances, want 1", len(m.Balances))
+	}
+	if got := m.Balances[0].UsedDays; got != 1.5 {
+		t.Errorf("got used %v, want 1.5", got)
+	}
+	if got := m.Balances[0].RemainingDays; got != 19.5 {
+		t.Errorf("got remaining %v, want 19.5", got)
+	}
+}
+
+func TestLeaveModel_HandleEntitlementEdited(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	leaveType := repository.CreateTestWorkhourDetails(t, 3, "Leave", "L", false)
+	entitlement := repository.CreateTestLeaveEntitlement(t, 2025, leaveType.ID, 21, 0, nil)
+
+	m := NewLeaveModel()
+
+	expiry := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	msg := LeaveEntitlementEditedMsg{
+		EntitlementID:   entitlement.ID,
+		Year:            2025,
+		DetailsID:       leaveType.ID,
+		AllowanceDays:   25,
+		CarryOverDays:   3,
+		CarryOverExpiry: &expiry,
+	}
+
+	updatedModel, _ := m.handleEntitlementEdited(msg)
+
+	if updatedModel.ActiveModal != nil {
+		t.Error("expected modal to be closed after edit")
+	}
+
+	stored, err := repository.GetLeaveEntitlement(2025, leaveType.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("expected stored entitlement, got %v (err %v)", stored, err)
+	}
+	if stored.AllowanceDays != 25 || stored.CarryOverDays != 3 {
+		t.Errorf("got allowance %v carry-over %v, want 25 and 3", stored.AllowanceDays, stored.CarryOverDays)
+	}
+	if stored.CarryOverExpiry == nil || !stored.CarryOverExpiry.Equal(expiry) {
+		t.Errorf("got expiry %v, want %v", stored.CarryOverExpiry, expiry)
+	}
+}
+
+func TestLeaveModel_HandleEntitlementDeleted(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	leaveType := repository.CreateTestWorkhourDetails(t, 3, "Leave", "L", false)
+	entitlement := repository.CreateTestLeaveEntitlement(t, 2025, leaveType.ID, 21, 0, nil)
+
+	m := NewLeaveModel()
+
+	updatedModel, _ := m.handleEntitlementDeleted(LeaveEntitlementDeletedMsg{EntitlementID: entitlement.ID})
+
+	if len(updatedModel.Balances) != 0 {
+		t.Errorf("expected 0 balances after delete, got %d", len(updatedModel.Balances))
+	}
+}
+
+func TestLeaveModel_Update_OpenCreateModal(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	m := NewLeaveModel()
+
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}
+
+	updatedModel, _ := m.Update(msg)
+	lm := updatedModel.(LeaveModel)
+
+	if _, ok := lm.ActiveModal.(LeaveEntitlementCreateModalWrapper); !ok {
+		t.Error("expected LeaveEntitlementCreateModalWrapper")
+	}
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"strings"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProfileSwitcher lists the profiles to switch the app to another one
+type ProfileSwitcher struct {
+	Profiles      []string
+	SelectedIndex int
+	ErrorMessage  string
+}
+
+type ProfileSelectedMsg struct {
+	Profile string
+}
+
+type ProfileSwitcherClosedMsg struct{}
+
+func NewProfileSwitcher() *ProfileSwitcher {
+	s := &ProfileSwitcher{}
+	profiles, err := repository.ListProfiles()
+	if err != nil {
+		s.ErrorMessage = err.Error()
+		return s
+	}
+
+	s.Profiles = profiles
+	for i, name := range profiles {
+		if name == repository.CurrentProfile() {
+			s.SelectedIndex = i
+		}
+	}
+	return s
+}
+
+func (s *ProfileSwitcher) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q", "P":
+			return dispatchProfileSwitcherClosedMsg()
+		case "up", "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case "down", "j":
+			if s.SelectedIndex < len(s.Profiles)-1 {
+				s.SelectedIndex++
+			}
+		case "enter":
+			if len(s.Profiles) == 0 {
+				return nil
+			}
+			return dispatchProfileSelectedMsg(s.Profiles[s.SelectedIndex])
+		}
+	}
+	return nil
+}
+
+func (s *ProfileSwitcher) View(width, height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).MarginBottom(1)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+
+	sb.WriteString(titleStyle.Render("Switch Profile"))
+	sb.WriteString("\n\n")
+
+	if s.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + s.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	for i, name := range s.Profiles {
+		line := "  " + name
+		if i == s.SelectedIndex {
+			line = selectedStyle.Render("▶ " + name)
+		}
+		sb.WriteString(line)
+		if name == repository.CurrentProfile() {
+			sb.WriteString(activeStyle.Render(" (open)"))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Create, rename or delete profiles with 'tltui profile'."))
+	sb.WriteString("\n\n")
+	sb.WriteString(render.RenderHelpText("↑/↓: select", "enter: switch", "esc: close"))
+
+	return render.RenderSimpleModal(width, height, sb.String())
+}
+
+func dispatchProfileSelectedMsg(profile string) tea.Cmd {
+	return func() tea.Msg {
+		return ProfileSelectedMsg{Profile: profile}
+	}
+}
+
+func dispatchProfileSwitcherClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return ProfileSwitcherClosedMsg{}
+	}
+}
@@ -0,0 +1,206 @@
+package projects
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	burnDownBarWidth = 30
+	burnDownMaxRows  = 31 // Longer ranges are shown one row per week
+)
+
+// BudgetBurnDownModal charts the remaining budget of a project day by day
+type BudgetBurnDownModal struct {
+	Project      domain.Project
+	Date         time.Time // Any day of the period being shown
+	Status       *domain.BudgetStatus
+	Points       []domain.BurnDownPoint
+	ErrorMessage string
+}
+
+type BudgetBurnDownClosedMsg struct{}
+
+func NewBudgetBurnDownModal(project domain.Project, date time.Time) *BudgetBurnDownModal {
+	m := &BudgetBurnDownModal{
+		Project: project,
+		Date:    date,
+	}
+	m.Reload()
+	return m
+}
+
+// Reload recomputes the burn-down of the period containing Date
+func (m *BudgetBurnDownModal) Reload() {
+	m.ErrorMessage = ""
+
+	status, err := repository.GetProjectBudgetStatus(m.Project, m.Date)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+	}
+	m.Status = status
+
+	points, err := repository.GetBudgetBurnDown(m.Project, m.Date)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		points = nil
+	}
+	m.Points = points
+}
+
+func (m *BudgetBurnDownModal) Update(msg tea.Msg) (BudgetBurnDownModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "b":
+			return *m, dispatchBudgetBurnDownClosedMsg()
+
+		case "<", "left", "h":
+			m.shiftPeriod(-1)
+		case ">", "right", "l":
+			m.shiftPeriod(1)
+		}
+	}
+
+	return *m, nil
+}
+
+// shiftPeriod moves to the previous or next period; total budgets have a single period
+func (m *BudgetBurnDownModal) shiftPeriod(direction int) {
+	switch m.Project.BudgetPeriod {
+	case domain.BudgetPeriodMonthly:
+		m.Date = time.Date(m.Date.Year(), m.Date.Month()+time.Month(direction), 1, 0, 0, 0, 0, m.Date.Location())
+	case domain.BudgetPeriodQuarterly:
+		m.Date = time.Date(m.Date.Year(), m.Date.Month()+time.Month(3*direction), 1, 0, 0, 0, 0, m.Date.Location())
+	default:
+		return
+	}
+	m.Reload()
+}
+
+func (m *BudgetBurnDownModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("241"))
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("255"))
+
+	emptyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Italic(true)
+
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	overStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+
+	sb.WriteString(titleStyle.Render("Budget Burn-down - " + m.Project.Name))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	if m.Status == nil {
+		sb.WriteString(emptyStyle.Render("This project has no budget"))
+		sb.WriteString("\n\n")
+		sb.WriteString(render.RenderHelpText("ESC: close"))
+		return render.RenderSimpleModal(Width, Height, sb.String())
+	}
+
+	sb.WriteString(valueStyle.Render(fmt.Sprintf("Period: %s   Budget: %gh   Used: %gh (%d%%)   Remaining: ",
+		m.Status.PeriodLabel(),
+		m.Project.BudgetHours,
+		roundBudgetHours(m.Status.ConsumedHours),
+		int(math.Round(m.Status.Ratio()*100)))))
+	remainingStyle := barStyle
+	if m.Status.RemainingHours() < 0 {
+		remainingStyle = overStyle
+	}
+	sb.WriteString(remainingStyle.Bold(true).Render(fmt.Sprintf("%gh", roundBudgetHours(m.Status.RemainingHours()))))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("%-10s %9s %9s %9s  %s", "Date", "Used", "Remaining", "Ideal", "Remaining budget")))
+	sb.WriteString("\n")
+
+	for _, point := range sampleBurnDown(m.Points) {
+		sb.WriteString(valueStyle.Render(fmt.Sprintf("%-10s %8gh %8gh %8gh  ",
+			point.Date.Format("2006-01-02"),
+			roundBudgetHours(point.ConsumedHours),
+			roundBudgetHours(point.RemainingHours),
+			roundBudgetHours(point.IdealRemaining))))
+		if point.RemainingHours < 0 {
+			sb.WriteString(overStyle.Render("over budget"))
+		} else {
+			sb.WriteString(barStyle.Render(burnDownBar(point, m.Project.BudgetHours)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	if m.Project.BudgetPeriod == domain.BudgetPeriodTotal {
+		sb.WriteString(render.RenderHelpText("ESC: close"))
+	} else {
+		sb.WriteString(render.RenderHelpText("</>: period", "ESC: close"))
+	}
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+// burnDownBar draws the remaining hours as a bar, with the ideal burn marked by a tick
+func burnDownBar(point domain.BurnDownPoint, budgetHours float64) string {
+	if budgetHours <= 0 {
+		return ""
+	}
+
+	filled := int(math.Round(point.RemainingHours / budgetHours * burnDownBarWidth))
+	ideal := int(math.Round(point.IdealRemaining / budgetHours * burnDownBarWidth))
+
+	bar := []rune(strings.Repeat("█", min(filled, burnDownBarWidth)) + strings.Repeat(" ", burnDownBarWidth-min(filled, burnDownBarWidth)))
+	if ideal > 0 && ideal <= burnDownBarWidth {
+		bar[ideal-1] = '│'
+	}
+	return string(bar)
+}
+
+// sampleBurnDown keeps one point per week when the range is too long to list daily
+func sampleBurnDown(points []domain.BurnDownPoint) []domain.BurnDownPoint {
+	if len(points) <= burnDownMaxRows {
+		return points
+	}
+
+	var sampled []domain.BurnDownPoint
+	for i := 0; i < len(points); i += 7 {
+		sampled = append(sampled, points[i])
+	}
+	if last := points[len(points)-1]; !sampled[len(sampled)-1].Date.Equal(last.Date) {
+		sampled = append(sampled, last)
+	}
+	return sampled
+}
+
+func roundBudgetHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+func dispatchBudgetBurnDownClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return BudgetBurnDownClosedMsg{}
+	}
+}
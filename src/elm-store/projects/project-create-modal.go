@@ -4,6 +4,8 @@ import (
 	"strconv"
 	"strings"
 	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,13 +17,16 @@ type ProjectCreateModal struct {
 }
 
 type ProjectCreatedMsg struct {
-	Name   string
-	OdooID int
+	Name         string
+	OdooID       int
+	ClientID     int
+	BudgetHours  float64
+	BudgetPeriod domain.BudgetPeriod
 }
 
 type ProjectCreateCanceledMsg struct{}
 
-func NewProjectCreateModal() *ProjectCreateModal {
+func NewProjectCreateModal(clients []domain.Client) *ProjectCreateModal {
 	nameField := common.NewRequiredFormField("Name", "Project Name", 40).
 		WithValidator(common.ChainValidators(
 			common.MinLengthValidator("Name", 2),
@@ -32,7 +37,10 @@ func NewProjectCreateModal() *ProjectCreateModal {
 		WithCharLimit(10).
 		WithValidator(common.PositiveIntValidator("Odoo ID"))
 
-	form := common.NewMixedForm(&nameField, &odooIDField)
+	clientSelect := newClientSelect(clients, 0)
+	budgetField, budgetPeriodSelect := newBudgetInputs(0, domain.BudgetPeriodTotal)
+
+	form := common.NewMixedForm(&nameField, &odooIDField, clientSelect, budgetField, budgetPeriodSelect)
 
 	return &ProjectCreateModal{
 		Form: form,
@@ -51,9 +59,17 @@ func (m *ProjectCreateModal) Update(msg tea.Msg) (ProjectCreateModal, tea.Cmd) {
 			name := strings.TrimSpace(m.Form.GetField(0).Value())
 			odooIDStr := strings.TrimSpace(m.Form.GetField(1).Value())
 			odooID, _ := strconv.Atoi(odooIDStr) // Already validated
+			clientID := max(m.Form.GetSearchSelect(2).GetSelectedID(), 0)
+			budgetHours, budgetPeriod := parseBudgetInputs(m.Form)
 
 			return *m, tea.Batch(
-				dispatchCreatedMsg(name, odooID),
+				dispatchCreatedMsg(ProjectCreatedMsg{
+					Name:         name,
+					OdooID:       odooID,
+					ClientID:     clientID,
+					BudgetHours:  budgetHours,
+					BudgetPeriod: budgetPeriod,
+				}),
 			)
 
 		case "esc":
@@ -85,12 +101,9 @@ func (m *ProjectCreateModal) View(Width, Height int) string {
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
-func dispatchCreatedMsg(name string, odooID int) tea.Cmd {
+func dispatchCreatedMsg(msg ProjectCreatedMsg) tea.Cmd {
 	return func() tea.Msg {
-		return ProjectCreatedMsg{
-			Name:   name,
-			OdooID: odooID,
-		}
+		return msg
 	}
 }
 
@@ -99,3 +112,60 @@ func dispatchCreateCanceledMsg() tea.Cmd {
 		return ProjectCreateCanceledMsg{}
 	}
 }
+
+// newClientSelect builds the optional client select shared by the project modals,
+// listing the most recently used clients first
+func newClientSelect(clients []domain.Client, selectedClientID int) *common.FormSearchSelect {
+	options := []common.SelectOption{{ID: 0, DisplayName: "No client"}}
+	for _, c := range clients {
+		options = append(options, common.SelectOption{
+			ID:          c.ID,
+			DisplayName: c.Name,
+			ExtraInfo:   c.LegalName,
+		})
+	}
+
+	recentIDs, _ := repository.GetRecentSelections(repository.SelectionKindClient)
+	clientSelect := common.NewFormSearchSelect("Client", options).WithRecent(recentIDs)
+	clientSelect.SelectByID(selectedClientID)
+	return clientSelect
+}
+
+var budgetPeriods = []domain.BudgetPeriod{
+	domain.BudgetPeriodTotal,
+	domain.BudgetPeriodMonthly,
+	domain.BudgetPeriodQuarterly,
+}
+
+// newBudgetInputs builds the budget hours field and period select shared by the project modals
+func newBudgetInputs(budgetHours float64, period domain.BudgetPeriod) (*common.FormField, *common.FormSelect) {
+	initialValue := ""
+	if budgetHours > 0 {
+		initialValue = strconv.FormatFloat(budgetHours, 'f', -1, 64)
+	}
+
+	budgetField := common.NewFormField("Budget Hours", "Leave empty for no budget", 40).
+		WithInitialValue(initialValue).
+		WithCharLimit(10).
+		WithValidator(common.OptionalValidator(common.NonNegativeFloatValidator("Budget Hours")))
+
+	options := make([]common.SelectOption, 0, len(budgetPeriods))
+	selectedIndex := 0
+	for i, p := range budgetPeriods {
+		if p == period {
+			selectedIndex = i
+		}
+		options = append(options, common.SelectOption{ID: int(p), DisplayName: p.String()})
+	}
+
+	periodSelect := common.NewRequiredFormSelect("Budget Period", options)
+	periodSelect.SelectedIndex = selectedIndex
+	return &budgetField, periodSelect
+}
+
+// parseBudgetInputs reads the validated budget inputs at form indexes 3 and 4
+func parseBudgetInputs(form *common.MixedForm) (float64, domain.BudgetPeriod) {
+	budgetHours, _ := strconv.ParseFloat(strings.TrimSpace(form.GetField(3).Value()), 64)
+	period := domain.BudgetPeriod(max(form.GetSelect(4).GetSelectedID(), 0))
+	return budgetHours, period
+}
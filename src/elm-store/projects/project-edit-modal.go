@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"strings"
 	"tltui/src/common"
+	"tltui/src/domain"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,16 +17,19 @@ type ProjectEditModal struct {
 }
 
 type ProjectEditedMsg struct {
-	ProjectID int
-	Name      string
-	OdooID    int
+	ProjectID    int
+	Name         string
+	OdooID       int
+	ClientID     int
+	BudgetHours  float64
+	BudgetPeriod domain.BudgetPeriod
 }
 
 type ProjectEditCanceledMsg struct{}
 
-func NewProjectEditModal(projectID int, name string, odooID int) *ProjectEditModal {
+func NewProjectEditModal(project domain.Project, clients []domain.Client) *ProjectEditModal {
 	nameField := common.NewRequiredFormField("Name", "Project Name", 40).
-		WithInitialValue(name).
+		WithInitialValue(project.Name).
 		WithValidator(common.ChainValidators(
 			common.MinLengthValidator("Name", 2),
 			common.MaxLengthValidator("Name", 50),
@@ -34,12 +38,15 @@ func NewProjectEditModal(projectID int, name string, odooID int) *ProjectEditMod
 	odooIDField := common.NewRequiredFormField("Odoo ID", "Odoo ID", 40).
 		WithCharLimit(10).
 		WithValidator(common.PositiveIntValidator("Odoo ID")).
-		WithInitialValue(strconv.Itoa(odooID))
+		WithInitialValue(strconv.Itoa(project.OdooID))
 
-	form := common.NewMixedForm(&nameField, &odooIDField)
+	clientSelect := newClientSelect(clients, project.ClientID)
+	budgetField, budgetPeriodSelect := newBudgetInputs(project.BudgetHours, project.BudgetPeriod)
+
+	form := common.NewMixedForm(&nameField, &odooIDField, clientSelect, budgetField, budgetPeriodSelect)
 
 	return &ProjectEditModal{
-		EditingProjectID: projectID,
+		EditingProjectID: project.ID,
 		Form:             form,
 	}
 }
@@ -55,10 +62,19 @@ func (m *ProjectEditModal) Update(msg tea.Msg) (ProjectEditModal, tea.Cmd) {
 
 			name := strings.TrimSpace(m.Form.GetField(0).Value())
 			odooIDStr := strings.TrimSpace(m.Form.GetField(1).Value())
-			odooID, _ := strconv.Atoi(odooIDStr) 
+			odooID, _ := strconv.Atoi(odooIDStr)
+			clientID := max(m.Form.GetSearchSelect(2).GetSelectedID(), 0)
+			budgetHours, budgetPeriod := parseBudgetInputs(m.Form)
 
 			return *m, tea.Batch(
-				dispatchEditedMsg(m.EditingProjectID, name, odooID),
+				dispatchEditedMsg(ProjectEditedMsg{
+					ProjectID:    m.EditingProjectID,
+					Name:         name,
+					OdooID:       odooID,
+					ClientID:     clientID,
+					BudgetHours:  budgetHours,
+					BudgetPeriod: budgetPeriod,
+				}),
 			)
 
 		case "esc":
@@ -90,13 +106,9 @@ func (m *ProjectEditModal) View(Width, Height int) string {
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
-func dispatchEditedMsg(projectID int, name string, odooID int) tea.Cmd {
+func dispatchEditedMsg(msg ProjectEditedMsg) tea.Cmd {
 	return func() tea.Msg {
-		return ProjectEditedMsg{
-			ProjectID: projectID,
-			Name:      name,
-			OdooID:    odooID,
-		}
+		return msg
 	}
 }
 
@@ -2,6 +2,8 @@ package projects
 
 import (
 	"fmt"
+	"math"
+	"time"
 	"tltui/src/common"
 	"tltui/src/domain"
 	"tltui/src/domain/repository"
@@ -12,9 +14,12 @@ import (
 
 func (m ProjectsModel) handleProjectCreated(msg ProjectCreatedMsg) (ProjectsModel, tea.Cmd) {
 	newProject := domain.Project{
-		ID:     m.NextID,
-		Name:   msg.Name,
-		OdooID: msg.OdooID,
+		ID:           m.NextID,
+		Name:         msg.Name,
+		OdooID:       msg.OdooID,
+		ClientID:     msg.ClientID,
+		BudgetHours:  msg.BudgetHours,
+		BudgetPeriod: msg.BudgetPeriod,
 	}
 
 	err := repository.CreateProject(newProject)
@@ -22,6 +27,7 @@ func (m ProjectsModel) handleProjectCreated(msg ProjectCreatedMsg) (ProjectsMode
 		m.ActiveModal = nil
 		return m, common.NotifyError("Failed to create project", err)
 	}
+	recordClientSelection(msg.ClientID)
 
 	projects, err := repository.GetAllProjectsFromDB()
 	if err != nil {
@@ -38,15 +44,19 @@ func (m ProjectsModel) handleProjectCreated(msg ProjectCreatedMsg) (ProjectsMode
 
 func (m ProjectsModel) handleProjectEdited(msg ProjectEditedMsg) (ProjectsModel, tea.Cmd) {
 	updatedProject := domain.Project{
-		ID:     msg.ProjectID,
-		Name:   msg.Name,
-		OdooID: msg.OdooID,
+		ID:           msg.ProjectID,
+		Name:         msg.Name,
+		OdooID:       msg.OdooID,
+		ClientID:     msg.ClientID,
+		BudgetHours:  msg.BudgetHours,
+		BudgetPeriod: msg.BudgetPeriod,
 	}
 	err := repository.UpdateProject(updatedProject)
 	if err != nil {
 		m.ActiveModal = nil
 		return m, common.NotifyError("Failed to update project", err)
 	}
+	recordClientSelection(msg.ClientID)
 
 	projects, err := repository.GetAllProjectsFromDB()
 	if err != nil {
@@ -79,14 +89,69 @@ func (m ProjectsModel) handleProjectDeleted(msg ProjectDeletedMsg) (ProjectsMode
 	return m, nil
 }
 
+// recordClientSelection remembers a chosen client so the client select offers it first.
+// Ordering is best-effort, so errors are ignored.
+func recordClientSelection(clientID int) {
+	if clientID > 0 {
+		_ = repository.RecordSelection(repository.SelectionKindClient, clientID)
+	}
+}
+
 func (m *ProjectsModel) updateTableRows() {
 	rows := []table.Row{}
 	for _, p := range m.Projects {
+		budget, used, remaining := budgetColumns(p)
 		rows = append(rows, table.Row{
 			fmt.Sprintf("%d", p.ID),
 			p.Name,
 			fmt.Sprintf("%d", p.OdooID),
+			m.clientName(p.ClientID),
+			budget,
+			used,
+			remaining,
 		})
 	}
 	m.TableView.SetRows(rows)
 }
+
+// budgetColumns formats the budget of a project for its current period
+func budgetColumns(project domain.Project) (string, string, string) {
+	status, err := repository.GetProjectBudgetStatus(project, time.Now())
+	if err != nil || status == nil {
+		return "", "", ""
+	}
+
+	budget := fmt.Sprintf("%gh", project.BudgetHours)
+	switch project.BudgetPeriod {
+	case domain.BudgetPeriodMonthly:
+		budget += "/mo"
+	case domain.BudgetPeriodQuarterly:
+		budget += "/qtr"
+	}
+
+	return budget,
+		fmt.Sprintf("%gh (%d%%)", math.Round(status.ConsumedHours*100)/100, int(math.Round(status.Ratio()*100))),
+		fmt.Sprintf("%gh", math.Round(status.RemainingHours()*100)/100)
+}
+
+func (m ProjectsModel) clientName(clientID int) string {
+	for _, c := range m.Clients {
+		if c.ID == clientID {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+func (m ProjectsModel) handleOpenBudgetBurnDown() (ProjectsModel, tea.Cmd) {
+	selectedProject := m.getSelectedProject()
+	if selectedProject == nil {
+		return m, nil
+	}
+	if !selectedProject.HasBudget() {
+		return m, common.NotifyInfo(selectedProject.Name + " has no budget")
+	}
+
+	m.ActiveModal = BudgetBurnDownModalWrapper{NewBudgetBurnDownModal(*selectedProject, time.Now())}
+	return m, nil
+}
@@ -49,3 +49,73 @@ func (w ProjectDeleteModalWrapper) Update(msg tea.Msg) (ProjectModal, tea.Cmd) {
 func (w ProjectDeleteModalWrapper) View(width, height int) string {
 	return w.ProjectDeleteModal.View(width, height)
 }
+
+// TaskListModalWrapper wraps TaskListModal to implement ProjectModal
+type TaskListModalWrapper struct {
+	*TaskListModal
+}
+
+func (w TaskListModalWrapper) Update(msg tea.Msg) (ProjectModal, tea.Cmd) {
+	_, cmd := w.TaskListModal.Update(msg)
+	return w, cmd
+}
+
+func (w TaskListModalWrapper) View(width, height int) string {
+	return w.TaskListModal.View(width, height)
+}
+
+// TaskCreateModalWrapper wraps TaskCreateModal to implement ProjectModal
+type TaskCreateModalWrapper struct {
+	*TaskCreateModal
+}
+
+func (w TaskCreateModalWrapper) Update(msg tea.Msg) (ProjectModal, tea.Cmd) {
+	_, cmd := w.TaskCreateModal.Update(msg)
+	return w, cmd
+}
+
+func (w TaskCreateModalWrapper) View(width, height int) string {
+	return w.TaskCreateModal.View(width, height)
+}
+
+// TaskEditModalWrapper wraps TaskEditModal to implement ProjectModal
+type TaskEditModalWrapper struct {
+	*TaskEditModal
+}
+
+func (w TaskEditModalWrapper) Update(msg tea.Msg) (ProjectModal, tea.Cmd) {
+	_, cmd := w.TaskEditModal.Update(msg)
+	return w, cmd
+}
+
+func (w TaskEditModalWrapper) View(width, height int) string {
+	return w.TaskEditModal.View(width, height)
+}
+
+// TaskDeleteModalWrapper wraps TaskDeleteModal to implement ProjectModal
+type TaskDeleteModalWrapper struct {
+	*TaskDeleteModal
+}
+
+func (w TaskDeleteModalWrapper) Update(msg tea.Msg) (ProjectModal, tea.Cmd) {
+	_, cmd := w.TaskDeleteModal.Update(msg)
+	return w, cmd
+}
+
+func (w TaskDeleteModalWrapper) View(width, height int) string {
+	return w.TaskDeleteModal.View(width, height)
+}
+
+// BudgetBurnDownModalWrapper wraps BudgetBurnDownModal to implement ProjectModal
+type BudgetBurnDownModalWrapper struct {
+	*BudgetBurnDownModal
+}
+
+func (w BudgetBurnDownModalWrapper) Update(msg tea.Msg) (ProjectModal, tea.Cmd) {
+	_, cmd := w.BudgetBurnDownModal.Update(msg)
+	return w, cmd
+}
+
+func (w BudgetBurnDownModalWrapper) View(width, height int) string {
+	return w.BudgetBurnDownModal.View(width, height)
+}
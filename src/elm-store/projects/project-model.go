@@ -1,7 +1,6 @@
 package projects
 
 import (
-	"fmt"
 	"tltui/src/common"
 	"tltui/src/domain"
 	"tltui/src/domain/repository"
@@ -15,10 +14,12 @@ type ProjectsModel struct {
 	Width  int
 	Height int
 
-	ActiveModal ProjectModal
+	ActiveModal    ProjectModal
+	TaskListParent *TaskListModal // Task list to return to after a task modal closes
 
 	TableView common.TableView
 	Projects  []domain.Project
+	Clients   []domain.Client
 	NextID    int
 }
 
@@ -31,6 +32,12 @@ func NewProjectsModel() ProjectsModel {
 	}
 	m.Projects = projects
 
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		clients = []domain.Client{}
+	}
+	m.Clients = clients
+
 	m.NextID = 1
 	for _, p := range m.Projects {
 		if p.ID >= m.NextID {
@@ -42,19 +49,15 @@ func NewProjectsModel() ProjectsModel {
 		{Title: "ID", Width: 6},
 		{Title: "Project Name", Width: 30},
 		{Title: "Odoo ID", Width: 10},
+		{Title: "Client", Width: 20},
+		{Title: "Budget", Width: 10},
+		{Title: "Used", Width: 14},
+		{Title: "Remaining", Width: 10},
 	}
 
-	rows := []table.Row{}
-	for _, p := range m.Projects {
-		rows = append(rows, table.Row{
-			fmt.Sprintf("%d", p.ID),
-			p.Name,
-			fmt.Sprintf("%d", p.OdooID),
-		})
-	}
-
-	m.TableView = common.NewTableView(columns, rows)
+	m.TableView = common.NewTableView(columns, []table.Row{})
 	m.TableView.Table.SetHeight(100)
+	m.updateTableRows()
 
 	return m
 }
@@ -86,6 +89,36 @@ func (m ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ActiveModal = nil
 		return m, nil
 
+	case TaskListClosedMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case BudgetBurnDownClosedMsg:
+		m.ActiveModal = nil
+		return m, nil
+
+	case TaskCreateRequestedMsg:
+		return m.handleTaskCreateRequested(msg)
+
+	case TaskEditRequestedMsg:
+		return m.handleTaskEditRequested(msg)
+
+	case TaskDeleteRequestedMsg:
+		return m.handleTaskDeleteRequested(msg)
+
+	case TaskCreatedMsg:
+		return m.handleTaskCreated(msg)
+
+	case TaskEditedMsg:
+		return m.handleTaskEdited(msg)
+
+	case TaskDeletedMsg:
+		return m.handleTaskDeleted(msg)
+
+	case TaskCreateCanceledMsg, TaskEditCanceledMsg, TaskDeleteCanceledMsg:
+		m.restoreTaskList()
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.Width = msg.Width
 		m.Height = msg.Height
@@ -110,7 +143,7 @@ func (m ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "n":
 			if m.ActiveModal == nil {
-				m.ActiveModal = ProjectCreateModalWrapper{NewProjectCreateModal()}
+				m.ActiveModal = ProjectCreateModalWrapper{NewProjectCreateModal(m.Clients)}
 				return m, nil
 			}
 
@@ -126,15 +159,21 @@ func (m ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case "t":
+			if m.ActiveModal == nil {
+				return m.handleOpenTaskList()
+			}
+
+		case "b":
+			if m.ActiveModal == nil {
+				return m.handleOpenBudgetBurnDown()
+			}
+
 		case "enter":
 			if m.ActiveModal == nil {
 				selectedProject := m.getSelectedProject()
 				if selectedProject != nil {
-					m.ActiveModal = ProjectEditModalWrapper{NewProjectEditModal(
-						selectedProject.ID,
-						selectedProject.Name,
-						selectedProject.OdooID,
-					)}
+					m.ActiveModal = ProjectEditModalWrapper{NewProjectEditModal(*selectedProject, m.Clients)}
 					return m, nil
 				}
 			}
@@ -152,7 +191,7 @@ func (m ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m ProjectsModel) View() string {
-	helpText := render.RenderHelpText("↑/↓: navigate", "enter: edit", "n: new", "d: delete", "q: quit")
+	helpText := render.RenderHelpText("↑/↓: navigate", "enter: edit", "n: new", "d: delete", "t: tasks", "b: budget", "q: quit")
 
 	if m.ActiveModal != nil {
 		return m.ActiveModal.View(m.Width, m.Height)
@@ -161,6 +200,23 @@ func (m ProjectsModel) View() string {
 	return m.TableView.View() + "\n" + helpText
 }
 
+// Refresh reloads the clients, which are managed in their own tab, and the budget consumption.
+// The projects are reloaded too, as other processes may have changed them.
+func (m *ProjectsModel) Refresh() {
+	clients, err := repository.GetAllClients()
+	if err != nil {
+		return
+	}
+	m.Clients = clients
+	if projects, err := repository.GetAllProjectsFromDB(); err == nil {
+		m.Projects = projects
+		for _, p := range m.Projects {
+			m.NextID = max(m.NextID, p.ID+1)
+		}
+	}
+	m.updateTableRows()
+}
+
 func (m ProjectsModel) getSelectedProject() *domain.Project {
 	cursor := m.TableView.Cursor()
 	if cursor >= 0 && cursor < len(m.Projects) {
@@ -2,6 +2,8 @@ package projects
 
 import (
 	"testing"
+	"time"
+	"tltui/src/domain"
 	"tltui/src/domain/repository"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,10 +14,10 @@ func TestProjectsModel_HandleProjectCreated(t *testing.T) {
 	defer cleanup()
 
 	tests := []struct {
-		name              string
-		msg               ProjectCreatedMsg
-		wantProjectCount  int
-		wantModalClosed   bool
+		name             string
+		msg              ProjectCreatedMsg
+		wantProjectCount int
+		wantModalClosed  bool
 	}{
 		{
 			name:             "creates project successfully",
@@ -208,3 +210,198 @@ func TestProjectsModel_GetSelectedProject(t *testing.T) {
 		t.Errorf("got name %q, want %q", selected.Name, "Project 1")
 	}
 }
+
+func TestProjectsModel_TaskLifecycle(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+
+	m := NewProjectsModel()
+
+	// Press 't' to open the task list of the selected project
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	pm := updatedModel.(ProjectsModel)
+
+	if _, ok := pm.ActiveModal.(TaskListModalWrapper); !ok {
+		t.Fatal("expected TaskListModalWrapper")
+	}
+
+	pm, _ = pm.handleTaskCreateRequested(TaskCreateRequestedMsg{ProjectID: project.ID})
+	if _, ok := pm.ActiveModal.(TaskCreateModalWrapper); !ok {
+		t.Fatal("expected TaskCreateModalWrapper")
+	}
+
+	pm, _ = pm.handleTaskCreated(TaskCreatedMsg{ProjectID: project.ID, Name: "API refactor", OdooID: 42})
+
+	wrapper, ok := pm.ActiveModal.(TaskListModalWrapper)
+	if !ok {
+		t.Fatal("expected task list to be restored after creation")
+	}
+	if len(wrapper.Tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(wrapper.Tasks))
+	}
+	task := wrapper.Tasks[0]
+	if task.Name != "API refactor" || task.OdooID != 42 {
+		t.Errorf("got task %+v, want API refactor with Odoo ID 42", task)
+	}
+
+	pm, _ = pm.handleTaskEditRequested(TaskEditRequestedMsg{Task: task})
+	pm, _ = pm.handleTaskEdited(TaskEditedMsg{TaskID: task.ID, ProjectID: project.ID, Name: "Code review"})
+
+	tasks, _ := repository.GetTasksByProject(project.ID)
+	if len(tasks) != 1 || tasks[0].Name != "Code review" || tasks[0].OdooID != 0 {
+		t.Errorf("got tasks %+v, want one Code review task without Odoo ID", tasks)
+	}
+
+	pm, _ = pm.handleTaskDeleteRequested(TaskDeleteRequestedMsg{Task: tasks[0]})
+	pm, _ = pm.handleTaskDeleted(TaskDeletedMsg{TaskID: task.ID})
+
+	wrapper = pm.ActiveModal.(TaskListModalWrapper)
+	if len(wrapper.Tasks) != 0 {
+		t.Errorf("expected no tasks after delete, got %d", len(wrapper.Tasks))
+	}
+
+	updatedModel, _ = pm.Update(TaskListClosedMsg{})
+	if updatedModel.(ProjectsModel).ActiveModal != nil {
+		t.Error("expected task list to be closed")
+	}
+}
+
+func TestProjectsModel_TaskDeleteKeepsWorkhours(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	project := repository.CreateTestProject(t, 1, "Arnia", 100)
+	details := repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	task := repository.CreateTestTask(t, project.ID, "API refactor", 0)
+
+	_, _, err := repository.CreateWorkhour(domain.Workhour{
+		Date:      time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local),
+		DetailsID: details.ID,
+		ProjectID: project.ID,
+		TaskID:    task.ID,
+		Hours:     6,
+	})
+	if err != nil {
+		t.Fatalf("failed to create workhour: %v", err)
+	}
+
+	taskHours, _ := repository.GetTaskHours(project.ID)
+	if taskHours[task.ID] != 6 {
+		t.Errorf("got %v task hours, want 6", taskHours[task.ID])
+	}
+
+	m := NewProjectsModel()
+	m.handleTaskDeleted(TaskDeletedMsg{TaskID: task.ID})
+
+	workhours, _ := repository.GetAllWorkhours()
+	if len(workhours) != 1 {
+		t.Fatalf("expected workhour to be kept, got %d", len(workhours))
+	}
+	if workhours[0].TaskID != 0 {
+		t.Errorf("expected task to be cleared, got %d", workhours[0].TaskID)
+	}
+
+	history, _ := repository.GetWorkhourHistory(workhours[0].Date, workhours[0].Date)
+	if last := history[len(history)-1]; last.Action != domain.ChangeUpdate || last.Old.TaskID != task.ID || last.New.TaskID != 0 {
+		t.Errorf("expected the cleared task in the history, got %+v", last)
+	}
+}
+
+func TestProjectsModel_HandleProjectCreated_WithClient(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	client := repository.CreateTestClient(t, "Arnia", "Arnia Software S.R.L.")
+
+	m := NewProjectsModel()
+	updatedModel, _ := m.handleProjectCreated(ProjectCreatedMsg{Name: "API", OdooID: 100, ClientID: client.ID})
+
+	if len(updatedModel.Projects) != 1 {
+		t.Fatalf("got %d projects, want 1", len(updatedModel.Projects))
+	}
+	if updatedModel.Projects[0].ClientID != client.ID {
+		t.Errorf("got client %d, want %d", updatedModel.Projects[0].ClientID, client.ID)
+	}
+	if got := updatedModel.clientName(client.ID); got != "Arnia" {
+		t.Errorf("got client name %q, want Arnia", got)
+	}
+}
+
+func TestProjectsModel_HandleProjectEdited_WithBudget(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	repository.CreateTestProject(t, 1, "API", 100)
+	repository.CreateTestWorkhourDetails(t, 1, "Development", "DEV", true)
+	repository.CreateTestWorkhour(t, time.Now(), 1, 1, 6)
+
+	m := NewProjectsModel()
+	updatedModel, _ := m.handleProjectEdited(ProjectEditedMsg{
+		ProjectID:    1,
+		Name:         "API",
+		OdooID:       100,
+		BudgetHours:  8,
+		BudgetPeriod: domain.BudgetPeriodMonthly,
+	})
+
+	project := updatedModel.Projects[0]
+	if project.BudgetHours != 8 || project.BudgetPeriod != domain.BudgetPeriodMonthly {
+		t.Fatalf("got budget %gh/%v, want 8h/Monthly", project.BudgetHours, project.BudgetPeriod)
+	}
+
+	row := updatedModel.TableView.Table.Rows()[0]
+	if row[4] != "8h/mo" || row[5] != "6h (75%)" || row[6] != "2h" {
+		t.Errorf("got budget columns %q %q %q", row[4], row[5], row[6])
+	}
+}
+
+func TestProjectEditModal_BudgetInputs(t *testing.T) {
+	project := domain.Project{ID: 1, Name: "API", OdooID: 100, BudgetHours: 40, BudgetPeriod: domain.BudgetPeriodQuarterly}
+	modal := NewProjectEditModal(project, nil)
+
+	if got := modal.Form.GetField(3).Value(); got != "40" {
+		t.Errorf("got budget field %q, want 40", got)
+	}
+
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command on submit")
+	}
+	msg, ok := cmd().(ProjectEditedMsg)
+	if !ok {
+		t.Fatalf("expected ProjectEditedMsg, got %T", cmd())
+	}
+	if msg.BudgetHours != 40 || msg.BudgetPeriod != domain.BudgetPeriodQuarterly {
+		t.Errorf("got budget %gh/%v, want 40h/Quarterly", msg.BudgetHours, msg.BudgetPeriod)
+	}
+}
+
+func TestProjectsModel_OpenBudgetBurnDown(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	project := repository.CreateTestProject(t, 1, "API", 100)
+	project.BudgetHours = 20
+	if err := repository.UpdateProject(project); err != nil {
+		t.Fatalf("failed to set budget: %v", err)
+	}
+
+	m := NewProjectsModel()
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	pm := updatedModel.(ProjectsModel)
+
+	wrapper, ok := pm.ActiveModal.(BudgetBurnDownModalWrapper)
+	if !ok {
+		t.Fatalf("expected budget burn-down modal, got %T", pm.ActiveModal)
+	}
+	if wrapper.Status == nil || wrapper.Status.RemainingHours() != 20 {
+		t.Errorf("expected 20h remaining, got %+v", wrapper.Status)
+	}
+
+	updatedModel, _ = pm.Update(BudgetBurnDownClosedMsg{})
+	if updatedModel.(ProjectsModel).ActiveModal != nil {
+		t.Error("expected modal to be closed")
+	}
+}
@@ -0,0 +1,120 @@
+package projects
+
+import (
+	"strconv"
+	"strings"
+	"tltui/src/common"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type TaskCreateModal struct {
+	ProjectID   int
+	ProjectName string
+	Form        *common.MixedForm
+}
+
+type TaskCreatedMsg struct {
+	ProjectID int
+	Name      string
+	OdooID    int
+}
+
+type TaskCreateCanceledMsg struct{}
+
+// newTaskForm builds the form shared by the task create and edit modals
+func newTaskForm(name string, odooID int) *common.MixedForm {
+	nameField := common.NewRequiredFormField("Name", "Task Name", 40).
+		WithInitialValue(name).
+		WithValidator(common.ChainValidators(
+			common.MinLengthValidator("Name", 2),
+			common.MaxLengthValidator("Name", 50),
+		))
+
+	odooIDValue := ""
+	if odooID != 0 {
+		odooIDValue = strconv.Itoa(odooID)
+	}
+	odooIDField := common.NewFormField("Odoo Task ID", "optional", 40).
+		WithCharLimit(10).
+		WithValidator(common.OptionalValidator(common.PositiveIntValidator("Odoo Task ID"))).
+		WithInitialValue(odooIDValue)
+
+	return common.NewMixedForm(&nameField, &odooIDField)
+}
+
+// parseTaskForm reads a validated task form, an empty Odoo ID becomes 0
+func parseTaskForm(form *common.MixedForm) (string, int) {
+	name := strings.TrimSpace(form.GetField(0).Value())
+	odooID, _ := strconv.Atoi(strings.TrimSpace(form.GetField(1).Value()))
+	return name, odooID
+}
+
+func NewTaskCreateModal(projectID int, projectName string) *TaskCreateModal {
+	return &TaskCreateModal{
+		ProjectID:   projectID,
+		ProjectName: projectName,
+		Form:        newTaskForm("", 0),
+	}
+}
+
+func (m *TaskCreateModal) Update(msg tea.Msg) (TaskCreateModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+
+			name, odooID := parseTaskForm(m.Form)
+			return *m, tea.Batch(
+				dispatchTaskCreatedMsg(m.ProjectID, name, odooID),
+			)
+
+		case "esc":
+			return *m, tea.Batch(
+				dispatchTaskCreateCanceledMsg(),
+			)
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *TaskCreateModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	sb.WriteString(titleStyle.Render("New Task - " + m.ProjectName))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Tab/Shift+Tab: navigate", "Enter: create", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchTaskCreatedMsg(projectID int, name string, odooID int) tea.Cmd {
+	return func() tea.Msg {
+		return TaskCreatedMsg{
+			ProjectID: projectID,
+			Name:      name,
+			OdooID:    odooID,
+		}
+	}
+}
+
+func dispatchTaskCreateCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return TaskCreateCanceledMsg{}
+	}
+}
@@ -0,0 +1,94 @@
+package projects
+
+import (
+	"strings"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type TaskDeleteModal struct {
+	TaskID   int
+	TaskName string
+}
+
+type TaskDeletedMsg struct {
+	TaskID int
+}
+
+type TaskDeleteCanceledMsg struct{}
+
+func NewTaskDeleteModal(taskID int, taskName string) *TaskDeleteModal {
+	return &TaskDeleteModal{
+		TaskID:   taskID,
+		TaskName: taskName,
+	}
+}
+
+func (m *TaskDeleteModal) Update(msg tea.Msg) (TaskDeleteModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y", "enter":
+			return *m, tea.Batch(
+				dispatchTaskDeletedMsg(m.TaskID),
+			)
+
+		case "n", "N", "esc":
+			return *m, tea.Batch(
+				dispatchTaskDeleteCanceledMsg(),
+			)
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *TaskDeleteModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("196")).
+		MarginBottom(1)
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("241"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	sb.WriteString(titleStyle.Render("⚠ Delete Task"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(warningStyle.Render("Are you sure you want to delete this task?"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Name: "))
+	sb.WriteString(m.TaskName)
+	sb.WriteString("\n\n")
+
+	sb.WriteString(labelStyle.Render("Hours logged on this task are kept without a task."))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(render.RenderHelpText("Y/Enter: confirm delete", "N/ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchTaskDeletedMsg(taskID int) tea.Cmd {
+	return func() tea.Msg {
+		return TaskDeletedMsg{
+			TaskID: taskID,
+		}
+	}
+}
+
+func dispatchTaskDeleteCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return TaskDeleteCanceledMsg{}
+	}
+}
@@ -0,0 +1,93 @@
+package projects
+
+import (
+	"strings"
+	"tltui/src/common"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type TaskEditModal struct {
+	EditingTaskID int
+	ProjectID     int
+	Form          *common.MixedForm
+}
+
+type TaskEditedMsg struct {
+	TaskID    int
+	ProjectID int
+	Name      string
+	OdooID    int
+}
+
+type TaskEditCanceledMsg struct{}
+
+func NewTaskEditModal(taskID int, projectID int, name string, odooID int) *TaskEditModal {
+	return &TaskEditModal{
+		EditingTaskID: taskID,
+		ProjectID:     projectID,
+		Form:          newTaskForm(name, odooID),
+	}
+}
+
+func (m *TaskEditModal) Update(msg tea.Msg) (TaskEditModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if err := m.Form.Validate(); err != nil {
+				return *m, nil
+			}
+
+			name, odooID := parseTaskForm(m.Form)
+			return *m, tea.Batch(
+				dispatchTaskEditedMsg(m.EditingTaskID, m.ProjectID, name, odooID),
+			)
+
+		case "esc":
+			return *m, tea.Batch(
+				dispatchTaskEditCanceledMsg(),
+			)
+		}
+	}
+
+	cmd := m.Form.Update(msg)
+	return *m, cmd
+}
+
+func (m *TaskEditModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	sb.WriteString(titleStyle.Render("Edit Task"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.Form.View())
+
+	sb.WriteString(render.RenderHelpText("Tab/Shift+Tab: navigate", "Enter: save", "ESC: cancel"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchTaskEditedMsg(taskID int, projectID int, name string, odooID int) tea.Cmd {
+	return func() tea.Msg {
+		return TaskEditedMsg{
+			TaskID:    taskID,
+			ProjectID: projectID,
+			Name:      name,
+			OdooID:    odooID,
+		}
+	}
+}
+
+func dispatchTaskEditCanceledMsg() tea.Cmd {
+	return func() tea.Msg {
+		return TaskEditCanceledMsg{}
+	}
+}
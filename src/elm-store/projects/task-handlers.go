@@ -0,0 +1,110 @@
+package projects
+
+import (
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m ProjectsModel) handleOpenTaskList() (ProjectsModel, tea.Cmd) {
+	selectedProject := m.getSelectedProject()
+	if selectedProject == nil {
+		return m, nil
+	}
+
+	m.ActiveModal = TaskListModalWrapper{NewTaskListModal(selectedProject.ID, selectedProject.Name)}
+	return m, nil
+}
+
+func (m ProjectsModel) handleTaskCreateRequested(msg TaskCreateRequestedMsg) (ProjectsModel, tea.Cmd) {
+	if wrapper, ok := m.ActiveModal.(TaskListModalWrapper); ok {
+		m.TaskListParent = wrapper.TaskListModal
+	}
+
+	projectName := ""
+	if m.TaskListParent != nil {
+		projectName = m.TaskListParent.ProjectName
+	}
+	m.ActiveModal = TaskCreateModalWrapper{NewTaskCreateModal(msg.ProjectID, projectName)}
+	return m, nil
+}
+
+func (m ProjectsModel) handleTaskEditRequested(msg TaskEditRequestedMsg) (ProjectsModel, tea.Cmd) {
+	if wrapper, ok := m.ActiveModal.(TaskListModalWrapper); ok {
+		m.TaskListParent = wrapper.TaskListModal
+	}
+
+	m.ActiveModal = TaskEditModalWrapper{NewTaskEditModal(
+		msg.Task.ID,
+		msg.Task.ProjectID,
+		msg.Task.Name,
+		msg.Task.OdooID,
+	)}
+	return m, nil
+}
+
+func (m ProjectsModel) handleTaskDeleteRequested(msg TaskDeleteRequestedMsg) (ProjectsModel, tea.Cmd) {
+	if wrapper, ok := m.ActiveModal.(TaskListModalWrapper); ok {
+		m.TaskListParent = wrapper.TaskListModal
+	}
+
+	m.ActiveModal = TaskDeleteModalWrapper{NewTaskDeleteModal(msg.Task.ID, msg.Task.Name)}
+	return m, nil
+}
+
+func (m ProjectsModel) handleTaskCreated(msg TaskCreatedMsg) (ProjectsModel, tea.Cmd) {
+	newTask := domain.Task{
+		ProjectID: msg.ProjectID,
+		Name:      msg.Name,
+		OdooID:    msg.OdooID,
+	}
+
+	if _, err := repository.CreateTask(newTask); err != nil {
+		m.restoreTaskList()
+		return m, common.NotifyError("Failed to create task", err)
+	}
+
+	m.restoreTaskList()
+	return m, nil
+}
+
+func (m ProjectsModel) handleTaskEdited(msg TaskEditedMsg) (ProjectsModel, tea.Cmd) {
+	updatedTask := domain.Task{
+		ID:        msg.TaskID,
+		ProjectID: msg.ProjectID,
+		Name:      msg.Name,
+		OdooID:    msg.OdooID,
+	}
+
+	if err := repository.UpdateTask(updatedTask); err != nil {
+		m.restoreTaskList()
+		return m, common.NotifyError("Failed to update task", err)
+	}
+
+	m.restoreTaskList()
+	return m, nil
+}
+
+func (m ProjectsModel) handleTaskDeleted(msg TaskDeletedMsg) (ProjectsModel, tea.Cmd) {
+	if err := repository.DeleteTask(msg.TaskID); err != nil {
+		m.restoreTaskList()
+		return m, common.NotifyError("Failed to delete task", err)
+	}
+
+	m.restoreTaskList()
+	return m, nil
+}
+
+// restoreTaskList reopens the task list a task modal was opened from, with fresh data
+func (m *ProjectsModel) restoreTaskList() {
+	if m.TaskListParent == nil {
+		m.ActiveModal = nil
+		return
+	}
+
+	m.TaskListParent.Reload()
+	m.ActiveModal = TaskListModalWrapper{m.TaskListParent}
+	m.TaskListParent = nil
+}
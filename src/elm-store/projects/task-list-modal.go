@@ -0,0 +1,195 @@
+package projects
+
+import (
+	"fmt"
+	"strings"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TaskListModal lists the tasks of a project and requests their create/edit/delete modals
+type TaskListModal struct {
+	ProjectID   int
+	ProjectName string
+
+	Tasks         []domain.Task
+	TaskHours     map[int]float64
+	SelectedIndex int
+	ErrorMessage  string
+}
+
+type TaskListClosedMsg struct{}
+
+type TaskCreateRequestedMsg struct {
+	ProjectID int
+}
+
+type TaskEditRequestedMsg struct {
+	Task domain.Task
+}
+
+type TaskDeleteRequestedMsg struct {
+	Task domain.Task
+}
+
+func NewTaskListModal(projectID int, projectName string) *TaskListModal {
+	m := &TaskListModal{
+		ProjectID:   projectID,
+		ProjectName: projectName,
+	}
+	m.Reload()
+	return m
+}
+
+// Reload refreshes the tasks and their logged hours from the database
+func (m *TaskListModal) Reload() {
+	m.ErrorMessage = ""
+
+	tasks, err := repository.GetTasksByProject(m.ProjectID)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		tasks = []domain.Task{}
+	}
+	m.Tasks = tasks
+
+	taskHours, err := repository.GetTaskHours(m.ProjectID)
+	if err != nil {
+		m.ErrorMessage = err.Error()
+		taskHours = map[int]float64{}
+	}
+	m.TaskHours = taskHours
+
+	if m.SelectedIndex >= len(m.Tasks) {
+		m.SelectedIndex = max(len(m.Tasks)-1, 0)
+	}
+}
+
+func (m *TaskListModal) Update(msg tea.Msg) (TaskListModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return *m, dispatchTaskListClosedMsg()
+
+		case "up", "k":
+			if m.SelectedIndex > 0 {
+				m.SelectedIndex--
+			}
+		case "down", "j":
+			if m.SelectedIndex < len(m.Tasks)-1 {
+				m.SelectedIndex++
+			}
+
+		case "n":
+			return *m, dispatchTaskCreateRequestedMsg(m.ProjectID)
+
+		case "e", "enter":
+			if task := m.selectedTask(); task != nil {
+				return *m, dispatchTaskEditRequestedMsg(*task)
+			}
+
+		case "d":
+			if task := m.selectedTask(); task != nil {
+				return *m, dispatchTaskDeleteRequestedMsg(*task)
+			}
+		}
+	}
+
+	return *m, nil
+}
+
+func (m *TaskListModal) selectedTask() *domain.Task {
+	if m.SelectedIndex >= 0 && m.SelectedIndex < len(m.Tasks) {
+		return &m.Tasks[m.SelectedIndex]
+	}
+	return nil
+}
+
+func (m *TaskListModal) View(Width, Height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39")).
+		MarginBottom(1)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("241"))
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("255"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	emptyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Italic(true)
+
+	sb.WriteString(titleStyle.Render("Tasks - " + m.ProjectName))
+	sb.WriteString("\n\n")
+
+	if m.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + m.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	if len(m.Tasks) == 0 {
+		sb.WriteString(emptyStyle.Render("No tasks for this project"))
+		sb.WriteString("\n\n")
+	} else {
+		sb.WriteString(headerStyle.Render(fmt.Sprintf("  %-30s %10s %8s", "Task", "Odoo ID", "Hours")))
+		sb.WriteString("\n")
+
+		for i, task := range m.Tasks {
+			odooID := "-"
+			if task.OdooID != 0 {
+				odooID = fmt.Sprintf("%d", task.OdooID)
+			}
+			line := fmt.Sprintf("%-30s %10s %7gh", task.Name, odooID, m.TaskHours[task.ID])
+
+			if i == m.SelectedIndex {
+				sb.WriteString(selectedStyle.Render("▶ " + line))
+			} else {
+				sb.WriteString(valueStyle.Render("  " + line))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(render.RenderHelpText("↑/↓: navigate", "n: new", "enter: edit", "d: delete", "ESC: close"))
+
+	return render.RenderSimpleModal(Width, Height, sb.String())
+}
+
+func dispatchTaskListClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return TaskListClosedMsg{}
+	}
+}
+
+func dispatchTaskCreateRequestedMsg(projectID int) tea.Cmd {
+	return func() tea.Msg {
+		return TaskCreateRequestedMsg{ProjectID: projectID}
+	}
+}
+
+func dispatchTaskEditRequestedMsg(task domain.Task) tea.Cmd {
+	return func() tea.Msg {
+		return TaskEditRequestedMsg{Task: task}
+	}
+}
+
+func dispatchTaskDeleteRequestedMsg(task domain.Task) tea.Cmd {
+	return func() tea.Msg {
+		return TaskDeleteRequestedMsg{Task: task}
+	}
+}
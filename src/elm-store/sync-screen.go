@@ -0,0 +1,218 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"tltui/src/common"
+	"tltui/src/domain"
+	"tltui/src/domain/repository"
+	"tltui/src/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SyncScreen shows the sync folder and lets the conflicts found by syncing be reviewed
+type SyncScreen struct {
+	Dir           string
+	Replica       string
+	Conflicts     []domain.SyncConflict
+	SelectedIndex int
+	Syncing       bool
+	ErrorMessage  string
+
+	projects        []domain.Project
+	workhourDetails []domain.WorkhourDetails
+}
+
+// SyncFinishedMsg reports a sync run, started from the screen or at startup
+type SyncFinishedMsg struct {
+	Result domain.SyncResult
+	Err    error
+}
+
+// SyncConflictResolvedMsg is sent after a conflict was reviewed, the workhours may have changed
+type SyncConflictResolvedMsg struct{}
+
+type SyncScreenClosedMsg struct{}
+
+func NewSyncScreen() *SyncScreen {
+	s := &SyncScreen{}
+	s.projects, _ = repository.GetAllProjectsFromDB()
+	s.workhourDetails, _ = repository.GetAllWorkhourDetailsFromDB()
+	s.Reload()
+	return s
+}
+
+// Reload reads the sync settings and the open conflicts again
+func (s *SyncScreen) Reload() {
+	dir, replica, err := repository.GetSyncConfig()
+	if err != nil {
+		s.ErrorMessage = err.Error()
+		return
+	}
+	s.Dir, s.Replica = dir, replica
+
+	conflicts, err := repository.GetSyncConflicts()
+	if err != nil {
+		s.ErrorMessage = err.Error()
+		return
+	}
+	s.Conflicts = conflicts
+	if s.SelectedIndex >= len(s.Conflicts) {
+		s.SelectedIndex = max(len(s.Conflicts)-1, 0)
+	}
+}
+
+func (s *SyncScreen) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q", "S":
+			return dispatchSyncScreenClosedMsg()
+		case "up", "k":
+			if s.SelectedIndex > 0 {
+				s.SelectedIndex--
+			}
+		case "down", "j":
+			if s.SelectedIndex < len(s.Conflicts)-1 {
+				s.SelectedIndex++
+			}
+		case "s":
+			if repository.IsReadOnly() {
+				return notifyReadOnly()
+			}
+			if s.Dir == "" || s.Syncing {
+				return nil
+			}
+			s.Syncing = true
+			s.ErrorMessage = ""
+			return runSync
+		case "enter", "o":
+			if len(s.Conflicts) == 0 {
+				return nil
+			}
+			if repository.IsReadOnly() {
+				return notifyReadOnly()
+			}
+			return s.resolve(msg.String() == "o")
+		}
+	}
+	return nil
+}
+
+// resolve marks the selected conflict as reviewed, switching to the discarded value with useOther
+func (s *SyncScreen) resolve(useOther bool) tea.Cmd {
+	conflict := s.Conflicts[s.SelectedIndex]
+	if err := repository.ResolveSyncConflict(conflict.ID, useOther); err != nil {
+		s.ErrorMessage = err.Error()
+		return common.NotifyError("Failed to resolve conflict", err)
+	}
+
+	s.Reload()
+	message := "Kept the current value"
+	if useOther {
+		message = "Switched to the discarded value, the next sync shares it"
+	}
+	return tea.Batch(
+		func() tea.Msg { return SyncConflictResolvedMsg{} },
+		common.NotifySuccess(message),
+	)
+}
+
+func (s *SyncScreen) View(width, height int) string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).MarginBottom(1)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	keptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	otherStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+
+	sb.WriteString(titleStyle.Render("Sync"))
+	sb.WriteString("\n\n")
+
+	if s.ErrorMessage != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		sb.WriteString(errorStyle.Render("⚠ " + s.ErrorMessage))
+		sb.WriteString("\n\n")
+	}
+
+	if s.Dir == "" {
+		sb.WriteString("Sync is not set up.\n\n")
+		sb.WriteString(mutedStyle.Render("Choose a shared folder with 'tltui sync init FOLDER'."))
+		sb.WriteString("\n\n")
+		sb.WriteString(render.RenderHelpText("esc: close"))
+		return render.RenderSimpleModal(width, height, sb.String())
+	}
+
+	sb.WriteString(fmt.Sprintf("Folder:  %s\n", s.Dir))
+	sb.WriteString(mutedStyle.Render(fmt.Sprintf("Replica: %s", s.Replica)))
+	sb.WriteString("\n\n")
+	if s.Syncing {
+		sb.WriteString(mutedStyle.Render("Syncing..."))
+		sb.WriteString("\n\n")
+	}
+
+	if len(s.Conflicts) == 0 {
+		sb.WriteString(keptStyle.Render("No conflicts"))
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("%d conflicts, the newest change was kept:\n\n", len(s.Conflicts)))
+		for i, c := range s.Conflicts {
+			marker := "  "
+			header := fmt.Sprintf("#%d  detected %s, kept the %s value", c.ID, c.DetectedAt.Format("2006-01-02 15:04"), c.Kept)
+			if i == s.SelectedIndex {
+				marker = "▶ "
+				header = selectedStyle.Render(header)
+			}
+			sb.WriteString(marker + header + "\n")
+			sb.WriteString("    " + keptStyle.Render("kept:      "+s.describe(c.KeptValue())) + "\n")
+			sb.WriteString("    " + otherStyle.Render("discarded: "+s.describe(c.OtherValue())) + "\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(render.RenderHelpText("↑/↓: select", "enter: keep", "o: use discarded", "s: sync now", "esc: close"))
+
+	return render.RenderSimpleModal(width, height, sb.String())
+}
+
+// describe formats a conflicting value, e.g. "2025-03-04 DEV 2h Arnia API 09:00-11:00"
+func (s *SyncScreen) describe(wh *domain.Workhour) string {
+	if wh == nil {
+		return "deleted"
+	}
+
+	typeName := fmt.Sprintf("type %d", wh.DetailsID)
+	for _, d := range s.workhourDetails {
+		if d.ID == wh.DetailsID {
+			typeName = d.ShortName
+			break
+		}
+	}
+	projectName := fmt.Sprintf("project %d", wh.ProjectID)
+	for _, p := range s.projects {
+		if p.ID == wh.ProjectID {
+			projectName = p.Name
+			break
+		}
+	}
+
+	desc := fmt.Sprintf("%s %s %gh %s", wh.Date.Format("2006-01-02"), typeName, math.Round(wh.Hours*100)/100, projectName)
+	if wh.HasTimes() {
+		desc += " " + wh.TimeRange()
+	}
+	return desc
+}
+
+func runSync() tea.Msg {
+	result, err := repository.Sync()
+	return SyncFinishedMsg{Result: result, Err: err}
+}
+
+func dispatchSyncScreenClosedMsg() tea.Cmd {
+	return func() tea.Msg {
+		return SyncScreenClosedMsg{}
+	}
+}
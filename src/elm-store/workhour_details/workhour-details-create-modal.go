@@ -3,6 +3,7 @@ package workhour_details
 import (
 	"strings"
 	"tltui/src/common"
+	"tltui/src/domain"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,9 +15,10 @@ type WorkhourDetailsCreateModal struct {
 }
 
 type WorkhourDetailsCreatedMsg struct {
-	Name      string
-	ShortName string
-	IsWork    bool
+	Name         string
+	ShortName    string
+	IsWork       bool
+	OvertimeRole domain.OvertimeRole
 }
 
 type WorkhourDetailsCreateCanceledMsg struct{}
@@ -30,7 +32,9 @@ func NewWorkhourDetailsCreateModal() *WorkhourDetailsCreateModal {
 	isWorkCheckbox := common.NewFormCheckbox("Is Work", true).
 		WithHelpText("included in mail report")
 
-	form := common.NewMixedForm(&nameField, &shortNameField, isWorkCheckbox)
+	overtimeRoleSelect := newOvertimeRoleSelect(domain.OvertimeRoleNone)
+
+	form := common.NewMixedForm(&nameField, &shortNameField, isWorkCheckbox, overtimeRoleSelect)
 
 	return &WorkhourDetailsCreateModal{
 		Form: form,
@@ -49,13 +53,15 @@ func (m *WorkhourDetailsCreateModal) Update(msg tea.Msg) (WorkhourDetailsCreateM
 			nameField := m.Form.GetField(0)
 			shortNameField := m.Form.GetField(1)
 			isWorkCheckbox := m.Form.GetCheckbox(2)
+			overtimeRoleSelect := m.Form.GetSelect(3)
 
 			name := strings.TrimSpace(nameField.Value())
 			shortName := strings.TrimSpace(shortNameField.Value())
 			isWork := isWorkCheckbox.Value
+			overtimeRole := domain.OvertimeRole(overtimeRoleSelect.GetSelectedID())
 
 			return *m, tea.Batch(
-				dispatchWorkhourDetailsCreatedMsg(name, shortName, isWork),
+				dispatchWorkhourDetailsCreatedMsg(name, shortName, isWork, overtimeRole),
 			)
 
 		case "esc":
@@ -87,12 +93,13 @@ func (m *WorkhourDetailsCreateModal) View(Width, Height int) string {
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
-func dispatchWorkhourDetailsCreatedMsg(name string, shortName string, isWork bool) tea.Cmd {
+func dispatchWorkhourDetailsCreatedMsg(name string, shortName string, isWork bool, overtimeRole domain.OvertimeRole) tea.Cmd {
 	return func() tea.Msg {
 		return WorkhourDetailsCreatedMsg{
-			Name:      name,
-			ShortName: shortName,
-			IsWork:    isWork,
+			Name:         name,
+			ShortName:    shortName,
+			IsWork:       isWork,
+			OvertimeRole: overtimeRole,
 		}
 	}
 }
@@ -102,3 +109,31 @@ func dispatchWorkhourDetailsCreateCanceledMsg() tea.Cmd {
 		return WorkhourDetailsCreateCanceledMsg{}
 	}
 }
+
+// newOvertimeRoleSelect builds the select used to pick how a type affects the overtime balance
+func newOvertimeRoleSelect(selected domain.OvertimeRole) *common.FormSelect {
+	options := []common.SelectOption{
+		{ID: int(domain.OvertimeRoleNone), DisplayName: overtimeRoleLabel(domain.OvertimeRoleNone), ExtraInfo: "counts towards the daily target"},
+		{ID: int(domain.OvertimeRoleOvertime), DisplayName: overtimeRoleLabel(domain.OvertimeRoleOvertime), ExtraInfo: "adds to the balance"},
+		{ID: int(domain.OvertimeRoleTimeOffInLieu), DisplayName: overtimeRoleLabel(domain.OvertimeRoleTimeOffInLieu), ExtraInfo: "consumes the balance"},
+	}
+
+	roleSelect := common.NewRequiredFormSelect("Overtime Role", options)
+	for i, option := range options {
+		if option.ID == int(selected) {
+			roleSelect.SelectedIndex = i
+		}
+	}
+	return roleSelect
+}
+
+func overtimeRoleLabel(role domain.OvertimeRole) string {
+	switch role {
+	case domain.OvertimeRoleOvertime:
+		return "Overtime"
+	case domain.OvertimeRoleTimeOffInLieu:
+		return "Time off in lieu"
+	default:
+		return "Regular"
+	}
+}
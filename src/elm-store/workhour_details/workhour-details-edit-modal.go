@@ -3,6 +3,7 @@ package workhour_details
 import (
 	"strings"
 	"tltui/src/common"
+	"tltui/src/domain"
 	"tltui/src/render"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,11 +20,12 @@ type WorkhourDetailsEditedMsg struct {
 	Name             string
 	ShortName        string
 	IsWork           bool
+	OvertimeRole     domain.OvertimeRole
 }
 
 type WorkhourDetailsEditCanceledMsg struct{}
 
-func NewWorkhourDetailsEditModal(workhourDetailID int, name string, shortName string, isWork bool) *WorkhourDetailsEditModal {
+func NewWorkhourDetailsEditModal(workhourDetailID int, name string, shortName string, isWork bool, overtimeRole domain.OvertimeRole) *WorkhourDetailsEditModal {
 	nameField := common.NewRequiredFormField("Name", "Name", 40).
 		WithInitialValue(name)
 
@@ -35,7 +37,9 @@ func NewWorkhourDetailsEditModal(workhourDetailID int, name string, shortName st
 	isWorkCheckbox := common.NewFormCheckbox("Is Work", isWork).
 		WithHelpText("included in mail report")
 
-	form := common.NewMixedForm(&nameField, &shortNameField, isWorkCheckbox)
+	overtimeRoleSelect := newOvertimeRoleSelect(overtimeRole)
+
+	form := common.NewMixedForm(&nameField, &shortNameField, isWorkCheckbox, overtimeRoleSelect)
 
 	return &WorkhourDetailsEditModal{
 		EditingWorkhourDetailID: workhourDetailID,
@@ -55,13 +59,15 @@ func (m *WorkhourDetailsEditModal) Update(msg tea.Msg) (WorkhourDetailsEditModal
 			nameField := m.Form.GetField(0)
 			shortNameField := m.Form.GetField(1)
 			isWorkCheckbox := m.Form.GetCheckbox(2)
+			overtimeRoleSelect := m.Form.GetSelect(3)
 
 			name := strings.TrimSpace(nameField.Value())
 			shortName := strings.TrimSpace(shortNameField.Value())
 			isWork := isWorkCheckbox.Value
+			overtimeRole := domain.OvertimeRole(overtimeRoleSelect.GetSelectedID())
 
 			return *m, tea.Batch(
-				dispatchWorkhourDetailsEditedMsg(m.EditingWorkhourDetailID, name, shortName, isWork),
+				dispatchWorkhourDetailsEditedMsg(m.EditingWorkhourDetailID, name, shortName, isWork, overtimeRole),
 			)
 
 		case "esc":
@@ -93,13 +99,14 @@ func (m *WorkhourDetailsEditModal) View(Width, Height int) string {
 	return render.RenderSimpleModal(Width, Height, sb.String())
 }
 
-func dispatchWorkhourDetailsEditedMsg(workhourDetailID int, name string, shortName string, isWork bool) tea.Cmd {
+func dispatchWorkhourDetailsEditedMsg(workhourDetailID int, name string, shortName string, isWork bool, overtimeRole domain.OvertimeRole) tea.Cmd {
 	return func() tea.Msg {
 		return WorkhourDetailsEditedMsg{
 			WorkhourDetailID: workhourDetailID,
 			Name:             name,
 			ShortName:        shortName,
 			IsWork:           isWork,
+			OvertimeRole:     overtimeRole,
 		}
 	}
 }
@@ -16,6 +16,8 @@ func (m WorkhourDetailsModel) handleWorkhourDetailCreated(msg WorkhourDetailsCre
 		Name:      msg.Name,
 		ShortName: msg.ShortName,
 		IsWork:    msg.IsWork,
+
+		OvertimeRole: msg.OvertimeRole,
 	}
 
 	err := repository.CreateWorkhourDetails(newWorkhourDetail)
@@ -43,6 +45,8 @@ func (m WorkhourDetailsModel) handleWorkhourDetailEdited(msg WorkhourDetailsEdit
 		Name:      msg.Name,
 		ShortName: msg.ShortName,
 		IsWork:    msg.IsWork,
+
+		OvertimeRole: msg.OvertimeRole,
 	}
 	err := repository.UpdateWorkhourDetails(updatedWorkhourDetail)
 	if err != nil {
@@ -93,7 +97,21 @@ func (m *WorkhourDetailsModel) updateTableRows() {
 			wd.Name,
 			wd.ShortName,
 			isWorkStr,
+			overtimeRoleLabel(wd.OvertimeRole),
 		})
 	}
 	m.TableView.SetRows(rows)
 }
+
+// Refresh reloads the workhour types, after they were changed elsewhere
+func (m *WorkhourDetailsModel) Refresh() {
+	workhourDetails, err := repository.GetAllWorkhourDetailsFromDB()
+	if err != nil {
+		return
+	}
+	m.WorkhourDetails = workhourDetails
+	for _, wd := range m.WorkhourDetails {
+		m.NextID = max(m.NextID, wd.ID+1)
+	}
+	m.updateTableRows()
+}
@@ -43,6 +43,7 @@ func NewWorkhourDetailsModel() WorkhourDetailsModel {
 		{Title: "Name", Width: 25},
 		{Title: "Short Name", Width: 15},
 		{Title: "Is Work", Width: 10},
+		{Title: "Overtime Role", Width: 18},
 	}
 
 	rows := []table.Row{}
@@ -56,6 +57,7 @@ func NewWorkhourDetailsModel() WorkhourDetailsModel {
 			wd.Name,
 			wd.ShortName,
 			isWorkStr,
+			overtimeRoleLabel(wd.OvertimeRole),
 		})
 	}
 
@@ -141,6 +143,7 @@ func (m WorkhourDetailsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						selectedWorkhourDetail.Name,
 						selectedWorkhourDetail.ShortName,
 						selectedWorkhourDetail.IsWork,
+						selectedWorkhourDetail.OvertimeRole,
 					)}
 					return m, nil
 				}
@@ -2,6 +2,7 @@ package workhour_details
 
 import (
 	"testing"
+	"tltui/src/domain"
 	"tltui/src/domain/repository"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,9 +13,9 @@ func TestWorkhourDetailsModel_HandleWorkhourDetailCreated(t *testing.T) {
 	defer cleanup()
 
 	tests := []struct {
-		name          string
-		msg           WorkhourDetailsCreatedMsg
-		wantCount     int
+		name            string
+		msg             WorkhourDetailsCreatedMsg
+		wantCount       int
 		wantModalClosed bool
 	}{
 		{
@@ -212,3 +213,20 @@ func TestWorkhourDetailsModel_GetSelectedWorkhourDetail(t *testing.T) {
 		t.Errorf("got name %q, want %q", selected.Name, "Detail 1")
 	}
 }
+
+func TestWorkhourDetailsModel_HandleWorkhourDetailCreated_OvertimeRole(t *testing.T) {
+	cleanup := repository.SetupTest(t)
+	defer cleanup()
+
+	m := NewWorkhourDetailsModel()
+	msg := WorkhourDetailsCreatedMsg{Name: "Time Off In Lieu", ShortName: "TOIL", OvertimeRole: domain.OvertimeRoleTimeOffInLieu}
+
+	updatedModel, _ := m.handleWorkhourDetailCreated(msg)
+
+	if len(updatedModel.WorkhourDetails) != 1 {
+		t.Fatalf("expected 1 workhour detail, got %d", len(updatedModel.WorkhourDetails))
+	}
+	if got := updatedModel.WorkhourDetails[0].OvertimeRole; got != domain.OvertimeRoleTimeOffInLieu {
+		t.Errorf("got overtime role %v, want %v", got, domain.OvertimeRoleTimeOffInLieu)
+	}
+}
@@ -3,42 +3,69 @@ package main
 import (
 	"fmt"
 	"os"
+	"tltui/src/cli"
 	"tltui/src/domain/repository"
 	store "tltui/src/elm-store"
-	"tltui/src/elm-store/calendar"
-	"tltui/src/elm-store/projects"
-	"tltui/src/elm-store/workhour_details"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func initModel() store.AppModel {
-	return store.AppModel{
-		Mode:            store.ModeViewCalendar,
-		Calendar:        calendar.NewCalendarModel(),
-		Projects:        projects.NewProjectsModel(),
-		WorkhourDetails: workhour_details.NewWorkhourDetailsModel(),
+func main() {
+	opts, args, err := cli.ParseOptions(os.Args[1:], os.Getenv)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
-}
 
-func main() {
-	if err := repository.InitDB(); err != nil {
+	repository.SetReadOnly(opts.ReadOnly)
+
+	if opts.DB != "" {
+		err = repository.InitDBAt(opts.DB)
+	} else {
+		if opts.Profile != "" {
+			exists, err := repository.ProfileExists(opts.Profile)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if !exists {
+				fmt.Printf("Profile %q does not exist, create it with 'tltui profile create %s'\n", opts.Profile, opts.Profile)
+				os.Exit(1)
+			}
+		}
+		err = repository.InitDB(opts.Profile)
+	}
+	if err != nil {
 		fmt.Printf("Failed to initialize database: %v\n", err)
 		os.Exit(1)
 	}
 	defer repository.CloseDB()
 
-	if err := repository.SeedProjects(); err != nil {
-		fmt.Printf("Failed to seed projects: %v\n", err)
-		os.Exit(1)
+	if !opts.ReadOnly {
+		if err := repository.SeedProjects(); err != nil {
+			fmt.Printf("Failed to seed projects: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := repository.SeedWorkhourDetails(); err != nil {
+			fmt.Printf("Failed to seed workhour details: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	if err := repository.SeedWorkhourDetails(); err != nil {
-		fmt.Printf("Failed to seed workhour details: %v\n", err)
-		os.Exit(1)
+	if len(args) > 0 {
+		if err := cli.Run(args, os.Stdout); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := repository.AutoBackup(); err != nil {
+		fmt.Printf("Warning: automatic backup failed: %v\n", err)
 	}
 
-	p := tea.NewProgram(initModel(), tea.WithAltScreen())
+	p := tea.NewProgram(store.NewAppModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
@@ -10,14 +10,29 @@ func RenderPageLayout(title, content string) string {
 	return fullContent
 }
 
-func RenderPageLayoutWithTabs(activeTabIndex int, content string) string {
+// RenderPageLayoutWithTabs renders the tab bar above the content. The tab bar ends
+// with the open profile or database and, when changes are disabled, a read-only badge.
+func RenderPageLayoutWithTabs(activeTabIndex int, location string, readOnly bool, content string) string {
 	tabs := []Tab{
 		{Key: "1", Label: "Calendar"},
 		{Key: "2", Label: "Projects"},
 		{Key: "3", Label: "Workhour Details"},
+		{Key: "4", Label: "Leave"},
+		{Key: "5", Label: "Clients"},
 	}
 
-	tabBar := RenderTabBar(tabs, activeTabIndex)
+	locationStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
+	badges := []string{locationStyle.Render(location)}
+	if readOnly {
+		readOnlyStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("230")).
+			Background(lipgloss.Color("160")).
+			Padding(0, 1)
+		badges = append(badges, readOnlyStyle.Render("read-only"))
+	}
+
+	tabBar := RenderTabBar(tabs, activeTabIndex, badges...)
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		tabBar,
@@ -11,7 +11,8 @@ type Tab struct {
 	Label string
 }
 
-func RenderTabBar(tabs []Tab, activeIndex int) string {
+// RenderTabBar renders the tabs, followed by badges such as the open profile
+func RenderTabBar(tabs []Tab, activeIndex int, badges ...string) string {
 	var sb strings.Builder
 
 	activeTabStyle := lipgloss.NewStyle().
@@ -42,5 +43,10 @@ func RenderTabBar(tabs []Tab, activeIndex int) string {
 		}
 	}
 
+	for _, badge := range badges {
+		sb.WriteString("    ")
+		sb.WriteString(badge)
+	}
+
 	return tabBarStyle.Render(sb.String())
 }